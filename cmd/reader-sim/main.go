@@ -0,0 +1,67 @@
+// reader-sim mensimulasikan reader RFID di gerbang untuk testing lokal. Setiap baris dari
+// stdin dianggap UID kartu yang ditempel dan dikirim ke POST /api/v1/badge-reader/tap.
+//
+//	go run ./cmd/reader-sim -key reader_xxx
+//	go run ./cmd/reader-sim -key reader_xxx -uid 04:A2:3B:1C
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	server := flag.String("server", "http://localhost:8080", "base URL server attendance")
+	key := flag.String("key", os.Getenv("READER_KEY"), "API key reader (default dari env READER_KEY)")
+	uid := flag.String("uid", "", "UID kartu untuk satu kali tap; kosong = baca UID dari stdin")
+	flag.Parse()
+
+	if *key == "" {
+		log.Fatal("reader key is required (-key or READER_KEY)")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	url := strings.TrimRight(*server, "/") + "/api/v1/badge-reader/tap"
+
+	if *uid != "" {
+		tap(client, url, *key, *uid)
+		return
+	}
+
+	fmt.Println("Tempelkan kartu (ketik UID lalu Enter, Ctrl+D untuk keluar)")
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			tap(client, url, *key, line)
+		}
+	}
+}
+
+func tap(client *http.Client, url, key, uid string) {
+	body, _ := json.Marshal(map[string]string{"uid": uid})
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("tap %s: %v", uid, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Reader-Key", key)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("tap %s: %v", uid, err)
+		return
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	fmt.Printf("[%d] %s\n", resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
@@ -1,13 +1,18 @@
 package config
 
 import (
+	"context"
 	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/event"
 	"employee-attendance-system/internal/middleware"
 	"employee-attendance-system/internal/repository"
 	route "employee-attendance-system/internal/route"
+	"employee-attendance-system/internal/scheduler"
+	"employee-attendance-system/internal/storage"
 	"employee-attendance-system/internal/usecase"
 	utils "employee-attendance-system/internal/util"
 	"log"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -30,26 +35,86 @@ func NewAppConfig(config *AppConfig) {
 
 	jwtUtils := utils.NewJWTCfg(config.Viper)
 
+	// Webhook dibuat lebih dulu karena dipakai usecase lain untuk publish event
+	webhookRepo := repository.NewWebhookRepository(config.DB, config.Log)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookRepo, config.Log, config.Validate)
+	webhookController := controller.NewWebhookController(webhookUseCase, config.Log, config.Validate)
+
 	userRepo := repository.NewUserRepository(config.DB, config.Log)
-	authUseCase := usecase.NewAuthUseCase(userRepo, config.Log, config.Validate, config.Viper, jwtUtils)
+	authUseCase := usecase.NewAuthUseCase(userRepo, config.Log, config.Validate, config.Viper, jwtUtils, webhookUseCase)
 	authController := controller.NewAuthController(authUseCase, config.Log, config.Validate)
 	authMiddleware := middleware.NewAuth(authUseCase, config.Log, config.Viper, jwtUtils)
 
+	// Idempotency-Key untuk endpoint yang rawan dikirim ulang
+	idempotencyRepo := repository.NewIdempotencyRepository(config.DB, config.Log)
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepo, config.Viper, config.Log)
+	idempotencyMiddleware := middleware.NewIdempotency(idempotencyUseCase, config.Log)
+
 	userUseCase := usecase.NewUserUseCase(userRepo, config.Log, config.Validate)
 	userController := controller.NewUserController(userUseCase, config.Log, config.Validate)
 
 	deptRepo := repository.NewDepartmentRepository(config.DB, config.Log)
-	deptUseCase := usecase.NewDepartmentUseCase(deptRepo, config.Log, config.Validate, userRepo)
+	deptUseCase := usecase.NewDepartmentUseCase(deptRepo, config.Log, config.Validate, userRepo, webhookUseCase)
 	deptController := controller.NewDepartmentController(deptUseCase, config.Log, config.Validate)
 
+	shiftRepo := repository.NewShiftRepository(config.DB, config.Log)
+	shiftUseCase := usecase.NewShiftUseCase(shiftRepo, userRepo, config.Log, config.Validate)
+	shiftController := controller.NewShiftController(shiftUseCase, config.Log, config.Validate)
+
+	holidayRepo := repository.NewHolidayRepository(config.DB, config.Log)
+	holidayUseCase := usecase.NewHolidayUseCase(holidayRepo, deptRepo, config.Log, config.Validate)
+	holidayController := controller.NewHolidayController(holidayUseCase, config.Log, config.Validate)
+
+	locationRepo := repository.NewLocationRepository(config.DB, config.Log)
+	locationUseCase := usecase.NewLocationUseCase(locationRepo, deptRepo, config.Log, config.Validate)
+	locationController := controller.NewLocationController(locationUseCase, config.Log, config.Validate)
+
+	// Pay period terkunci dicek oleh semua usecase yang mengubah attendance
+	payrollRepo := repository.NewPayrollRepository(config.DB, config.Log)
+	// Event attendance untuk feed real-time; webhook attendance di-enqueue langsung oleh usecase
+	events := event.NewBroker(config.Log)
+
+	// Foto selfie clock in/out
+	files := storage.NewStorage(config.Viper, config.Log)
+
 	attRepo := repository.NewAttendanceRepository(config.DB, config.Log)
-	attUseCase := usecase.NewAttendanceUseCase(attRepo, userRepo, deptRepo, config.Log, config.Validate) // Reuse profileRepo
+	attUseCase := usecase.NewAttendanceUseCase(attRepo, userRepo, deptRepo, shiftRepo, holidayRepo, locationRepo, payrollRepo, events, webhookUseCase, files, config.Log, config.Validate) // Reuse profileRepo
 	attController := controller.NewAttendanceController(attUseCase, config.Log, config.Validate)
 
+	badgeRepo := repository.NewBadgeRepository(config.DB, config.Log)
+	badgeUseCase := usecase.NewBadgeUseCase(badgeRepo, attRepo, userRepo, locationRepo, attUseCase, config.Viper, config.Log, config.Validate)
+	badgeController := controller.NewBadgeController(badgeUseCase, config.Log, config.Validate)
+	badgeReaderMiddleware := middleware.NewBadgeReaderAuth(badgeUseCase, config.Log)
+
+	kioskRepo := repository.NewKioskRepository(config.DB, config.Log)
+	kioskUseCase := usecase.NewKioskUseCase(kioskRepo, badgeRepo, userRepo, deptRepo, locationRepo, attUseCase, config.Viper, config.Log, config.Validate)
+	kioskController := controller.NewKioskController(kioskUseCase, config.Log, config.Validate)
+	kioskMiddleware := middleware.NewKioskAuth(kioskUseCase, config.Log)
+
+	offlineSyncRepo := repository.NewOfflineSyncRepository(config.DB, config.Log)
+	offlineSyncUseCase := usecase.NewOfflineSyncUseCase(offlineSyncRepo, userRepo, attUseCase, config.Viper, config.Log, config.Validate)
+	offlineSyncController := controller.NewOfflineSyncController(offlineSyncUseCase, config.Log, config.Validate)
+
+	correctionRepo := repository.NewCorrectionRepository(config.DB, config.Log)
+	correctionUseCase := usecase.NewCorrectionUseCase(correctionRepo, attRepo, userRepo, payrollRepo, events, webhookUseCase, config.Log, config.Validate)
+	correctionController := controller.NewCorrectionController(correctionUseCase, config.Log, config.Validate)
+
+	overtimeRepo := repository.NewOvertimeRepository(config.DB, config.Log)
+	overtimeUseCase := usecase.NewOvertimeUseCase(overtimeRepo, attRepo, userRepo, payrollRepo, config.Log, config.Validate)
+	overtimeController := controller.NewOvertimeController(overtimeUseCase, config.Log, config.Validate)
+
+	leaveRepo := repository.NewLeaveRepository(config.DB, config.Log)
+	leaveUseCase := usecase.NewLeaveUseCase(leaveRepo, userRepo, shiftRepo, holidayRepo, payrollRepo, config.Log, config.Validate)
+	leaveController := controller.NewLeaveController(leaveUseCase, config.Log, config.Validate)
+
+	payrollUseCase := usecase.NewPayrollUseCase(payrollRepo, attRepo, overtimeRepo, config.Log, config.Validate)
+	payrollController := controller.NewPayrollController(payrollUseCase, config.Log, config.Validate)
+
 	authRoutesConfig := route.RouteConfig{
 		App:            config.App,
 		AuthController: authController,
 		AuthMiddleware: authMiddleware,
+		Idempotency:    idempotencyMiddleware,
 	}
 
 	profileRoutesConfig := route.UserRouteConfig{
@@ -62,18 +127,133 @@ func NewAppConfig(config *AppConfig) {
 		App:                  config.App,
 		AttendanceController: attController,
 		AuthMiddleware:       authMiddleware,
+		Idempotency:          idempotencyMiddleware,
 	}
 	deptRoutesConfig := route.DepartmentRouteConfig{
 		App:                  config.App,
 		DepartmentController: deptController,
 		AuthMiddleware:       authMiddleware,
+		Idempotency:          idempotencyMiddleware,
+	}
+	shiftRoutesConfig := route.ShiftRouteConfig{
+		App:             config.App,
+		ShiftController: shiftController,
+		AuthMiddleware:  authMiddleware,
+	}
+	locationRoutesConfig := route.LocationRouteConfig{
+		App:                config.App,
+		LocationController: locationController,
+		AuthMiddleware:     authMiddleware,
+	}
+	correctionRoutesConfig := route.CorrectionRouteConfig{
+		App:                  config.App,
+		CorrectionController: correctionController,
+		AuthMiddleware:       authMiddleware,
+	}
+	overtimeRoutesConfig := route.OvertimeRouteConfig{
+		App:                config.App,
+		OvertimeController: overtimeController,
+		AuthMiddleware:     authMiddleware,
+	}
+	holidayRoutesConfig := route.HolidayRouteConfig{
+		App:               config.App,
+		HolidayController: holidayController,
+		AuthMiddleware:    authMiddleware,
+	}
+	leaveRoutesConfig := route.LeaveRouteConfig{
+		App:             config.App,
+		LeaveController: leaveController,
+		AuthMiddleware:  authMiddleware,
+	}
+	payrollRoutesConfig := route.PayrollRouteConfig{
+		App:               config.App,
+		PayrollController: payrollController,
+		AuthMiddleware:    authMiddleware,
+	}
+	webhookRoutesConfig := route.WebhookRouteConfig{
+		App:               config.App,
+		WebhookController: webhookController,
+		AuthMiddleware:    authMiddleware,
+	}
+	kioskRoutesConfig := route.KioskRouteConfig{
+		App:             config.App,
+		KioskController: kioskController,
+		AuthMiddleware:  authMiddleware,
+		KioskMiddleware: kioskMiddleware,
+	}
+	badgeRoutesConfig := route.BadgeRouteConfig{
+		App:                   config.App,
+		BadgeController:       badgeController,
+		AuthMiddleware:        authMiddleware,
+		BadgeReaderMiddleware: badgeReaderMiddleware,
+	}
+	offlineSyncRoutesConfig := route.OfflineSyncRouteConfig{
+		App:                   config.App,
+		OfflineSyncController: offlineSyncController,
+		AuthMiddleware:        authMiddleware,
+	}
+	// Signed URL LocalStorage dilayani oleh server ini sendiri
+	if localFiles, ok := files.(*storage.LocalStorage); ok {
+		fileRoutesConfig := route.FileRouteConfig{
+			App:            config.App,
+			FileController: controller.NewFileController(localFiles, config.Log),
+		}
+		fileRoutesConfig.Setup()
 	}
 	authRoutesConfig.Setup()
 	profileRoutesConfig.Setup()
 	deptRoutesConfig.Setup()
+	shiftRoutesConfig.Setup()
+	holidayRoutesConfig.Setup()
+	locationRoutesConfig.Setup()
 	attRoutesConfig.Setup()
+	correctionRoutesConfig.Setup()
+	overtimeRoutesConfig.Setup()
+	leaveRoutesConfig.Setup()
+	payrollRoutesConfig.Setup()
+	webhookRoutesConfig.Setup()
+	kioskRoutesConfig.Setup()
+	badgeRoutesConfig.Setup()
+	offlineSyncRoutesConfig.Setup()
+
+	// Background jobs
+	jobRunRepo := repository.NewJobRunRepository(config.DB, config.Log)
+	absenceUseCase := usecase.NewAbsenceUseCase(attRepo, userRepo, shiftRepo, holidayRepo, payrollRepo, jobRunRepo, config.Viper, config.Log)
+	jobs := scheduler.NewScheduler(config.Log)
+	jobs.Every("absence-detection", durationOrDefault(config.Viper, "scheduler.absenceInterval", 15*time.Minute), func(ctx context.Context, now time.Time) error {
+		_, err := absenceUseCase.DetectAbsences(ctx, now)
+		return err
+	})
+	autoClockOutUseCase := usecase.NewAutoClockOutUseCase(attRepo, userRepo, payrollRepo, events, webhookUseCase, config.Log)
+	jobs.Every("auto-clock-out", durationOrDefault(config.Viper, "scheduler.autoClockOutInterval", 5*time.Minute), func(ctx context.Context, now time.Time) error {
+		_, err := autoClockOutUseCase.CloseForgottenClockOuts(ctx, now)
+		return err
+	})
+	punctualityUseCase := usecase.NewPunctualityUseCase(attRepo, config.Log)
+	jobs.Every("punctuality", durationOrDefault(config.Viper, "scheduler.punctualityInterval", time.Minute), func(ctx context.Context, now time.Time) error {
+		_, err := punctualityUseCase.RecomputeStale(ctx, now)
+		return err
+	})
+	jobs.Every("webhook-delivery", durationOrDefault(config.Viper, "scheduler.webhookInterval", 5*time.Second), func(ctx context.Context, now time.Time) error {
+		_, err := webhookUseCase.DeliverDue(ctx, now)
+		return err
+	})
+	jobs.Every("idempotency-cleanup", durationOrDefault(config.Viper, "scheduler.idempotencyCleanupInterval", time.Hour), func(ctx context.Context, now time.Time) error {
+		_, err := idempotencyUseCase.PurgeExpired(ctx, now)
+		return err
+	})
+	jobs.Start(context.Background())
+	defer jobs.Stop()
+
 	config.Log.Info("Server starting on :8080")
 	if err := config.App.Listen(":8080"); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+func durationOrDefault(viper *viper.Viper, key string, def time.Duration) time.Duration {
+	if d := viper.GetDuration(key); d > 0 {
+		return d
+	}
+	return def
+}
@@ -74,6 +74,44 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 		"attendance_type": {
 			string(domain.AttendanceTypeIn),
 			string(domain.AttendanceTypeOut),
+			string(domain.AttendanceTypeAdjustment),
+			string(domain.AttendanceTypeBreakStart),
+			string(domain.AttendanceTypeBreakEnd),
+		},
+		"break_type": {
+			string(domain.BreakLunch),
+			string(domain.BreakPrayer),
+			string(domain.BreakOther),
+		},
+		"attendance_status": {
+			string(domain.AttendanceStatusPresent),
+			string(domain.AttendanceStatusOnLeave),
+			string(domain.AttendanceStatusAbsent),
+		},
+		"attendance_mode": {
+			string(domain.AttendanceModeOffice),
+			string(domain.AttendanceModeRemote),
+			string(domain.AttendanceModeFieldVisit),
+			string(domain.AttendanceModeBusinessTrip),
+		},
+		"holiday_type": {
+			string(domain.HolidayNational),
+			string(domain.HolidayCompany),
+		},
+		"correction_type": {
+			string(domain.CorrectionMissingClockIn),
+			string(domain.CorrectionMissingClockOut),
+			string(domain.CorrectionWrongTime),
+		},
+		"overtime_kind": {
+			string(domain.OvertimePreApproval),
+			string(domain.OvertimePostApproval),
+		},
+		"approval_status": {
+			string(domain.ApprovalPending),
+			string(domain.ApprovalApproved),
+			string(domain.ApprovalRejected),
+			string(domain.ApprovalCancelled),
 		},
 	}
 
@@ -89,6 +127,27 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 			if err != nil {
 				log.Printf("Gagal membuat tipe %s: %v", typeName, err)
 			}
+			continue
+		}
+		// Tipe sudah ada: tambahkan value baru tanpa mengubah yang lama
+		for _, value := range values {
+			err = db.Exec(fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS '%s'", typeName, value)).Error
+			if err != nil {
+				log.Printf("Gagal menambah value %s ke tipe %s: %v", value, typeName, err)
+			}
+		}
+	}
+
+	// idx_attendance_breaks_open gagal dibuat jika data lama punya lebih dari satu istirahat terbuka
+	// per attendance; istirahat terbuka yang lebih lama ditutup di jam mulainya (durasi 0)
+	if db.Migrator().HasTable(&domain.AttendanceBreak{}) {
+		err = db.Exec(`UPDATE attendance_breaks b SET ended_at = b.started_at
+			WHERE b.ended_at IS NULL AND b.deleted_at IS NULL AND EXISTS (
+				SELECT 1 FROM attendance_breaks n
+				WHERE n.attendance_id = b.attendance_id AND n.ended_at IS NULL AND n.deleted_at IS NULL
+					AND (n.started_at, n.id) > (b.started_at, b.id))`).Error
+		if err != nil {
+			log.Printf("Gagal menutup istirahat terbuka ganda: %v", err)
 		}
 	}
 
@@ -100,8 +159,29 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 		&domain.ApplicationRole{},
 		&domain.RefreshToken{},
 		&domain.Department{},
+		&domain.OfficeLocation{},
+		&domain.DepartmentModeRule{},
+		&domain.Shift{},
+		&domain.ShiftAssignment{},
 		&domain.Attendance{},
 		&domain.AttendanceHistory{},
+		&domain.AttendanceBreak{},
+		&domain.LeaveType{},
+		&domain.LeaveBalance{},
+		&domain.LeaveRequest{},
+		&domain.Holiday{},
+		&domain.AttendanceCorrection{},
+		&domain.OvertimeRequest{},
+		&domain.PayPeriod{},
+		&domain.WebhookSubscription{},
+		&domain.WebhookDelivery{},
+		&domain.KioskDevice{},
+		&domain.KioskScan{},
+		&domain.BadgeCard{},
+		&domain.BadgeReader{},
+		&domain.OfflineClockEvent{},
+		&domain.IdempotencyRecord{},
+		&domain.JobRun{},
 	)
 	if err != nil {
 		log.Fatalf("failed to auto migrate: %v", err)
@@ -2,12 +2,18 @@
 package controller
 
 import (
+	"bufio"
 	"employee-attendance-system/internal/entity/domain"
 	"employee-attendance-system/internal/entity/dto"
 	"employee-attendance-system/internal/middleware"
 	"employee-attendance-system/internal/usecase"
 	utils "employee-attendance-system/internal/util"
+	"encoding/json"
+	"fmt"
+	"io"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -19,10 +25,17 @@ import (
 type AttendanceController interface {
 	ClockIn(c *fiber.Ctx) error
 	ClockOut(c *fiber.Ctx) error
+	BreakStart(c *fiber.Ctx) error
+	BreakEnd(c *fiber.Ctx) error
 	GetAttendanceLogs(c *fiber.Ctx) error
 	GetAdminDashboard(ctx *fiber.Ctx) error
 	GetAttendanceHistory(ctx *fiber.Ctx) error
 	CheckCurrentStatus(ctx *fiber.Ctx) error
+	GetTimesheet(ctx *fiber.Ctx) error
+	ExportAttendanceLogs(ctx *fiber.Ctx) error
+	GetAttendanceReport(ctx *fiber.Ctx) error
+	StreamAttendanceFeed(ctx *fiber.Ctx) error
+	GetAttendancePhoto(ctx *fiber.Ctx) error
 }
 
 type attendanceController struct {
@@ -80,19 +93,31 @@ func (c *attendanceController) GetAttendanceHistory(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Attendance history retrieved", histories, pagination))
 }
+
+// adminDashboardErrorStatus memetakan rentang tanggal yang salah ke 400; error lain (DB) adalah 500
+func adminDashboardErrorStatus(err error) int {
+	switch {
+	case err.Error() == "end_date cannot be before start_date", strings.HasPrefix(err.Error(), "date range must not exceed"):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
 func (c *attendanceController) GetAdminDashboard(ctx *fiber.Ctx) error {
 	var req dto.AdminDashboardRequest
 	if start := ctx.Query("start_date"); start != "" {
 		t, err := time.Parse("2006-01-02", start)
-		if err == nil {
-			req.StartDate = &t
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid start_date, use YYYY-MM-DD", nil))
 		}
+		req.StartDate = &t
 	}
 	if end := ctx.Query("end_date"); end != "" {
 		t, err := time.Parse("2006-01-02", end)
-		if err == nil {
-			req.EndDate = &t
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid end_date, use YYYY-MM-DD", nil))
 		}
+		req.EndDate = &t
 	}
 
 	if err := c.validate.Struct(req); err != nil {
@@ -110,16 +135,38 @@ func (c *attendanceController) GetAdminDashboard(ctx *fiber.Ctx) error {
 
 	dashboard, err := c.usecase.GetAdminDashboard(ctx.Context(), req)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+		statusCode := adminDashboardErrorStatus(err)
+		if statusCode == fiber.StatusInternalServerError {
+			c.log.WithError(err).Error("Failed to get admin dashboard")
+		}
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Dashboard data retrieved", dashboard, struct{}{}))
 }
 
 func (c *attendanceController) ClockIn(ctx *fiber.Ctx) error {
+	// Body opsional, berisi lokasi device. Multipart dipakai jika menyertakan foto selfie.
+	var req dto.ClockInRequest
+	if isMultipart(ctx) {
+		var err error
+		req.Mode = ctx.FormValue("mode")
+		if req.Latitude, req.Longitude, req.Accuracy, req.Photo, err = bindClockForm(ctx); err == nil {
+			err = c.validate.Struct(req)
+		}
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	} else if len(ctx.Body()) > 0 {
+		allowedFields := utils.GenerateAllowedFields(dto.ClockInRequest{})
+		if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
 	userID := middleware.GetLocalKeys(ctx).UserID
 
-	attendance, err := c.usecase.ClockIn(ctx.Context(), userID)
+	attendance, err := c.usecase.ClockIn(ctx.Context(), userID, req)
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
 	}
@@ -128,9 +175,26 @@ func (c *attendanceController) ClockIn(ctx *fiber.Ctx) error {
 }
 
 func (c *attendanceController) ClockOut(ctx *fiber.Ctx) error {
+	// Body opsional, berisi lokasi device. Multipart dipakai jika menyertakan foto selfie.
+	var req dto.ClockOutRequest
+	if isMultipart(ctx) {
+		var err error
+		if req.Latitude, req.Longitude, req.Accuracy, req.Photo, err = bindClockForm(ctx); err == nil {
+			err = c.validate.Struct(req)
+		}
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	} else if len(ctx.Body()) > 0 {
+		allowedFields := utils.GenerateAllowedFields(dto.ClockOutRequest{})
+		if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
 	userID := middleware.GetLocalKeys(ctx).UserID
 
-	attendance, err := c.usecase.ClockOut(ctx.Context(), userID)
+	attendance, err := c.usecase.ClockOut(ctx.Context(), userID, req)
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
 	}
@@ -138,16 +202,40 @@ func (c *attendanceController) ClockOut(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Clocked out", attendance, struct{}{}))
 }
 
+func (c *attendanceController) BreakStart(ctx *fiber.Ctx) error {
+	var req dto.BreakStartRequest
+	allowedFields := utils.GenerateAllowedFields(dto.BreakStartRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	brk, err := c.usecase.BreakStart(ctx.Context(), userID, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Break started", brk, struct{}{}))
+}
+
+func (c *attendanceController) BreakEnd(ctx *fiber.Ctx) error {
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	brk, err := c.usecase.BreakEnd(ctx.Context(), userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Break ended", brk, struct{}{}))
+}
+
 func (c *attendanceController) GetAttendanceLogs(ctx *fiber.Ctx) error {
 	var req dto.GetAttendanceLogsRequest
 	req.Page = ctx.QueryInt("page", 1)
 	req.Limit = ctx.QueryInt("limit", 10)
-	req.Date = ctx.Query("date")
-	departmentIDStr := ctx.Query("department_id")
-	if departmentIDStr != "" {
-		if parsedID, err := uuid.Parse(departmentIDStr); err == nil {
-			req.DepartmentID = &parsedID
-		}
+	if err := bindAttendanceLogsQuery(ctx, &req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
 	}
 	if err := c.validate.Struct(req); err != nil {
 		var errors []utils.ErrorDetail
@@ -183,6 +271,90 @@ func (c *attendanceController) GetAttendanceLogs(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Attendance logs retrieved", logs, pagination))
 }
 
+// bindAttendanceLogsQuery mengisi filter logs dari query string, dipakai logs dan export
+func bindAttendanceLogsQuery(ctx *fiber.Ctx, req *dto.GetAttendanceLogsRequest) error {
+	req.Date = ctx.Query("date")
+	req.StartDate = ctx.Query("start_date")
+	req.EndDate = ctx.Query("end_date")
+	req.Status = ctx.Query("status")
+	req.Mode = ctx.Query("mode")
+	req.SortBy = ctx.Query("sort_by")
+	req.SortOrder = ctx.Query("sort_order")
+	for key, target := range map[string]**int{
+		"min_late_minutes":        &req.MinLateMinutes,
+		"min_early_leave_minutes": &req.MinEarlyLeaveMinutes,
+		"min_worked_minutes":      &req.MinWorkedMinutes,
+		"max_worked_minutes":      &req.MaxWorkedMinutes,
+	} {
+		value, err := optionalQueryInt(ctx, key)
+		if err != nil {
+			return fmt.Errorf("Invalid %s", key)
+		}
+		*target = value
+	}
+	departmentIDStr := ctx.Query("department_id")
+	if departmentIDStr != "" {
+		if parsedID, err := uuid.Parse(departmentIDStr); err == nil {
+			req.DepartmentID = &parsedID
+		}
+	}
+	return nil
+}
+
+// ExportAttendanceLogs men-stream logs sebagai CSV atau XLSX tanpa paging
+func (c *attendanceController) ExportAttendanceLogs(ctx *fiber.Ctx) error {
+	var req dto.GetAttendanceLogsRequest
+	if err := bindAttendanceLogsQuery(ctx, &req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+	format := ctx.Query("format", "csv")
+	if format != "csv" && format != "xlsx" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "format must be csv or xlsx", nil))
+	}
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	export, err := c.usecase.ExportAttendanceLogs(ctx.Context(), localKeys.UserID, localKeys.Role, req)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if err.Error() == "no access" {
+			status = fiber.StatusForbidden
+		}
+		return ctx.Status(status).JSON(utils.ErrorResponse(status, err.Error(), nil))
+	}
+
+	filename := fmt.Sprintf("attendance-logs-%s.%s", time.Now().Format("20060102-150405"), format)
+	if format == "xlsx" {
+		ctx.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	} else {
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	}
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// Status dan header sudah terkirim saat stream berjalan, jadi error di tengah export hanya bisa di-log
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		var table utils.TableWriter
+		if format == "xlsx" {
+			if table, err = utils.NewXLSXTableWriter(w, "Attendance Logs"); err != nil {
+				c.log.WithError(err).Error("Failed to start attendance logs export")
+				return
+			}
+		} else {
+			table = utils.NewCSVTableWriter(w)
+		}
+		if err := export(table); err != nil {
+			c.log.WithError(err).Error("Failed to export attendance logs")
+		}
+	})
+	return nil
+}
+
 // func (c *attendanceController) CheckCurrentStatus(ctx *fiber.Ctx) error {
 // 	var req dto.CheckCurrentStatusRequest
 // 	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
@@ -277,3 +449,256 @@ func (c *attendanceController) CheckCurrentStatus(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).
 		JSON(utils.SuccessResponse(fiber.StatusOK, "Current status retrieved", status, struct{}{}))
 }
+
+// optionalQueryInt membaca query integer opsional, nil jika tidak dikirim
+func optionalQueryInt(ctx *fiber.Ctx, key string) (*int, error) {
+	raw := ctx.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func (c *attendanceController) GetTimesheet(ctx *fiber.Ctx) error {
+	var req dto.GetTimesheetRequest
+	req.Month = ctx.Query("month")
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid user_id", nil))
+		}
+		req.UserID = &userID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	// Karyawan hanya bisa melihat timesheet miliknya, admin semua
+	localKeys := middleware.GetLocalKeys(ctx)
+	targetUserID := localKeys.UserID
+	if req.UserID != nil {
+		if *req.UserID != localKeys.UserID && localKeys.Role != string(domain.Admin) {
+			return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only for other users", nil))
+		}
+		targetUserID = *req.UserID
+	}
+
+	timesheet, err := c.usecase.GetTimesheet(ctx.Context(), targetUserID, req)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(utils.ErrorResponse(status, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Timesheet retrieved", timesheet, struct{}{}))
+}
+
+func attendanceReportErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "department not found":
+		return fiber.StatusNotFound
+	case "no access":
+		return fiber.StatusForbidden
+	}
+	return fiber.StatusBadRequest
+}
+
+// GetAttendanceReport mengembalikan laporan departemen / karyawan sebagai PDF (default) atau JSON
+func (c *attendanceController) GetAttendanceReport(ctx *fiber.Ctx) error {
+	var req dto.AttendanceReportRequest
+	req.StartDate = ctx.Query("start_date")
+	req.EndDate = ctx.Query("end_date")
+	for key, target := range map[string]**uuid.UUID{
+		"department_id": &req.DepartmentID,
+		"user_id":       &req.UserID,
+	} {
+		if value := ctx.Query(key); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid "+key, nil))
+			}
+			*target = &id
+		}
+	}
+	format := ctx.Query("format", "pdf")
+	if format != "pdf" && format != "json" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "format must be pdf or json", nil))
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	report, err := c.usecase.GetAttendanceReport(ctx.Context(), localKeys.UserID, localKeys.Role, req)
+	if err != nil {
+		status := attendanceReportErrorStatus(err)
+		return ctx.Status(status).JSON(utils.ErrorResponse(status, err.Error(), nil))
+	}
+	if format == "json" {
+		return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Attendance report generated", report, struct{}{}))
+	}
+
+	pdf, err := c.usecase.RenderAttendanceReportPDF(report)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+	filename := fmt.Sprintf("attendance-report-%s-%s-%s.pdf", report.Scope, req.StartDate, req.EndDate)
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return ctx.Status(fiber.StatusOK).Send(pdf)
+}
+
+// feedHeartbeat menjaga koneksi SSE tetap hidup melewati proxy dan mendeteksi client yang sudah putus
+const feedHeartbeat = 25 * time.Second
+
+func isMultipart(ctx *fiber.Ctx) bool {
+	return strings.HasPrefix(ctx.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm)
+}
+
+// bindClockForm membaca lokasi dan foto dari form multipart clock in/out. Field foto bernama "photo".
+func bindClockForm(ctx *fiber.Ctx) (latitude, longitude, accuracy *float64, photo *dto.ClockPhoto, err error) {
+	if latitude, err = formFloat(ctx, "latitude"); err != nil {
+		return
+	}
+	if longitude, err = formFloat(ctx, "longitude"); err != nil {
+		return
+	}
+	if accuracy, err = formFloat(ctx, "accuracy"); err != nil {
+		return
+	}
+
+	file, ferr := ctx.FormFile("photo")
+	if ferr != nil {
+		// Foto opsional; wajib atau tidaknya ditentukan departemen di usecase
+		return
+	}
+	f, ferr := file.Open()
+	if ferr != nil {
+		err = fmt.Errorf("invalid photo upload")
+		return
+	}
+	defer f.Close()
+	content, ferr := io.ReadAll(f)
+	if ferr != nil {
+		err = fmt.Errorf("invalid photo upload")
+		return
+	}
+	photo = &dto.ClockPhoto{Content: content}
+	return
+}
+
+func formFloat(ctx *fiber.Ctx, field string) (*float64, error) {
+	raw := ctx.FormValue(field)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("field '%s' must be a number", field)
+	}
+	return &v, nil
+}
+
+// GetAttendancePhoto mengembalikan signed URL foto selfie satu attendance history; admin only
+func (c *attendanceController) GetAttendancePhoto(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	photo, err := c.usecase.GetAttendancePhotoURL(ctx.Context(), id)
+	if err != nil {
+		statusCode := fiber.StatusInternalServerError
+		switch err.Error() {
+		case "attendance history not found", "attendance history has no photo":
+			statusCode = fiber.StatusNotFound
+		}
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Attendance photo URL created", photo, struct{}{}))
+}
+
+// StreamAttendanceFeed mengirim event clock in, clock out dan koreksi yang disetujui sebagai
+// Server-Sent Events. Autentikasi lewat header Authorization atau query ticket sekali pakai;
+// stream ditutup saat JWT asalnya kedaluwarsa sehingga client harus login/minta ticket lagi.
+func (c *attendanceController) StreamAttendanceFeed(ctx *fiber.Ctx) error {
+	var req dto.AttendanceFeedRequest
+	if departmentIDStr := ctx.Query("department_id"); departmentIDStr != "" {
+		departmentID, err := uuid.Parse(departmentIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid department_id", nil))
+		}
+		req.DepartmentID = &departmentID
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	sub, err := c.usecase.SubscribeAttendanceFeed(ctx.Context(), localKeys.UserID, localKeys.Role, req)
+	if err != nil {
+		status := attendanceReportErrorStatus(err)
+		return ctx.Status(status).JSON(utils.ErrorResponse(status, err.Error(), nil))
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	tokenExpiresAt := middleware.GetTokenExpiresAt(ctx)
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+		ticker := time.NewTicker(feedHeartbeat)
+		defer ticker.Stop()
+		var tokenExpired <-chan time.Time
+		if !tokenExpiresAt.IsZero() {
+			timer := time.NewTimer(time.Until(tokenExpiresAt))
+			defer timer.Stop()
+			tokenExpired = timer.C
+		}
+
+		fmt.Fprint(w, "retry: 3000\n\n")
+		for {
+			// Flush gagal berarti client sudah menutup koneksi
+			if err := w.Flush(); err != nil {
+				return
+			}
+			select {
+			case e, ok := <-sub.Events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(e)
+				if err != nil {
+					c.log.WithError(err).Error("Failed to encode attendance event")
+					continue
+				}
+				fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			case <-tokenExpired:
+				return
+			}
+		}
+	})
+	return nil
+}
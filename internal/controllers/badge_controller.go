@@ -0,0 +1,386 @@
+// badge_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type BadgeController interface {
+	CreateBadge(ctx *fiber.Ctx) error
+	GetBadges(ctx *fiber.Ctx) error
+	GetMyBadges(ctx *fiber.Ctx) error
+	DeactivateBadge(ctx *fiber.Ctx) error
+	ReportBadgeLost(ctx *fiber.Ctx) error
+	ReactivateBadge(ctx *fiber.Ctx) error
+	RotateBadgeSecret(ctx *fiber.Ctx) error
+	CreateReader(ctx *fiber.Ctx) error
+	GetReaders(ctx *fiber.Ctx) error
+	UpdateReader(ctx *fiber.Ctx) error
+	DeleteReader(ctx *fiber.Ctx) error
+	RotateReaderKey(ctx *fiber.Ctx) error
+	Tap(ctx *fiber.Ctx) error
+}
+
+type badgeController struct {
+	usecase  usecase.BadgeUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewBadgeController(usecase usecase.BadgeUseCase, log *logrus.Logger, validate *validator.Validate) BadgeController {
+	return &badgeController{usecase: usecase, log: log, validate: validate}
+}
+
+func badgeErrorStatus(err error) int {
+	switch err.Error() {
+	case "badge not found", "badge reader not found", "employee not found", "profile not found", "badge not registered":
+		return fiber.StatusNotFound
+	case "access denied", "badge is deactivated":
+		return fiber.StatusForbidden
+	case "badge uid already registered", "employee already has an active qr badge", "badge is already active", "badge is already deactivated",
+		"already clocked in today", "already clocked out", "pay period is locked":
+		return fiber.StatusConflict
+	case "badge was tapped too recently":
+		return fiber.StatusTooManyRequests
+	}
+	return fiber.StatusBadRequest
+}
+
+func (c *badgeController) CreateBadge(ctx *fiber.Ctx) error {
+	var req dto.CreateBadgeRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateBadgeRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	badge, err := c.usecase.CreateBadge(ctx.Context(), localKeys.UserID, req)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Badge registered", badge, struct{}{}))
+}
+
+func (c *badgeController) GetBadges(ctx *fiber.Ctx) error {
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var req dto.ListBadgesRequest
+	req.Page = ctx.QueryInt("page", 1)
+	req.Limit = ctx.QueryInt("limit", 10)
+	req.EmployeeCode = ctx.Query("employee_code")
+	req.Status = ctx.Query("status")
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	badges, total, err := c.usecase.GetBadges(ctx.Context(), req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: req.Page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(req.Limit))),
+		HasNextPage: req.Page*req.Limit < int(total),
+		NextPage: func() *int {
+			if req.Page*req.Limit < int(total) {
+				np := req.Page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badges retrieved", badges, pagination))
+}
+
+func (c *badgeController) GetMyBadges(ctx *fiber.Ctx) error {
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	badges, err := c.usecase.GetMyBadges(ctx.Context(), userID)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badges retrieved", badges, struct{}{}))
+}
+
+func (c *badgeController) DeactivateBadge(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	// Body opsional, berisi alasan
+	var req dto.DeactivateBadgeRequest
+	if len(ctx.Body()) > 0 {
+		allowedFields := utils.GenerateAllowedFields(dto.DeactivateBadgeRequest{})
+		if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	badge, err := c.usecase.DeactivateBadge(ctx.Context(), localKeys.UserID, id, req)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge deactivated", badge, struct{}{}))
+}
+
+// ReportBadgeLost bisa dipanggil pemilik kartu atau admin
+func (c *badgeController) ReportBadgeLost(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.DeactivateBadgeRequest
+	if len(ctx.Body()) > 0 {
+		allowedFields := utils.GenerateAllowedFields(dto.DeactivateBadgeRequest{})
+		if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+
+	badge, err := c.usecase.ReportBadgeLost(ctx.Context(), localKeys.UserID, localKeys.Role, id, req)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge reported lost", badge, struct{}{}))
+}
+
+func (c *badgeController) ReactivateBadge(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	badge, err := c.usecase.ReactivateBadge(ctx.Context(), id)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge reactivated", badge, struct{}{}))
+}
+
+// RotateBadgeSecret membatalkan kode badge QR yang sudah beredar
+func (c *badgeController) RotateBadgeSecret(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	badge, err := c.usecase.RotateBadgeSecret(ctx.Context(), id)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge secret rotated", badge, struct{}{}))
+}
+
+func (c *badgeController) CreateReader(ctx *fiber.Ctx) error {
+	var req dto.CreateBadgeReaderRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateBadgeReaderRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	reader, err := c.usecase.CreateReader(ctx.Context(), localKeys.UserID, req)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Badge reader created", reader, struct{}{}))
+}
+
+func (c *badgeController) GetReaders(ctx *fiber.Ctx) error {
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	page := ctx.QueryInt("page", 1)
+	limit := ctx.QueryInt("limit", 10)
+	if page < 1 || limit < 1 || limit > 100 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid page or limit", nil))
+	}
+
+	readers, total, err := c.usecase.GetReaders(ctx.Context(), page, limit)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(limit))),
+		HasNextPage: page*limit < int(total),
+		NextPage: func() *int {
+			if page*limit < int(total) {
+				np := page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge readers retrieved", readers, pagination))
+}
+
+func (c *badgeController) UpdateReader(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.UpdateBadgeReaderRequest
+	allowedFields := utils.GenerateAllowedFields(dto.UpdateBadgeReaderRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	reader, err := c.usecase.UpdateReader(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge reader updated", reader, struct{}{}))
+}
+
+func (c *badgeController) DeleteReader(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	if err := c.usecase.DeleteReader(ctx.Context(), id); err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge reader deleted", nil, struct{}{}))
+}
+
+func (c *badgeController) RotateReaderKey(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	reader, err := c.usecase.RotateReaderKey(ctx.Context(), id)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge reader key rotated", reader, struct{}{}))
+}
+
+// Tap dipanggil reader RFID setiap kali kartu ditempel; clock in / clock out ditentukan server
+func (c *badgeController) Tap(ctx *fiber.Ctx) error {
+	var req dto.BadgeTapRequest
+	allowedFields := utils.GenerateAllowedFields(dto.BadgeTapRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	result, err := c.usecase.Tap(ctx.Context(), middleware.GetReaderID(ctx), req)
+	if err != nil {
+		statusCode := badgeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	message := "Clocked in"
+	if result.Action == "clock_out" {
+		message = "Clocked out"
+	}
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, message, result, struct{}{}))
+}
@@ -0,0 +1,199 @@
+// correction_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CorrectionController interface {
+	SubmitCorrection(c *fiber.Ctx) error
+	GetCorrection(c *fiber.Ctx) error
+	ListCorrections(c *fiber.Ctx) error // List with pagination
+	ApproveCorrection(c *fiber.Ctx) error
+	RejectCorrection(c *fiber.Ctx) error
+	CancelCorrection(c *fiber.Ctx) error
+}
+
+type correctionController struct {
+	usecase  usecase.CorrectionUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewCorrectionController(usecase usecase.CorrectionUseCase, log *logrus.Logger, validate *validator.Validate) CorrectionController {
+	return &correctionController{usecase: usecase, log: log, validate: validate}
+}
+
+func correctionErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "profile not found", "correction not found":
+		return fiber.StatusNotFound
+	case "access denied":
+		return fiber.StatusForbidden
+	case "a pending correction already exists for this work date", "correction already reviewed",
+		"attendance already has a clock in", "attendance already has a clock out", "pay period is locked":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
+func (c *correctionController) SubmitCorrection(ctx *fiber.Ctx) error {
+	var req dto.CreateCorrectionRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateCorrectionRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	correction, err := c.usecase.SubmitCorrection(ctx.Context(), userID, req)
+	if err != nil {
+		statusCode := correctionErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Correction submitted", correction, struct{}{}))
+}
+
+func (c *correctionController) GetCorrection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	correction, err := c.usecase.GetCorrection(ctx.Context(), localKeys.UserID, localKeys.Role, id)
+	if err != nil {
+		statusCode := correctionErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Correction retrieved", correction, struct{}{}))
+}
+
+func (c *correctionController) ListCorrections(ctx *fiber.Ctx) error {
+	var req dto.ListCorrectionsRequest
+	req.Page = ctx.QueryInt("page", 1)
+	req.Limit = ctx.QueryInt("limit", 10)
+	req.Status = ctx.Query("status")
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid user_id", nil))
+		}
+		req.UserID = &userID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if req.UserID != nil && *req.UserID != localKeys.UserID && localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Access denied", nil))
+	}
+
+	corrections, total, err := c.usecase.ListCorrections(ctx.Context(), localKeys.UserID, localKeys.Role, req)
+	if err != nil {
+		statusCode := correctionErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: req.Page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(req.Limit))),
+		HasNextPage: req.Page*req.Limit < int(total),
+		NextPage: func() *int {
+			if req.Page*req.Limit < int(total) {
+				np := req.Page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Corrections retrieved", corrections, pagination))
+}
+
+func (c *correctionController) ApproveCorrection(ctx *fiber.Ctx) error {
+	return c.review(ctx, true)
+}
+
+func (c *correctionController) RejectCorrection(ctx *fiber.Ctx) error {
+	return c.review(ctx, false)
+}
+
+func (c *correctionController) review(ctx *fiber.Ctx, approve bool) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	// Body opsional, hanya berisi catatan reviewer
+	var req dto.ReviewCorrectionRequest
+	if len(ctx.Body()) > 0 {
+		allowedFields := utils.GenerateAllowedFields(dto.ReviewCorrectionRequest{})
+		if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var (
+		correction *dto.CorrectionResponse
+		message    string
+	)
+	if approve {
+		correction, err = c.usecase.ApproveCorrection(ctx.Context(), localKeys.UserID, id, req)
+		message = "Correction approved"
+	} else {
+		correction, err = c.usecase.RejectCorrection(ctx.Context(), localKeys.UserID, id, req)
+		message = "Correction rejected"
+	}
+	if err != nil {
+		statusCode := correctionErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, message, correction, struct{}{}))
+}
+
+func (c *correctionController) CancelCorrection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	correction, err := c.usecase.CancelCorrection(ctx.Context(), localKeys.UserID, localKeys.Role, id)
+	if err != nil {
+		statusCode := correctionErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Correction cancelled", correction, struct{}{}))
+}
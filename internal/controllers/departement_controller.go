@@ -21,6 +21,8 @@ type DepartmentController interface {
 	DeleteDepartment(c *fiber.Ctx) error
 	GetDepartments(c *fiber.Ctx) error // List with pagination
 	AssignmentDepartement(ctx *fiber.Ctx) error
+	GetAttendanceModeRules(ctx *fiber.Ctx) error
+	SetAttendanceModeRules(ctx *fiber.Ctx) error
 }
 
 type departmentController struct {
@@ -189,3 +191,54 @@ func (c *departmentController) GetDepartments(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Departments retrieved", depts, pagination))
 }
+
+func (c *departmentController) GetAttendanceModeRules(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	rules, err := c.usecase.GetAttendanceModeRules(ctx.Context(), id)
+	if err != nil {
+		if err.Error() == "department not found" {
+			return ctx.Status(fiber.StatusNotFound).JSON(utils.ErrorResponse(fiber.StatusNotFound, err.Error(), nil))
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Attendance mode rules retrieved", rules, struct{}{}))
+}
+
+func (c *departmentController) SetAttendanceModeRules(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.SetDepartmentModeRulesRequest
+	allowedFields := utils.GenerateAllowedFields(dto.SetDepartmentModeRulesRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	rules, err := c.usecase.SetAttendanceModeRules(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := fiber.StatusBadRequest
+		if err.Error() == "department not found" {
+			statusCode = fiber.StatusNotFound
+		}
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Attendance mode rules updated", rules, struct{}{}))
+}
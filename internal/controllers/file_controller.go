@@ -0,0 +1,46 @@
+// file_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/storage"
+	utils "employee-attendance-system/internal/util"
+	"path"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type FileController interface {
+	ServeSignedFile(ctx *fiber.Ctx) error
+}
+
+// fileController melayani file LocalStorage lewat signed URL; akses dibuktikan signature, bukan JWT
+type fileController struct {
+	files *storage.LocalStorage
+	log   *logrus.Logger
+}
+
+func NewFileController(files *storage.LocalStorage, log *logrus.Logger) FileController {
+	return &fileController{files: files, log: log}
+}
+
+func (c *fileController) ServeSignedFile(ctx *fiber.Ctx) error {
+	key := ctx.Query("key")
+	if err := c.files.Verify(key, ctx.Query("expires"), ctx.Query("signature"), time.Now()); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, err.Error(), nil))
+	}
+
+	file, err := c.files.Open(ctx.Context(), key)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return ctx.Status(fiber.StatusNotFound).JSON(utils.ErrorResponse(fiber.StatusNotFound, err.Error(), nil))
+		}
+		c.log.WithError(err).WithField("key", key).Error("Failed to open stored file")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, "Failed to open file", nil))
+	}
+
+	ctx.Type(path.Ext(key))
+	ctx.Set(fiber.HeaderCacheControl, "private, no-store")
+	return ctx.SendStream(file)
+}
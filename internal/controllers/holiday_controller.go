@@ -0,0 +1,223 @@
+// holiday_controller.go
+package controller
+
+import (
+	"bytes"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"io"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type HolidayController interface {
+	CreateHoliday(c *fiber.Ctx) error
+	GetHoliday(c *fiber.Ctx) error
+	UpdateHoliday(c *fiber.Ctx) error
+	DeleteHoliday(c *fiber.Ctx) error
+	GetHolidays(c *fiber.Ctx) error // List with pagination
+	ImportHolidays(c *fiber.Ctx) error
+}
+
+type holidayController struct {
+	usecase  usecase.HolidayUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewHolidayController(usecase usecase.HolidayUseCase, log *logrus.Logger, validate *validator.Validate) HolidayController {
+	return &holidayController{usecase: usecase, log: log, validate: validate}
+}
+
+func holidayErrorStatus(err error) int {
+	switch err.Error() {
+	case "holiday not found", "department not found":
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusBadRequest
+}
+
+func (c *holidayController) CreateHoliday(ctx *fiber.Ctx) error {
+	var req dto.CreateHolidayRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateHolidayRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	holiday, err := c.usecase.CreateHoliday(ctx.Context(), req)
+	if err != nil {
+		statusCode := holidayErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Holiday created", holiday, struct{}{}))
+}
+
+func (c *holidayController) GetHoliday(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	holiday, err := c.usecase.GetHoliday(ctx.Context(), id)
+	if err != nil {
+		statusCode := holidayErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Holiday retrieved", holiday, struct{}{}))
+}
+
+func (c *holidayController) UpdateHoliday(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.UpdateHolidayRequest
+	allowedFields := utils.GenerateAllowedFields(dto.UpdateHolidayRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	holiday, err := c.usecase.UpdateHoliday(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := holidayErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Holiday updated", holiday, struct{}{}))
+}
+
+func (c *holidayController) DeleteHoliday(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	if err := c.usecase.DeleteHoliday(ctx.Context(), id); err != nil {
+		statusCode := holidayErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Holiday deleted", nil, struct{}{}))
+}
+
+func (c *holidayController) GetHolidays(ctx *fiber.Ctx) error {
+	var req dto.ListHolidaysRequest
+	req.Page = ctx.QueryInt("page", 1)
+	req.Limit = ctx.QueryInt("limit", 10)
+	req.Year = ctx.QueryInt("year", 0)
+	if deptIDStr := ctx.Query("department_id"); deptIDStr != "" {
+		deptID, err := uuid.Parse(deptIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid department_id", nil))
+		}
+		req.DepartmentID = &deptID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	holidays, total, err := c.usecase.GetHolidays(ctx.Context(), req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: req.Page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(req.Limit))),
+		HasNextPage: req.Page*req.Limit < int(total),
+		NextPage: func() *int {
+			if req.Page*req.Limit < int(total) {
+				np := req.Page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Holidays retrieved", holidays, pagination))
+}
+
+// ImportHolidays menerima file .ics lewat multipart field "file" atau body text/calendar mentah
+func (c *holidayController) ImportHolidays(ctx *fiber.Ctx) error {
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var req dto.ImportHolidaysRequest
+	req.Type = ctx.Query("type")
+	if deptIDStr := ctx.Query("department_id"); deptIDStr != "" {
+		deptID, err := uuid.Parse(deptIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid department_id", nil))
+		}
+		req.DepartmentID = &deptID
+	}
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	var body io.Reader
+	if fileHeader, err := ctx.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Cannot read uploaded file", nil))
+		}
+		defer file.Close()
+		body = file
+	} else if len(ctx.Body()) > 0 {
+		body = bytes.NewReader(ctx.Body())
+	} else {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Calendar file is required", nil))
+	}
+
+	result, err := c.usecase.ImportICalendar(ctx.Context(), req, body)
+	if err != nil {
+		statusCode := holidayErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Holidays imported", result, struct{}{}))
+}
@@ -0,0 +1,249 @@
+// kiosk_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type KioskController interface {
+	CreateKiosk(ctx *fiber.Ctx) error
+	GetKiosks(ctx *fiber.Ctx) error
+	UpdateKiosk(ctx *fiber.Ctx) error
+	DeleteKiosk(ctx *fiber.Ctx) error
+	RotateKioskKey(ctx *fiber.Ctx) error
+	GetQRCode(ctx *fiber.Ctx) error
+	ScanKioskQR(ctx *fiber.Ctx) error
+	ScanBadge(ctx *fiber.Ctx) error
+	GetBadge(ctx *fiber.Ctx) error
+}
+
+type kioskController struct {
+	usecase  usecase.KioskUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewKioskController(usecase usecase.KioskUseCase, log *logrus.Logger, validate *validator.Validate) KioskController {
+	return &kioskController{usecase: usecase, log: log, validate: validate}
+}
+
+func kioskErrorStatus(err error) int {
+	switch err.Error() {
+	case "kiosk not found", "profile not found", "employee not found", "badge not found":
+		return fiber.StatusNotFound
+	case "this kiosk is not assigned to your department", "badge is deactivated":
+		return fiber.StatusForbidden
+	case "kiosk code has already been used", "already clocked in today", "already clocked out", "pay period is locked":
+		return fiber.StatusConflict
+	case "kiosk code has expired", "kiosk is inactive":
+		return fiber.StatusGone
+	}
+	return fiber.StatusBadRequest
+}
+
+func (c *kioskController) CreateKiosk(ctx *fiber.Ctx) error {
+	var req dto.CreateKioskRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateKioskRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	kiosk, err := c.usecase.CreateKiosk(ctx.Context(), localKeys.UserID, req)
+	if err != nil {
+		statusCode := kioskErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Kiosk created", kiosk, struct{}{}))
+}
+
+func (c *kioskController) GetKiosks(ctx *fiber.Ctx) error {
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	page := ctx.QueryInt("page", 1)
+	limit := ctx.QueryInt("limit", 10)
+	if page < 1 || limit < 1 || limit > 100 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid page or limit", nil))
+	}
+
+	kiosks, total, err := c.usecase.GetKiosks(ctx.Context(), page, limit)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(limit))),
+		HasNextPage: page*limit < int(total),
+		NextPage: func() *int {
+			if page*limit < int(total) {
+				np := page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Kiosks retrieved", kiosks, pagination))
+}
+
+func (c *kioskController) UpdateKiosk(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.UpdateKioskRequest
+	allowedFields := utils.GenerateAllowedFields(dto.UpdateKioskRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	kiosk, err := c.usecase.UpdateKiosk(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := kioskErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Kiosk updated", kiosk, struct{}{}))
+}
+
+func (c *kioskController) DeleteKiosk(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	if err := c.usecase.DeleteKiosk(ctx.Context(), id); err != nil {
+		statusCode := kioskErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Kiosk deleted", nil, struct{}{}))
+}
+
+func (c *kioskController) RotateKioskKey(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	kiosk, err := c.usecase.RotateKioskKey(ctx.Context(), id)
+	if err != nil {
+		statusCode := kioskErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Kiosk key rotated", kiosk, struct{}{}))
+}
+
+// GetQRCode dipanggil kiosk secara berkala untuk mendapatkan QR baru yang ditampilkan di layar
+func (c *kioskController) GetQRCode(ctx *fiber.Ctx) error {
+	qr, err := c.usecase.IssueQRCode(ctx.Context(), middleware.GetKioskID(ctx))
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Kiosk QR code issued", qr, struct{}{}))
+}
+
+// ScanKioskQR dipanggil HP karyawan setelah scan QR di layar kiosk
+func (c *kioskController) ScanKioskQR(ctx *fiber.Ctx) error {
+	var req dto.KioskScanRequest
+	allowedFields := utils.GenerateAllowedFields(dto.KioskScanRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	result, err := c.usecase.ScanKioskQR(ctx.Context(), userID, req)
+	if err != nil {
+		statusCode := kioskErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, kioskActionMessage(result.Action), result, struct{}{}))
+}
+
+// ScanBadge dipanggil kiosk setelah scan QR badge karyawan
+func (c *kioskController) ScanBadge(ctx *fiber.Ctx) error {
+	var req dto.KioskBadgeScanRequest
+	allowedFields := utils.GenerateAllowedFields(dto.KioskBadgeScanRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	result, err := c.usecase.ScanBadge(ctx.Context(), middleware.GetKioskID(ctx), req)
+	if err != nil {
+		statusCode := kioskErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, kioskActionMessage(result.Action), result, struct{}{}))
+}
+
+func (c *kioskController) GetBadge(ctx *fiber.Ctx) error {
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	badge, err := c.usecase.GetBadge(ctx.Context(), userID)
+	if err != nil {
+		statusCode := kioskErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Badge retrieved", badge, struct{}{}))
+}
+
+func kioskActionMessage(action string) string {
+	if action == "clock_out" {
+		return "Clocked out"
+	}
+	return "Clocked in"
+}
@@ -0,0 +1,350 @@
+// leave_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type LeaveController interface {
+	CreateLeaveType(c *fiber.Ctx) error
+	UpdateLeaveType(c *fiber.Ctx) error
+	DeleteLeaveType(c *fiber.Ctx) error
+	GetLeaveTypes(c *fiber.Ctx) error
+
+	GetLeaveBalances(c *fiber.Ctx) error
+	SetLeaveBalance(c *fiber.Ctx) error
+
+	SubmitLeaveRequest(c *fiber.Ctx) error
+	GetLeaveRequest(c *fiber.Ctx) error
+	ListLeaveRequests(c *fiber.Ctx) error
+	ApproveLeaveRequest(c *fiber.Ctx) error
+	RejectLeaveRequest(c *fiber.Ctx) error
+	CancelLeaveRequest(c *fiber.Ctx) error
+}
+
+type leaveController struct {
+	usecase  usecase.LeaveUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewLeaveController(usecase usecase.LeaveUseCase, log *logrus.Logger, validate *validator.Validate) LeaveController {
+	return &leaveController{usecase: usecase, log: log, validate: validate}
+}
+
+// leaveErrorStatus memetakan pesan error usecase ke HTTP status code
+func leaveErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "profile not found", "leave type not found", "leave request not found":
+		return fiber.StatusNotFound
+	case "access denied":
+		return fiber.StatusForbidden
+	case "leave request overlaps an existing request", "leave request already reviewed", "pay period is locked":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
+func (c *leaveController) CreateLeaveType(ctx *fiber.Ctx) error {
+	var req dto.CreateLeaveTypeRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateLeaveTypeRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	leaveType, err := c.usecase.CreateLeaveType(ctx.Context(), req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Leave type created", leaveType, struct{}{}))
+}
+
+func (c *leaveController) UpdateLeaveType(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.UpdateLeaveTypeRequest
+	allowedFields := utils.GenerateAllowedFields(dto.UpdateLeaveTypeRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	leaveType, err := c.usecase.UpdateLeaveType(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave type updated", leaveType, struct{}{}))
+}
+
+func (c *leaveController) DeleteLeaveType(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	if err := c.usecase.DeleteLeaveType(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave type deleted", nil, struct{}{}))
+}
+
+func (c *leaveController) GetLeaveTypes(ctx *fiber.Ctx) error {
+	leaveTypes, err := c.usecase.GetLeaveTypes(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave types retrieved", leaveTypes, struct{}{}))
+}
+
+func (c *leaveController) GetLeaveBalances(ctx *fiber.Ctx) error {
+	var req dto.GetLeaveBalancesRequest
+	localKeys := middleware.GetLocalKeys(ctx)
+	req.UserID = localKeys.UserID
+	req.Year = ctx.QueryInt("year", time.Now().Year())
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid user_id", nil))
+		}
+		req.UserID = userID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	if req.UserID != localKeys.UserID && localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Access denied", nil))
+	}
+
+	balances, err := c.usecase.GetLeaveBalances(ctx.Context(), req)
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave balances retrieved", balances, struct{}{}))
+}
+
+func (c *leaveController) SetLeaveBalance(ctx *fiber.Ctx) error {
+	var req dto.SetLeaveBalanceRequest
+	allowedFields := utils.GenerateAllowedFields(dto.SetLeaveBalanceRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	balance, err := c.usecase.SetLeaveBalance(ctx.Context(), req)
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave balance updated", balance, struct{}{}))
+}
+
+func (c *leaveController) SubmitLeaveRequest(ctx *fiber.Ctx) error {
+	var req dto.CreateLeaveRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateLeaveRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	leave, err := c.usecase.SubmitLeaveRequest(ctx.Context(), userID, req)
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Leave request submitted", leave, struct{}{}))
+}
+
+func (c *leaveController) GetLeaveRequest(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	leave, err := c.usecase.GetLeaveRequest(ctx.Context(), localKeys.UserID, localKeys.Role, id)
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave request retrieved", leave, struct{}{}))
+}
+
+func (c *leaveController) ListLeaveRequests(ctx *fiber.Ctx) error {
+	var req dto.ListLeaveRequestsRequest
+	req.Page = ctx.QueryInt("page", 1)
+	req.Limit = ctx.QueryInt("limit", 10)
+	req.Status = ctx.Query("status")
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid user_id", nil))
+		}
+		req.UserID = &userID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if req.UserID != nil && *req.UserID != localKeys.UserID && localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Access denied", nil))
+	}
+
+	requests, total, err := c.usecase.ListLeaveRequests(ctx.Context(), localKeys.UserID, localKeys.Role, req)
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: req.Page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(req.Limit))),
+		HasNextPage: req.Page*req.Limit < int(total),
+		NextPage: func() *int {
+			if req.Page*req.Limit < int(total) {
+				np := req.Page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave requests retrieved", requests, pagination))
+}
+
+func (c *leaveController) ApproveLeaveRequest(ctx *fiber.Ctx) error {
+	return c.review(ctx, true)
+}
+
+func (c *leaveController) RejectLeaveRequest(ctx *fiber.Ctx) error {
+	return c.review(ctx, false)
+}
+
+func (c *leaveController) review(ctx *fiber.Ctx, approve bool) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	// Body opsional, hanya berisi catatan reviewer
+	var req dto.ReviewLeaveRequest
+	if len(ctx.Body()) > 0 {
+		allowedFields := utils.GenerateAllowedFields(dto.ReviewLeaveRequest{})
+		if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var (
+		leave   *dto.LeaveRequestResponse
+		message string
+	)
+	if approve {
+		leave, err = c.usecase.ApproveLeaveRequest(ctx.Context(), localKeys.UserID, id, req)
+		message = "Leave request approved"
+	} else {
+		leave, err = c.usecase.RejectLeaveRequest(ctx.Context(), localKeys.UserID, id, req)
+		message = "Leave request rejected"
+	}
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, message, leave, struct{}{}))
+}
+
+func (c *leaveController) CancelLeaveRequest(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	leave, err := c.usecase.CancelLeaveRequest(ctx.Context(), localKeys.UserID, localKeys.Role, id)
+	if err != nil {
+		statusCode := leaveErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Leave request cancelled", leave, struct{}{}))
+}
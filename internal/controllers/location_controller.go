@@ -0,0 +1,157 @@
+// location_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type LocationController interface {
+	CreateLocation(c *fiber.Ctx) error
+	GetLocation(c *fiber.Ctx) error
+	UpdateLocation(c *fiber.Ctx) error
+	DeleteLocation(c *fiber.Ctx) error
+	GetLocations(c *fiber.Ctx) error // List with pagination
+}
+
+type locationController struct {
+	usecase  usecase.LocationUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewLocationController(usecase usecase.LocationUseCase, log *logrus.Logger, validate *validator.Validate) LocationController {
+	return &locationController{usecase: usecase, log: log, validate: validate}
+}
+
+func locationErrorStatus(err error) int {
+	switch err.Error() {
+	case "office location not found", "department not found":
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusInternalServerError
+}
+
+func (c *locationController) CreateLocation(ctx *fiber.Ctx) error {
+	var req dto.CreateOfficeLocationRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateOfficeLocationRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	location, err := c.usecase.CreateLocation(ctx.Context(), req)
+	if err != nil {
+		statusCode := locationErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Office location created", location, struct{}{}))
+}
+
+func (c *locationController) GetLocation(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	location, err := c.usecase.GetLocation(ctx.Context(), id)
+	if err != nil {
+		statusCode := locationErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Office location retrieved", location, struct{}{}))
+}
+
+func (c *locationController) UpdateLocation(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.UpdateOfficeLocationRequest
+	allowedFields := utils.GenerateAllowedFields(dto.UpdateOfficeLocationRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	location, err := c.usecase.UpdateLocation(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := locationErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Office location updated", location, struct{}{}))
+}
+
+func (c *locationController) DeleteLocation(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	if middleware.GetLocalKeys(ctx).Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	if err := c.usecase.DeleteLocation(ctx.Context(), id); err != nil {
+		statusCode := locationErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Office location deleted", nil, struct{}{}))
+}
+
+func (c *locationController) GetLocations(ctx *fiber.Ctx) error {
+	page := ctx.QueryInt("page", 1)
+	limit := ctx.QueryInt("limit", 10)
+
+	locations, total, err := c.usecase.GetLocations(ctx.Context(), page, limit)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(limit))),
+		HasNextPage: page*limit < int(total),
+		NextPage: func() *int {
+			if page*limit < int(total) {
+				np := page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Office locations retrieved", locations, pagination))
+}
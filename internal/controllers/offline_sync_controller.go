@@ -0,0 +1,50 @@
+// offline_sync_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type OfflineSyncController interface {
+	SyncClockEvents(ctx *fiber.Ctx) error
+}
+
+type offlineSyncController struct {
+	usecase  usecase.OfflineSyncUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewOfflineSyncController(usecase usecase.OfflineSyncUseCase, log *logrus.Logger, validate *validator.Validate) OfflineSyncController {
+	return &offlineSyncController{usecase: usecase, log: log, validate: validate}
+}
+
+// SyncClockEvents menerima batch event clock in/out offline. Event yang ditolak tetap
+// dibalas 200 dengan status per event; hanya kegagalan seluruh batch yang dibalas error.
+func (c *offlineSyncController) SyncClockEvents(ctx *fiber.Ctx) error {
+	var req dto.OfflineSyncRequest
+	allowedFields := utils.GenerateAllowedFields(dto.OfflineSyncRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	result, err := c.usecase.SyncClockEvents(ctx.Context(), userID, req)
+	if err != nil {
+		if err.Error() == "profile not found" {
+			return ctx.Status(fiber.StatusNotFound).JSON(utils.ErrorResponse(fiber.StatusNotFound, err.Error(), nil))
+		}
+		c.log.WithError(err).WithField("user_id", userID).Error("Failed to sync offline clock events")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, "Failed to sync offline clock events, please retry", nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Offline clock events synced", result, struct{}{}))
+}
@@ -0,0 +1,240 @@
+// overtime_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type OvertimeController interface {
+	SubmitOvertime(c *fiber.Ctx) error
+	GetOvertime(c *fiber.Ctx) error
+	ListOvertime(c *fiber.Ctx) error // List with pagination
+	ApproveOvertime(c *fiber.Ctx) error
+	RejectOvertime(c *fiber.Ctx) error
+	CancelOvertime(c *fiber.Ctx) error
+	GetOvertimeSummary(c *fiber.Ctx) error
+}
+
+type overtimeController struct {
+	usecase  usecase.OvertimeUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewOvertimeController(usecase usecase.OvertimeUseCase, log *logrus.Logger, validate *validator.Validate) OvertimeController {
+	return &overtimeController{usecase: usecase, log: log, validate: validate}
+}
+
+func overtimeErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "profile not found", "overtime request not found":
+		return fiber.StatusNotFound
+	case "access denied":
+		return fiber.StatusForbidden
+	case "an overtime request already exists for this work date", "overtime request already reviewed", "pay period is locked":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
+func (c *overtimeController) SubmitOvertime(ctx *fiber.Ctx) error {
+	var req dto.CreateOvertimeRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateOvertimeRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	userID := middleware.GetLocalKeys(ctx).UserID
+
+	request, err := c.usecase.SubmitOvertime(ctx.Context(), userID, req)
+	if err != nil {
+		statusCode := overtimeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Overtime request submitted", request, struct{}{}))
+}
+
+func (c *overtimeController) GetOvertime(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	request, err := c.usecase.GetOvertime(ctx.Context(), localKeys.UserID, localKeys.Role, id)
+	if err != nil {
+		statusCode := overtimeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Overtime request retrieved", request, struct{}{}))
+}
+
+func (c *overtimeController) ListOvertime(ctx *fiber.Ctx) error {
+	var req dto.ListOvertimeRequest
+	req.Page = ctx.QueryInt("page", 1)
+	req.Limit = ctx.QueryInt("limit", 10)
+	req.Status = ctx.Query("status")
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid user_id", nil))
+		}
+		req.UserID = &userID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if req.UserID != nil && *req.UserID != localKeys.UserID && localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Access denied", nil))
+	}
+
+	requests, total, err := c.usecase.ListOvertime(ctx.Context(), localKeys.UserID, localKeys.Role, req)
+	if err != nil {
+		statusCode := overtimeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: req.Page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(req.Limit))),
+		HasNextPage: req.Page*req.Limit < int(total),
+		NextPage: func() *int {
+			if req.Page*req.Limit < int(total) {
+				np := req.Page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Overtime requests retrieved", requests, pagination))
+}
+
+func (c *overtimeController) ApproveOvertime(ctx *fiber.Ctx) error {
+	return c.review(ctx, true)
+}
+
+func (c *overtimeController) RejectOvertime(ctx *fiber.Ctx) error {
+	return c.review(ctx, false)
+}
+
+func (c *overtimeController) review(ctx *fiber.Ctx, approve bool) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	// Body opsional, berisi menit yang disetujui dan catatan reviewer
+	var req dto.ReviewOvertimeRequest
+	if len(ctx.Body()) > 0 {
+		allowedFields := utils.GenerateAllowedFields(dto.ReviewOvertimeRequest{})
+		if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var (
+		request *dto.OvertimeResponse
+		message string
+	)
+	if approve {
+		request, err = c.usecase.ApproveOvertime(ctx.Context(), localKeys.UserID, id, req)
+		message = "Overtime request approved"
+	} else {
+		request, err = c.usecase.RejectOvertime(ctx.Context(), localKeys.UserID, id, req)
+		message = "Overtime request rejected"
+	}
+	if err != nil {
+		statusCode := overtimeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, message, request, struct{}{}))
+}
+
+func (c *overtimeController) CancelOvertime(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	request, err := c.usecase.CancelOvertime(ctx.Context(), localKeys.UserID, localKeys.Role, id)
+	if err != nil {
+		statusCode := overtimeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Overtime request cancelled", request, struct{}{}))
+}
+
+func (c *overtimeController) GetOvertimeSummary(ctx *fiber.Ctx) error {
+	var req dto.OvertimeSummaryRequest
+	req.StartDate = ctx.Query("start_date")
+	req.EndDate = ctx.Query("end_date")
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid user_id", nil))
+		}
+		req.UserID = &userID
+	}
+	if departmentIDStr := ctx.Query("department_id"); departmentIDStr != "" {
+		departmentID, err := uuid.Parse(departmentIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid department_id", nil))
+		}
+		req.DepartmentID = &departmentID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" && ((req.UserID != nil && *req.UserID != localKeys.UserID) || req.DepartmentID != nil) {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Access denied", nil))
+	}
+
+	summary, err := c.usecase.GetOvertimeSummary(ctx.Context(), localKeys.UserID, localKeys.Role, req)
+	if err != nil {
+		statusCode := overtimeErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Overtime summary retrieved", summary, struct{}{}))
+}
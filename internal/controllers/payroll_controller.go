@@ -0,0 +1,226 @@
+// payroll_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"fmt"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type PayrollController interface {
+	ExportPayroll(c *fiber.Ctx) error
+	LockAndExportPayroll(c *fiber.Ctx) error
+	LockPayPeriod(c *fiber.Ctx) error
+	ListPayPeriods(c *fiber.Ctx) error
+	UnlockPayPeriod(c *fiber.Ctx) error
+	RelockPayPeriod(c *fiber.Ctx) error
+}
+
+type payrollController struct {
+	usecase  usecase.PayrollUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewPayrollController(usecase usecase.PayrollUseCase, log *logrus.Logger, validate *validator.Validate) PayrollController {
+	return &payrollController{usecase: usecase, log: log, validate: validate}
+}
+
+func payrollErrorStatus(err error) int {
+	switch err.Error() {
+	case "pay period not found":
+		return fiber.StatusNotFound
+	case "pay period already locked", "pay period is not locked", "overlaps with a locked pay period":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
+// ExportPayroll mengirim rekap payroll sebagai file (csv / json) tanpa mengubah status pay period
+func (c *payrollController) ExportPayroll(ctx *fiber.Ctx) error {
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var req dto.PayrollExportRequest
+	req.StartDate = ctx.Query("start_date")
+	req.EndDate = ctx.Query("end_date")
+	req.Format = ctx.Query("format", "csv")
+	// GET tidak boleh mengubah state (prefetch, link preview, retry); lock lewat POST /payroll/export
+	if ctx.Query("lock") != "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Use POST /payroll/export to lock the pay period", nil))
+	}
+	if departmentIDStr := ctx.Query("department_id"); departmentIDStr != "" {
+		departmentID, err := uuid.Parse(departmentIDStr)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid department_id", nil))
+		}
+		req.DepartmentID = &departmentID
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	file, err := c.usecase.ExportPayroll(ctx.Context(), localKeys.UserID, req)
+	if err != nil {
+		statusCode := payrollErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return sendPayrollFile(ctx, file)
+}
+
+// LockAndExportPayroll mengunci pay period lalu mengirim rekapnya sebagai file
+func (c *payrollController) LockAndExportPayroll(ctx *fiber.Ctx) error {
+	var req dto.LockPayrollExportRequest
+	allowedFields := utils.GenerateAllowedFields(dto.LockPayrollExportRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	file, err := c.usecase.LockAndExportPayroll(ctx.Context(), localKeys.UserID, req)
+	if err != nil {
+		statusCode := payrollErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return sendPayrollFile(ctx, file)
+}
+
+func sendPayrollFile(ctx *fiber.Ctx, file *dto.PayrollExportFile) error {
+	ctx.Set(fiber.HeaderContentType, file.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.Filename))
+	return ctx.Status(fiber.StatusOK).Send(file.Content)
+}
+
+func (c *payrollController) LockPayPeriod(ctx *fiber.Ctx) error {
+	var req dto.LockPayPeriodRequest
+	allowedFields := utils.GenerateAllowedFields(dto.LockPayPeriodRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	period, err := c.usecase.LockPayPeriod(ctx.Context(), localKeys.UserID, req)
+	if err != nil {
+		statusCode := payrollErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Pay period locked", period, struct{}{}))
+}
+
+func (c *payrollController) ListPayPeriods(ctx *fiber.Ctx) error {
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var req dto.ListPayPeriodsRequest
+	req.Page = ctx.QueryInt("page", 1)
+	req.Limit = ctx.QueryInt("limit", 10)
+	req.Status = ctx.Query("status")
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	periods, total, err := c.usecase.ListPayPeriods(ctx.Context(), req)
+	if err != nil {
+		statusCode := payrollErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: req.Page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(req.Limit))),
+		HasNextPage: req.Page*req.Limit < int(total),
+		NextPage: func() *int {
+			if req.Page*req.Limit < int(total) {
+				np := req.Page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Pay periods retrieved", periods, pagination))
+}
+
+func (c *payrollController) UnlockPayPeriod(ctx *fiber.Ctx) error {
+	return c.setLock(ctx, false)
+}
+
+func (c *payrollController) RelockPayPeriod(ctx *fiber.Ctx) error {
+	return c.setLock(ctx, true)
+}
+
+func (c *payrollController) setLock(ctx *fiber.Ctx, lock bool) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var (
+		period  *dto.PayPeriodResponse
+		message string
+	)
+	if lock {
+		period, err = c.usecase.RelockPayPeriod(ctx.Context(), localKeys.UserID, id)
+		message = "Pay period locked"
+	} else {
+		period, err = c.usecase.UnlockPayPeriod(ctx.Context(), localKeys.UserID, id)
+		message = "Pay period unlocked"
+	}
+	if err != nil {
+		statusCode := payrollErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, message, period, struct{}{}))
+}
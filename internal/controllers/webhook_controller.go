@@ -0,0 +1,251 @@
+// webhook_controller.go
+package controller
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/middleware"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"math"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookController interface {
+	CreateWebhook(ctx *fiber.Ctx) error
+	GetWebhooks(ctx *fiber.Ctx) error
+	UpdateWebhook(ctx *fiber.Ctx) error
+	DeleteWebhook(ctx *fiber.Ctx) error
+	GetDeliveries(ctx *fiber.Ctx) error
+	ReplayDelivery(ctx *fiber.Ctx) error
+	ReplayFailedDeliveries(ctx *fiber.Ctx) error
+}
+
+type webhookController struct {
+	usecase  usecase.WebhookUseCase
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewWebhookController(usecase usecase.WebhookUseCase, log *logrus.Logger, validate *validator.Validate) WebhookController {
+	return &webhookController{usecase: usecase, log: log, validate: validate}
+}
+
+func webhookErrorStatus(err error) int {
+	switch err.Error() {
+	case "webhook not found", "webhook delivery not found":
+		return fiber.StatusNotFound
+	case "only failed deliveries can be replayed":
+		return fiber.StatusConflict
+	}
+	if strings.HasPrefix(err.Error(), "invalid webhook url") {
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+func (c *webhookController) CreateWebhook(ctx *fiber.Ctx) error {
+	var req dto.CreateWebhookRequest
+	allowedFields := utils.GenerateAllowedFields(dto.CreateWebhookRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	webhook, err := c.usecase.CreateWebhook(ctx.Context(), localKeys.UserID, req)
+	if err != nil {
+		statusCode := webhookErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Webhook created", webhook, struct{}{}))
+}
+
+func (c *webhookController) GetWebhooks(ctx *fiber.Ctx) error {
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	page := ctx.QueryInt("page", 1)
+	limit := ctx.QueryInt("limit", 10)
+	if page < 1 || limit < 1 || limit > 100 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid page or limit", nil))
+	}
+
+	webhooks, total, err := c.usecase.GetWebhooks(ctx.Context(), page, limit)
+	if err != nil {
+		statusCode := webhookErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(limit))),
+		HasNextPage: page*limit < int(total),
+		NextPage: func() *int {
+			if page*limit < int(total) {
+				np := page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Webhooks retrieved", webhooks, pagination))
+}
+
+func (c *webhookController) UpdateWebhook(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	var req dto.UpdateWebhookRequest
+	allowedFields := utils.GenerateAllowedFields(dto.UpdateWebhookRequest{})
+	if err := utils.BindAndValidateBody(ctx, &req, allowedFields, c.validate); err != nil {
+		var errors []utils.ErrorDetail
+		if validationErr := c.validate.Struct(req); validationErr != nil {
+			for _, e := range validationErr.(validator.ValidationErrors) {
+				errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+			}
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, err.Error(), errors))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	webhook, err := c.usecase.UpdateWebhook(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := webhookErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Webhook updated", webhook, struct{}{}))
+}
+
+func (c *webhookController) DeleteWebhook(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	if err := c.usecase.DeleteWebhook(ctx.Context(), id); err != nil {
+		statusCode := webhookErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Webhook deleted", nil, struct{}{}))
+}
+
+// GetDeliveries menampilkan log pengiriman satu webhook, bisa difilter per status
+func (c *webhookController) GetDeliveries(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	var req dto.ListWebhookDeliveriesRequest
+	req.Page = ctx.QueryInt("page", 1)
+	req.Limit = ctx.QueryInt("limit", 10)
+	req.Status = ctx.Query("status")
+
+	if err := c.validate.Struct(req); err != nil {
+		var errors []utils.ErrorDetail
+		for _, e := range err.(validator.ValidationErrors) {
+			errors = append(errors, utils.ErrorDetail{Field: e.Field(), Message: e.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Validation failed", errors))
+	}
+
+	deliveries, total, err := c.usecase.GetDeliveries(ctx.Context(), id, req)
+	if err != nil {
+		statusCode := webhookErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	pagination := utils.Pagination{
+		CurrentPage: req.Page,
+		TotalItems:  int(total),
+		TotalPages:  int(math.Ceil(float64(total) / float64(req.Limit))),
+		HasNextPage: req.Page*req.Limit < int(total),
+		NextPage: func() *int {
+			if req.Page*req.Limit < int(total) {
+				np := req.Page + 1
+				return &np
+			}
+			return nil
+		}(),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(utils.SuccessResponse(fiber.StatusOK, "Webhook deliveries retrieved", deliveries, pagination))
+}
+
+func (c *webhookController) ReplayDelivery(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	delivery, err := c.usecase.ReplayDelivery(ctx.Context(), id)
+	if err != nil {
+		statusCode := webhookErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(utils.SuccessResponse(fiber.StatusAccepted, "Webhook delivery queued for replay", delivery, struct{}{}))
+}
+
+// ReplayFailedDeliveries me-replay semua delivery gagal milik satu webhook
+func (c *webhookController) ReplayFailedDeliveries(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Invalid ID", nil))
+	}
+
+	localKeys := middleware.GetLocalKeys(ctx)
+	if localKeys.Role != "admin" {
+		return ctx.Status(fiber.StatusForbidden).JSON(utils.ErrorResponse(fiber.StatusForbidden, "Admin only", nil))
+	}
+
+	replayed, err := c.usecase.ReplayFailedDeliveries(ctx.Context(), id)
+	if err != nil {
+		statusCode := webhookErrorStatus(err)
+		return ctx.Status(statusCode).JSON(utils.ErrorResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(utils.SuccessResponse(fiber.StatusAccepted, "Failed webhook deliveries queued for replay", dto.ReplayWebhookDeliveriesResponse{Replayed: replayed}, struct{}{}))
+}
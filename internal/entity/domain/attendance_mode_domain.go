@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttendanceMode adalah tempat pekerjaan dilakukan pada hari tersebut
+type AttendanceMode string
+
+const (
+	AttendanceModeOffice       AttendanceMode = "office"
+	AttendanceModeRemote       AttendanceMode = "remote"
+	AttendanceModeFieldVisit   AttendanceMode = "field_visit"
+	AttendanceModeBusinessTrip AttendanceMode = "business_trip"
+)
+
+// DepartmentModeRule mengizinkan satu attendance mode untuk departemen pada hari tertentu.
+// Departemen tanpa rule hanya boleh mode office.
+type DepartmentModeRule struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	DepartmentID uuid.UUID      `json:"department_id" gorm:"type:uuid;not null;uniqueIndex:idx_department_mode"`
+	Mode         AttendanceMode `json:"mode" gorm:"type:attendance_mode;not null;uniqueIndex:idx_department_mode"`
+	Weekdays     []int          `json:"weekdays" gorm:"type:jsonb;serializer:json;not null"` // 0 = Minggu ... 6 = Sabtu
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Department *Department `json:"department,omitempty" gorm:"foreignKey:DepartmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (r *DepartmentModeRule) AppliesOn(day time.Weekday) bool {
+	for _, d := range r.Weekdays {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BadgeStatus string
+
+const (
+	BadgeStatusActive      BadgeStatus = "active"
+	BadgeStatusLost        BadgeStatus = "lost"
+	BadgeStatusDeactivated BadgeStatus = "deactivated" // Rusak, dikembalikan, karyawan keluar, dsb.
+)
+
+type BadgeKind string
+
+const (
+	BadgeKindRFID BadgeKind = "rfid"
+	BadgeKindQR   BadgeKind = "qr" // Badge QR yang di-scan kiosk
+)
+
+// BadgeCard memetakan badge ke karyawan. UID kartu RFID disimpan dalam bentuk hex uppercase
+// tanpa pemisah, sehingga "04:a2:3b:1c" dan "04A23B1C" dianggap kartu yang sama. Badge QR
+// tidak punya UID; kodenya ditandatangani bersama Secret, jadi mengganti Secret membatalkan
+// semua cetakan/screenshot kode lama.
+type BadgeCard struct {
+	ID                uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Kind              BadgeKind      `json:"kind" gorm:"type:varchar(10);not null;default:'rfid'"`
+	UID               *string        `json:"uid" gorm:"type:varchar(64);uniqueIndex"`
+	Secret            string         `json:"-" gorm:"type:varchar(64)"`
+	EmployeeCode      string         `json:"employee_code" gorm:"type:varchar(50);index;not null"`
+	Status            BadgeStatus    `json:"status" gorm:"type:varchar(20);not null;default:'active'"`
+	Label             string         `json:"label" gorm:"type:varchar(100)"` // Nomor cetak di kartu, opsional
+	LastScanAt        *time.Time     `json:"last_scan_at"`
+	DeactivatedAt     *time.Time     `json:"deactivated_at"`
+	DeactivatedBy     *uuid.UUID     `json:"deactivated_by" gorm:"type:uuid"`
+	DeactivatedReason string         `json:"deactivated_reason" gorm:"type:text"`
+	IssuedBy          *uuid.UUID     `json:"issued_by" gorm:"type:uuid"`
+	CreatedAt         time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt         time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// BadgeReader adalah reader RFID di gerbang. Reader mengautentikasi dirinya dengan API key (DeviceKey).
+type BadgeReader struct {
+	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name             string         `json:"name" gorm:"type:varchar(255);not null"`
+	OfficeLocationID *uuid.UUID     `json:"office_location_id" gorm:"type:uuid"` // Lokasi reader, dicatat di history sebagai pengganti GPS
+	CreatedBy        *uuid.UUID     `json:"created_by" gorm:"type:uuid"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+
+	DeviceKey
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BreakType string
+
+const (
+	BreakLunch  BreakType = "lunch"
+	BreakPrayer BreakType = "prayer"
+	BreakOther  BreakType = "other"
+)
+
+// AttendanceBreak adalah satu istirahat di antara clock in dan clock out. Partial unique index
+// idx_attendance_breaks_open menjamin paling banyak satu istirahat terbuka per attendance,
+// juga saat dua request break start datang bersamaan.
+type AttendanceBreak struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode string         `json:"employee_code" gorm:"type:varchar(50);index;not null"`
+	AttendanceID string         `json:"attendance_id" gorm:"type:varchar(100);index;not null;uniqueIndex:idx_attendance_breaks_open,where:ended_at IS NULL AND deleted_at IS NULL"` // FK to Attendance.AttendanceID
+	Type         BreakType      `json:"type" gorm:"type:break_type;not null"`
+	StartedAt    time.Time      `json:"started_at" gorm:"type:timestamp;not null"`
+	EndedAt      *time.Time     `json:"ended_at" gorm:"type:timestamp"` // Nil selama istirahat masih berjalan
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
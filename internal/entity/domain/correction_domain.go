@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CorrectionType string
+
+const (
+	CorrectionMissingClockIn  CorrectionType = "missing_clock_in"
+	CorrectionMissingClockOut CorrectionType = "missing_clock_out"
+	CorrectionWrongTime       CorrectionType = "wrong_time"
+)
+
+// AttendanceCorrection adalah pengajuan koreksi absen oleh karyawan (lupa clock in/out atau jam salah).
+// Original* menyimpan nilai attendance saat pengajuan dibuat untuk audit.
+type AttendanceCorrection struct {
+	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode     string         `json:"employee_code" gorm:"type:varchar(50);index;not null"`  // FK to UserProfile.EmployeeCode
+	AttendanceID     string         `json:"attendance_id" gorm:"type:varchar(100);index;not null"` // Attendance.AttendanceID yang dikoreksi
+	WorkDate         time.Time      `json:"work_date" gorm:"type:date;not null"`
+	Type             CorrectionType `json:"type" gorm:"type:correction_type;not null"`
+	ClockIn          *time.Time     `json:"clock_in" gorm:"type:timestamp"`  // Jam masuk yang diajukan
+	ClockOut         *time.Time     `json:"clock_out" gorm:"type:timestamp"` // Jam keluar yang diajukan
+	OriginalClockIn  *time.Time     `json:"original_clock_in" gorm:"type:timestamp"`
+	OriginalClockOut *time.Time     `json:"original_clock_out" gorm:"type:timestamp"`
+	Reason           string         `json:"reason" gorm:"type:text;not null"`
+	Status           ApprovalStatus `json:"status" gorm:"type:approval_status;not null;default:'pending'"`
+	ReviewedBy       *uuid.UUID     `json:"reviewed_by" gorm:"type:uuid"`
+	ReviewedAt       *time.Time     `json:"reviewed_at"`
+	ReviewNote       string         `json:"review_note" gorm:"type:text"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+}
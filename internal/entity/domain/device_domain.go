@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// DeviceKey adalah kredensial perangkat yang login dengan API key (kiosk, reader RFID).
+// Di-embed ke tabel perangkat; yang disimpan hanya hash key-nya.
+type DeviceKey struct {
+	KeyHash    string     `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+	IsActive   bool       `json:"is_active" gorm:"not null;default:true"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+}
+
+// Key memberi akses ke kredensial perangkat yang meng-embed DeviceKey
+func (k *DeviceKey) Key() *DeviceKey {
+	return k
+}
@@ -9,44 +9,118 @@ import (
 
 // New struct for Department
 type Department struct {
-	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	Name            string         `json:"name" gorm:"column:department_name;type:varchar(255);not null"`
-	MaxClockInTime  time.Time      `json:"max_clock_in_time" gorm:"type:time;not null"`
-	MaxClockOutTime time.Time      `json:"max_clock_out_time" gorm:"type:time;not null"`
-	CreatedAt       time.Time      `json:"created_at" gorm:"default:current_timestamp"`
-	UpdatedAt       time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
-	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name            string    `json:"name" gorm:"column:department_name;type:varchar(255);not null"`
+	MaxClockInTime  time.Time `json:"max_clock_in_time" gorm:"type:time;not null"`
+	MaxClockOutTime time.Time `json:"max_clock_out_time" gorm:"type:time;not null"`
+	GraceMinutes    int       `json:"grace_minutes" gorm:"not null;default:0"` // Toleransi terlambat untuk karyawan tanpa shift
+	// Rule penilaian punctuality: fixed_window (jadwal shift/departemen), flexitime (core hours) atau minimum_duration
+	PunctualityRule     PunctualityRule  `json:"punctuality_rule" gorm:"type:varchar(20);not null;default:'fixed_window'"`
+	CoreStartTime       *time.Time       `json:"core_start_time" gorm:"type:time"` // Untuk flexitime
+	CoreEndTime         *time.Time       `json:"core_end_time" gorm:"type:time"`
+	MinWorkMinutes      int              `json:"min_work_minutes" gorm:"not null;default:480"`            // Untuk minimum_duration
+	WeekdayGraceMinutes map[int]int      `json:"weekday_grace_minutes" gorm:"type:jsonb;serializer:json"` // Override grace per weekday (0 = Minggu)
+	HolidayPolicy       HolidayPolicy    `json:"holiday_policy" gorm:"type:varchar(20);not null;default:'overtime'"`
+	AutoClockOutMode    AutoClockOutMode `json:"auto_clock_out_mode" gorm:"type:varchar(20);not null;default:'none'"` // Auto clock out untuk attendance yang lupa clock out
+	AutoClockOutTime    *time.Time       `json:"auto_clock_out_time" gorm:"type:time"`                                // Untuk mode fixed_time
+	AutoClockOutHours   int              `json:"auto_clock_out_hours" gorm:"not null;default:0"`                      // Untuk mode after_hours
+	MaxBreakMinutes     int              `json:"max_break_minutes" gorm:"not null;default:0"`                         // Total istirahat per hari, 0 = tanpa batas
+	RequireClockPhoto   bool             `json:"require_clock_photo" gorm:"not null;default:false"`                   // Foto selfie wajib saat clock in/out dari device karyawan
+	// Lembur: kurang dari OvertimeMinMinutes tidak dihitung, sisanya dibulatkan ke bawah per OvertimeRoundingMinutes
+	OvertimeMinMinutes      int            `json:"overtime_min_minutes" gorm:"not null;default:30"`
+	OvertimeRoundingMinutes int            `json:"overtime_rounding_minutes" gorm:"not null;default:15"`
+	CreatedAt               time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt               time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt               gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+type PunctualityRule string
+
+const (
+	PunctualityFixedWindow     PunctualityRule = "fixed_window"
+	PunctualityFlexitime       PunctualityRule = "flexitime"
+	PunctualityMinimumDuration PunctualityRule = "minimum_duration"
+)
+
+type AutoClockOutMode string
+
+const (
+	AutoClockOutNone       AutoClockOutMode = "none"
+	AutoClockOutFixedTime  AutoClockOutMode = "fixed_time"  // ditutup pada jam tertentu
+	AutoClockOutAfterHours AutoClockOutMode = "after_hours" // ditutup N jam setelah clock in
+)
+
 // New struct for Attendance (daily record)
 type Attendance struct {
-	ID           uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	EmployeeCode string         `gorm:"type:varchar(50);index;not null"` // FK to UserProfile.EmployeeCode
-	AttendanceID string         `gorm:"type:varchar(100);uniqueIndex"`   // Unique ID (e.g., generate as "EMP001-2025-09-15")
-	ClockIn      *time.Time     `gorm:"type:timestamp"`                  // Nullable
-	ClockOut     *time.Time     `gorm:"type:timestamp"`                  // Nullable
-	CreatedAt    time.Time      `gorm:"default:current_timestamp"`
-	UpdatedAt    time.Time      `gorm:"default:current_timestamp"`
-	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	ID             uuid.UUID        `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode   string           `gorm:"type:varchar(50);index;not null"` // FK to UserProfile.EmployeeCode
+	AttendanceID   string           `gorm:"type:varchar(100);uniqueIndex"`   // Unique ID (e.g., generate as "EMP001-2025-09-15")
+	WorkDate       *time.Time       `gorm:"type:date;index"`                 // Hari kerja (tanggal mulai shift), bisa beda dengan tanggal clock out
+	ClockIn        *time.Time       `gorm:"type:timestamp"`                  // Nullable
+	ClockOut       *time.Time       `gorm:"type:timestamp"`                  // Nullable
+	Status         AttendanceStatus `gorm:"type:attendance_status;not null;default:'present'"`
+	HolidayWork    bool             `gorm:"not null;default:false"`                         // Clock in di hari libur (dihitung lembur)
+	AutoClosed     bool             `gorm:"not null;default:false"`                         // Clock out diisi otomatis oleh sistem, bukan clock out asli
+	Mode           AttendanceMode   `gorm:"type:attendance_mode;not null;default:'office'"` // Lokasi kerja yang dipilih saat clock in
+	LeaveRequestID *uuid.UUID       `gorm:"type:uuid;index"`                                // Terisi jika record dibuat dari cuti yang disetujui
+	CreatedAt      time.Time        `gorm:"default:current_timestamp"`
+	UpdatedAt      time.Time        `gorm:"default:current_timestamp"`
+	DeletedAt      gorm.DeletedAt   `gorm:"index"`
+
+	// Hasil punctualityRule di usecase, disimpan supaya logs dan dashboard bisa filter, sort dan
+	// agregasi di SQL. Diisi job punctuality; PunctualityEvaluatedAt NULL berarti perlu dihitung ulang.
+	LateMinutes            int        `gorm:"not null;default:0"`
+	EarlyLeaveMinutes      int        `gorm:"not null;default:0"`
+	WorkedMinutes          *int       // NULL jika belum clock out
+	PunctualityEvaluatedAt *time.Time `gorm:"type:timestamp;index"`
 }
 
+type AttendanceStatus string
+
+const (
+	AttendanceStatusPresent AttendanceStatus = "present"
+	AttendanceStatusOnLeave AttendanceStatus = "on_leave"
+	AttendanceStatusAbsent  AttendanceStatus = "absent" // Dibuat otomatis oleh absence job
+)
+
 // New struct for AttendanceHistory (logs for each in/out action)
 
 type AttendanceType string
 
 const (
-	AttendanceTypeIn  AttendanceType = "in"
-	AttendanceTypeOut AttendanceType = "out"
+	AttendanceTypeIn         AttendanceType = "in"
+	AttendanceTypeOut        AttendanceType = "out"
+	AttendanceTypeAdjustment AttendanceType = "adjustment" // Koreksi yang disetujui admin
+	AttendanceTypeBreakStart AttendanceType = "break_start"
+	AttendanceTypeBreakEnd   AttendanceType = "break_end"
+)
+
+// AttendanceActor adalah pihak yang melakukan aksi pada attendance
+type AttendanceActor string
+
+const (
+	ActorEmployee AttendanceActor = "employee"
+	ActorAdmin    AttendanceActor = "admin"
+	ActorSystem   AttendanceActor = "system"
 )
 
 type AttendanceHistory struct {
-	ID             uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	EmployeeCode   string         `gorm:"type:varchar(50);index;not null"`  // FK to UserProfile.EmployeeCode
-	AttendanceID   string         `gorm:"type:varchar(100);index;not null"` // FK to Attendance.AttendanceID
-	DateAttendance time.Time      `gorm:"type:timestamp;not null"`
-	AttendanceType AttendanceType `gorm:"type:attendance_type;not null"` // in = In, out = Out
-	Description    string         `gorm:"type:text"`
-	CreatedAt      time.Time      `gorm:"default:current_timestamp"`
-	UpdatedAt      time.Time      `gorm:"default:current_timestamp"`
-	DeletedAt      gorm.DeletedAt `gorm:"index"`
+	ID               uuid.UUID       `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode     string          `gorm:"type:varchar(50);index;not null"`  // FK to UserProfile.EmployeeCode
+	AttendanceID     string          `gorm:"type:varchar(100);index;not null"` // FK to Attendance.AttendanceID
+	DateAttendance   time.Time       `gorm:"type:timestamp;not null"`
+	AttendanceType   AttendanceType  `gorm:"type:attendance_type;not null"` // in, out, break_start, break_end, adjustment = Koreksi
+	Description      string          `gorm:"type:text"`
+	Actor            AttendanceActor `gorm:"type:varchar(20);not null;default:'employee'"`
+	ActorUserID      *uuid.UUID      `gorm:"type:uuid"`             // User yang mengubah attendance jika bukan karyawan sendiri (e.g., admin yang approve koreksi)
+	Latitude         *float64        `gorm:"type:double precision"` // Lokasi yang dikirim device saat clock in/out
+	Longitude        *float64        `gorm:"type:double precision"`
+	LocationAccuracy *float64        `gorm:"type:double precision"` // Akurasi GPS dalam meter
+	OfficeLocationID *uuid.UUID      `gorm:"type:uuid"`             // Office location yang cocok dengan koordinat
+	KioskID          *uuid.UUID      `gorm:"type:uuid;index"`       // Kiosk tempat clock in/out dilakukan
+	ReaderID         *uuid.UUID      `gorm:"type:uuid;index"`       // Badge reader (RFID) tempat clock in/out dilakukan
+	PhotoKey         string          `gorm:"type:varchar(255)"`     // Key foto selfie di storage, kosong = tanpa foto
+	CreatedAt        time.Time       `gorm:"default:current_timestamp"`
+	UpdatedAt        time.Time       `gorm:"default:current_timestamp"`
+	DeletedAt        gorm.DeletedAt  `gorm:"index"`
 }
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type HolidayType string
+
+const (
+	HolidayNational HolidayType = "national"
+	HolidayCompany  HolidayType = "company"
+)
+
+// HolidayPolicy menentukan perilaku clock in pada hari libur per departemen
+type HolidayPolicy string
+
+const (
+	HolidayPolicyBlock    HolidayPolicy = "block"    // clock in ditolak
+	HolidayPolicyOvertime HolidayPolicy = "overtime" // clock in diizinkan dan dihitung lembur
+)
+
+// Holiday adalah hari libur nasional / cuti bersama perusahaan.
+// DepartmentID kosong berarti berlaku untuk semua departemen.
+type Holiday struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Date         time.Time      `json:"date" gorm:"type:date;index;not null"`
+	Name         string         `json:"name" gorm:"type:varchar(255);not null"`
+	Type         HolidayType    `json:"type" gorm:"type:holiday_type;not null;default:'national'"`
+	DepartmentID *uuid.UUID     `json:"department_id,omitempty" gorm:"type:uuid;index"`
+	ExternalUID  string         `json:"external_uid,omitempty" gorm:"type:varchar(255);index"` // UID VEVENT dari import .ics
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Department *Department `json:"department,omitempty" gorm:"foreignKey:DepartmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyRecord menyimpan response pertama untuk satu header Idempotency-Key, supaya
+// retry request yang sama (mis. tombol clock in ditekan dua kali saat koneksi buruk)
+// mendapat response yang sama tanpa menjalankan aksinya lagi. Key berlaku per scope:
+// user untuk endpoint dengan JWT, alamat IP untuk endpoint publik seperti signup.
+type IdempotencyRecord struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Scope       string    `gorm:"type:varchar(100);uniqueIndex:idx_idempotency_scope_key;not null"`
+	Key         string    `gorm:"type:varchar(255);uniqueIndex:idx_idempotency_scope_key;not null"`
+	RequestHash string    `gorm:"type:varchar(64);not null"` // HMAC-SHA256 method + path + body
+	StatusCode  int       `gorm:"not null;default:0"`        // 0 = request pertama masih diproses
+	ContentType string    `gorm:"type:varchar(100)"`
+	Body        []byte    `gorm:"type:bytea"`
+	LockedAt    time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"index;not null"`
+	CreatedAt   time.Time `gorm:"default:current_timestamp"`
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// JobRun mencatat kapan job background terakhir selesai tanpa error, supaya job yang
+// terlewat (mis. server mati semalaman) bisa mengejar hari yang belum diperiksa
+type JobRun struct {
+	Name          string    `gorm:"type:varchar(100);primaryKey"`
+	LastSuccessAt time.Time `gorm:"not null"`
+	UpdatedAt     time.Time `gorm:"default:current_timestamp"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KioskDevice adalah perangkat bersama (mis. tablet di pintu pabrik) yang menampilkan QR
+// berganti-ganti untuk clock in/out karyawan yang tidak punya device sendiri.
+// Kiosk mengautentikasi dirinya dengan API key (DeviceKey).
+type KioskDevice struct {
+	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name             string         `json:"name" gorm:"type:varchar(255);not null"`
+	DepartmentID     *uuid.UUID     `json:"department_id" gorm:"type:uuid;index"` // Kosong = bisa dipakai semua departemen
+	OfficeLocationID *uuid.UUID     `json:"office_location_id" gorm:"type:uuid"`  // Lokasi kiosk, dicatat di history sebagai pengganti GPS
+	CreatedBy        *uuid.UUID     `json:"created_by" gorm:"type:uuid"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+
+	DeviceKey
+
+	Department     *Department     `json:"department,omitempty" gorm:"foreignKey:DepartmentID"`
+	OfficeLocation *OfficeLocation `json:"office_location,omitempty" gorm:"foreignKey:OfficeLocationID"`
+}
+
+type KioskScanMethod string
+
+const (
+	KioskScanEmployee KioskScanMethod = "employee_scan" // Karyawan scan QR kiosk dari HP
+	KioskScanBadge    KioskScanMethod = "badge_scan"    // Kiosk scan QR badge karyawan
+)
+
+// KioskScan mencatat setiap scan di kiosk. Unique (nonce, employee_code) menolak QR kiosk
+// yang sama dipakai ulang oleh karyawan yang sama; scan badge tidak punya nonce.
+type KioskScan struct {
+	ID           uuid.UUID       `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	KioskID      uuid.UUID       `gorm:"type:uuid;index;not null"`
+	Method       KioskScanMethod `gorm:"type:varchar(20);not null"`
+	Nonce        *string         `gorm:"type:varchar(64);uniqueIndex:idx_kiosk_scans_nonce_employee"`
+	EmployeeCode string          `gorm:"type:varchar(50);uniqueIndex:idx_kiosk_scans_nonce_employee;index;not null"`
+	ScannedAt    time.Time       `gorm:"not null"`
+	CreatedAt    time.Time       `gorm:"default:current_timestamp"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApprovalStatus dipakai oleh semua request yang butuh persetujuan admin
+type ApprovalStatus string
+
+const (
+	ApprovalPending   ApprovalStatus = "pending"
+	ApprovalApproved  ApprovalStatus = "approved"
+	ApprovalRejected  ApprovalStatus = "rejected"
+	ApprovalCancelled ApprovalStatus = "cancelled"
+)
+
+// LeaveType adalah jenis cuti (tahunan, sakit, dinas, dll)
+type LeaveType struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Code        string         `json:"code" gorm:"type:varchar(30);uniqueIndex;not null"` // e.g., "ANNUAL", "SICK"
+	Name        string         `json:"name" gorm:"type:varchar(100);not null"`
+	IsPaid      bool           `json:"is_paid" gorm:"not null;default:true"`
+	AnnualQuota int            `json:"annual_quota" gorm:"not null;default:0"` // 0 = tanpa batas kuota
+	CreatedAt   time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// LeaveBalance adalah jatah cuti karyawan per jenis cuti per tahun
+type LeaveBalance struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode string         `json:"employee_code" gorm:"type:varchar(50);not null;uniqueIndex:idx_leave_balance"` // FK to UserProfile.EmployeeCode
+	LeaveTypeID  uuid.UUID      `json:"leave_type_id" gorm:"type:uuid;not null;uniqueIndex:idx_leave_balance"`
+	Year         int            `json:"year" gorm:"not null;uniqueIndex:idx_leave_balance"`
+	Entitled     int            `json:"entitled" gorm:"not null;default:0"`
+	Used         int            `json:"used" gorm:"not null;default:0"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	LeaveType *LeaveType `json:"leave_type,omitempty" gorm:"foreignKey:LeaveTypeID"`
+}
+
+// LeaveRequest adalah pengajuan cuti oleh karyawan
+type LeaveRequest struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode string         `json:"employee_code" gorm:"type:varchar(50);index;not null"` // FK to UserProfile.EmployeeCode
+	LeaveTypeID  uuid.UUID      `json:"leave_type_id" gorm:"type:uuid;index;not null"`
+	StartDate    time.Time      `json:"start_date" gorm:"type:date;not null"`
+	EndDate      time.Time      `json:"end_date" gorm:"type:date;not null"`
+	Days         int            `json:"days" gorm:"not null"` // Jumlah hari kerja yang terpotong
+	Reason       string         `json:"reason" gorm:"type:text"`
+	Status       ApprovalStatus `json:"status" gorm:"type:approval_status;not null;default:'pending'"`
+	ReviewedBy   *uuid.UUID     `json:"reviewed_by" gorm:"type:uuid"`
+	ReviewedAt   *time.Time     `json:"reviewed_at"`
+	ReviewNote   string         `json:"review_note" gorm:"type:text"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	LeaveType *LeaveType `json:"leave_type,omitempty" gorm:"foreignKey:LeaveTypeID"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OfficeLocation adalah area kantor (titik pusat + radius) tempat karyawan boleh clock in/out.
+// Departemen tanpa office location tidak dibatasi lokasinya.
+type OfficeLocation struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name         string         `json:"name" gorm:"type:varchar(255);not null"`
+	Address      string         `json:"address" gorm:"type:text"`
+	Latitude     float64        `json:"latitude" gorm:"not null"`
+	Longitude    float64        `json:"longitude" gorm:"not null"`
+	RadiusMeters int            `json:"radius_meters" gorm:"not null;default:100"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Departments []*Department `json:"departments,omitempty" gorm:"many2many:department_office_locations;"`
+}
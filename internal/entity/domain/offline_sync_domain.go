@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OfflineEventStatus string
+
+const (
+	OfflineEventPending  OfflineEventStatus = "pending" // Sedang diproses
+	OfflineEventApplied  OfflineEventStatus = "applied"
+	OfflineEventRejected OfflineEventStatus = "rejected"
+)
+
+// OfflineClockEvent mencatat event clock in/out yang direkam aplikasi mobile saat offline.
+// Event ID dibuat client; unique (employee_code, event_id) membuat upload ulang batch yang
+// sama tidak memproses event dua kali, dan hasil pertamanya dikembalikan lagi ke client.
+type OfflineClockEvent struct {
+	ID           uuid.UUID          `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID      uuid.UUID          `gorm:"type:uuid;uniqueIndex:idx_offline_events_employee_event;not null"`
+	EmployeeCode string             `gorm:"type:varchar(50);uniqueIndex:idx_offline_events_employee_event;not null"`
+	Action       string             `gorm:"type:varchar(20);not null"` // clock_in / clock_out
+	ClientTime   time.Time          `gorm:"not null"`
+	Status       OfflineEventStatus `gorm:"type:varchar(20);not null"`
+	Message      string             `gorm:"type:varchar(255)"` // Alasan penolakan
+	Conflict     string             `gorm:"type:varchar(30)"`  // Jenis konflik dengan attendance yang sudah ada, e.g. auto_clock_out
+	AttendanceID string             `gorm:"type:varchar(100)"`
+	ClaimedAt    time.Time          `gorm:"not null"` // Untuk mengambil alih event pending yang prosesnya terhenti
+	ProcessedAt  *time.Time
+	CreatedAt    time.Time `gorm:"default:current_timestamp"`
+}
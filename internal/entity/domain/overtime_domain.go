@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OvertimeKind string
+
+const (
+	OvertimePreApproval  OvertimeKind = "pre_approval"  // Diajukan sebelum lembur dikerjakan
+	OvertimePostApproval OvertimeKind = "post_approval" // Diajukan setelah clock out, menit diambil dari attendance
+)
+
+// OvertimeRequest adalah pengajuan lembur karyawan untuk satu hari kerja.
+// Menit yang dibayar tidak pernah melebihi lembur aktual dari attendance hari tersebut.
+type OvertimeRequest struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode    string         `json:"employee_code" gorm:"type:varchar(50);index;not null"` // FK to UserProfile.EmployeeCode
+	WorkDate        time.Time      `json:"work_date" gorm:"type:date;index;not null"`
+	Kind            OvertimeKind   `json:"kind" gorm:"type:overtime_kind;not null"`
+	Minutes         int            `json:"minutes" gorm:"not null"`                    // Menit yang diajukan
+	ApprovedMinutes int            `json:"approved_minutes" gorm:"not null;default:0"` // Menit yang disetujui admin
+	Reason          string         `json:"reason" gorm:"type:text;not null"`
+	Status          ApprovalStatus `json:"status" gorm:"type:approval_status;not null;default:'pending'"`
+	ReviewedBy      *uuid.UUID     `json:"reviewed_by" gorm:"type:uuid"`
+	ReviewedAt      *time.Time     `json:"reviewed_at"`
+	ReviewNote      string         `json:"review_note" gorm:"type:text"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PayPeriodStatus string
+
+const (
+	PayPeriodOpen   PayPeriodStatus = "open"
+	PayPeriodLocked PayPeriodStatus = "locked" // Attendance di rentang ini tidak bisa diubah lagi
+)
+
+// PayPeriod adalah periode gaji yang sudah diexport ke payroll. Selama locked, perubahan
+// attendance (clock, koreksi, cuti, lembur) pada tanggal di rentang ini ditolak.
+type PayPeriod struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	StartDate      time.Time       `json:"start_date" gorm:"type:date;index;not null"`
+	EndDate        time.Time       `json:"end_date" gorm:"type:date;index;not null"`
+	Status         PayPeriodStatus `json:"status" gorm:"type:varchar(20);not null;default:'locked'"`
+	Note           string          `json:"note" gorm:"type:text"`
+	LockedBy       *uuid.UUID      `json:"locked_by" gorm:"type:uuid"`
+	LockedAt       *time.Time      `json:"locked_at"`
+	UnlockedBy     *uuid.UUID      `json:"unlocked_by" gorm:"type:uuid"`
+	UnlockedAt     *time.Time      `json:"unlocked_at"`
+	LastExportedAt *time.Time      `json:"last_exported_at"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt      time.Time       `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt      gorm.DeletedAt  `json:"-" gorm:"index"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Shift adalah jadwal kerja (pagi/sore/malam) yang bisa di-assign ke karyawan
+type Shift struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name         string         `json:"name" gorm:"type:varchar(100);not null"`
+	StartTime    time.Time      `json:"start_time" gorm:"type:time;not null"`
+	EndTime      time.Time      `json:"end_time" gorm:"type:time;not null"`
+	GraceMinutes int            `json:"grace_minutes" gorm:"not null;default:0"`
+	Weekdays     []int          `json:"weekdays" gorm:"type:jsonb;serializer:json;not null"` // 0 = Minggu ... 6 = Sabtu
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// ShiftAssignment menghubungkan karyawan ke shift untuk rentang tanggal tertentu
+type ShiftAssignment struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EmployeeCode  string         `json:"employee_code" gorm:"type:varchar(50);index;not null"` // FK to UserProfile.EmployeeCode
+	ShiftID       uuid.UUID      `json:"shift_id" gorm:"type:uuid;index;not null"`
+	EffectiveFrom time.Time      `json:"effective_from" gorm:"type:date;not null"`
+	EffectiveTo   *time.Time     `json:"effective_to" gorm:"type:date"` // Nullable, berarti berlaku seterusnya
+	CreatedAt     time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Shift *Shift `json:"shift,omitempty" gorm:"foreignKey:ShiftID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// AppliesOn mengecek apakah shift berlaku di hari tersebut
+func (s *Shift) AppliesOn(day time.Weekday) bool {
+	for _, d := range s.Weekdays {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
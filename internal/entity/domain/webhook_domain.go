@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Jenis event yang bisa dilanggan webhook
+const (
+	WebhookEventClockIn            = "attendance.clock_in"
+	WebhookEventClockOut           = "attendance.clock_out"
+	WebhookEventCorrectionApproved = "attendance.correction_approved"
+	WebhookEventUserSignup         = "user.signup"
+	WebhookEventDepartmentAssigned = "department.assigned"
+)
+
+// WebhookSubscription adalah endpoint eksternal (chat, HR tools) yang menerima event terpilih.
+// Payload ditandatangani HMAC-SHA256 memakai Secret.
+type WebhookSubscription struct {
+	ID         uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name       string         `json:"name" gorm:"type:varchar(255);not null"`
+	URL        string         `json:"url" gorm:"type:text;not null"`
+	Secret     string         `json:"-" gorm:"type:varchar(255);not null"`
+	EventTypes []string       `json:"event_types" gorm:"type:jsonb;serializer:json;not null"`
+	IsActive   bool           `json:"is_active" gorm:"not null;default:true"`
+	CreatedBy  *uuid.UUID     `json:"created_by" gorm:"type:uuid"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt  time.Time      `json:"updated_at" gorm:"default:current_timestamp"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending" // Menunggu dikirim atau dicoba ulang
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // Semua percobaan gagal, bisa di-replay admin
+)
+
+// WebhookDelivery adalah log pengiriman satu event ke satu subscription. Payload disimpan apa adanya
+// (text, bukan jsonb) supaya signature tetap cocok saat dikirim ulang.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SubscriptionID uuid.UUID             `json:"subscription_id" gorm:"type:uuid;index;not null"`
+	EventID        uuid.UUID             `json:"event_id" gorm:"type:uuid;index;not null"`
+	EventType      string                `json:"event_type" gorm:"type:varchar(100);not null"`
+	Payload        string                `json:"payload" gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"type:varchar(20);index;not null;default:'pending'"`
+	Attempts       int                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at" gorm:"index"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at"`
+	ResponseStatus *int                  `json:"response_status"`
+	ResponseBody   string                `json:"response_body" gorm:"type:text"` // Dipotong maksimal 1 KB
+	LastError      string                `json:"last_error" gorm:"type:text"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+	ReplayOf       *uuid.UUID            `json:"replay_of" gorm:"type:uuid"` // Delivery asal jika hasil replay
+	CreatedAt      time.Time             `json:"created_at" gorm:"default:current_timestamp"`
+	UpdatedAt      time.Time             `json:"updated_at" gorm:"default:current_timestamp"`
+
+	// Relationships
+	Subscription *WebhookSubscription `json:"-" gorm:"foreignKey:SubscriptionID"`
+}
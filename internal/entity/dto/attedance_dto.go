@@ -13,14 +13,30 @@ type GetAttendanceHistoryRequest struct {
 }
 
 type AttendanceHistoryResponse struct {
-	ID             uuid.UUID `json:"id"`
-	EmployeeCode   string    `json:"employee_code"`
-	AttendanceID   string    `json:"attendance_id"`
-	DateAttendance time.Time `json:"date_attendance"`
-	AttendanceType string    `json:"attendance_type"` // "in" or "out"
-	Description    string    `json:"description"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	EmployeeCode     string     `json:"employee_code"`
+	AttendanceID     string     `json:"attendance_id"`
+	DateAttendance   time.Time  `json:"date_attendance"`
+	AttendanceType   string     `json:"attendance_type"` // "in", "out", "break_start", "break_end" or "adjustment"
+	Description      string     `json:"description"`
+	Actor            string     `json:"actor"` // "employee", "admin" or "system"
+	ActorUserID      *uuid.UUID `json:"actor_user_id,omitempty"`
+	Latitude         *float64   `json:"latitude,omitempty"`
+	Longitude        *float64   `json:"longitude,omitempty"`
+	LocationAccuracy *float64   `json:"location_accuracy,omitempty"`
+	OfficeLocationID *uuid.UUID `json:"office_location_id,omitempty"`
+	KioskID          *uuid.UUID `json:"kiosk_id,omitempty"`
+	ReaderID         *uuid.UUID `json:"reader_id,omitempty"`
+	HasPhoto         bool       `json:"has_photo"` // URL foto diambil lewat /attendance/history/:id/photo
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// AttendancePhotoURLResponse berisi signed URL foto selfie yang hanya berlaku sebentar
+type AttendancePhotoURLResponse struct {
+	HistoryID uuid.UUID `json:"history_id"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // Untuk Admin Dashboard
@@ -33,6 +49,74 @@ type AdminDashboardResponse struct {
 	TotalEmployeesPerDept   map[string]int `json:"total_employees_per_dept"` // Key: Dept Name, Value: Count
 	TotalUpdatedDepts       int            `json:"total_updated_depts"`
 	TotalTodayRegistrations int            `json:"total_today_registrations"`
+
+	// KPI attendance untuk rentang start_date - end_date
+	StartDate        string                     `json:"start_date"`
+	EndDate          string                     `json:"end_date"`
+	ClockedInNow     int                        `json:"clocked_in_now"` // Karyawan yang sedang clock in (belum clock out)
+	Attendance       DashboardAttendanceStats   `json:"attendance"`     // Seluruh departemen
+	Departments      []DashboardAttendanceStats `json:"departments"`
+	DailyTrend       []DashboardTrendPoint      `json:"daily_trend"`
+	TopLateEmployees []DashboardLateEmployee    `json:"top_late_employees"`
+}
+
+// Statistik attendance hasil agregasi SQL. Persentase dibulatkan 2 desimal.
+type DashboardAttendanceStats struct {
+	DepartmentName     string  `json:"department_name,omitempty"`
+	PresentDays        int     `json:"present_days"`
+	AbsentDays         int     `json:"absent_days"`
+	LeaveDays          int     `json:"leave_days"`
+	LateDays           int     `json:"late_days"`
+	AttendanceRate     float64 `json:"attendance_rate"`      // Hadir / (hadir + absent), cuti tidak dihitung
+	LateRate           float64 `json:"late_rate"`            // Terlambat / hadir
+	AverageLateMinutes float64 `json:"average_late_minutes"` // Rata-rata menit terlambat per hari terlambat
+}
+
+type DashboardTrendPoint struct {
+	Date           string  `json:"date"`
+	Present        int     `json:"present"`
+	Late           int     `json:"late"`
+	Absent         int     `json:"absent"`
+	OnLeave        int     `json:"on_leave"`
+	AttendanceRate float64 `json:"attendance_rate"`
+}
+
+type DashboardLateEmployee struct {
+	EmployeeCode       string  `json:"employee_code"`
+	FullName           string  `json:"full_name"`
+	DepartmentName     string  `json:"department_name"`
+	LateDays           int     `json:"late_days"`
+	TotalLateMinutes   int     `json:"total_late_minutes"`
+	AverageLateMinutes float64 `json:"average_late_minutes"`
+}
+
+// Jenis event pada feed attendance real-time
+const (
+	AttendanceEventClockIn            = "clock_in"
+	AttendanceEventClockOut           = "clock_out"
+	AttendanceEventCorrectionApproved = "correction_approved"
+)
+
+// AttendanceEvent dikirim ke subscriber feed setiap ada perubahan attendance
+type AttendanceEvent struct {
+	ID             uuid.UUID  `json:"id"`
+	Type           string     `json:"type"`
+	EmployeeCode   string     `json:"employee_code"`
+	FullName       string     `json:"full_name"`
+	DepartmentID   *uuid.UUID `json:"department_id"` // Nil untuk karyawan tanpa departemen (hanya dikirim ke webhook)
+	DepartmentName string     `json:"department_name"`
+	AttendanceID   string     `json:"attendance_id"`
+	WorkDate       string     `json:"work_date"`
+	Status         string     `json:"status"`
+	Mode           string     `json:"mode"`
+	ClockIn        *time.Time `json:"clock_in"`
+	ClockOut       *time.Time `json:"clock_out"`
+	Actor          string     `json:"actor"` // employee, admin, atau system
+	OccurredAt     time.Time  `json:"occurred_at"`
+}
+
+type AttendanceFeedRequest struct {
+	DepartmentID *uuid.UUID `query:"department_id" validate:"omitempty,uuid"` // Kosong = semua departemen yang boleh dilihat
 }
 
 type CheckCurrentStatusRequest struct {
@@ -45,8 +129,120 @@ type CurrentStatusResponse struct {
 	EmployeeCode string     `json:"employee_code"`
 	FullName     string     `json:"full_name"`
 	Department   string     `json:"department,omitempty"`
-	Status       string     `json:"status"` // "Clocked In", "Clocked Out", "Not Clocked"
+	Status       string     `json:"status"` // "Clocked In", "On Break", "Clocked Out", "On Leave", "Not Clocked"
 	ClockIn      *time.Time `json:"clock_in,omitempty"`
 	ClockOut     *time.Time `json:"clock_out,omitempty"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	// Penilaian punctuality hari ini sesuai rule departemen
+	InPunctuality     string    `json:"in_punctuality"`
+	OutPunctuality    string    `json:"out_punctuality"`
+	LateMinutes       int       `json:"late_minutes"`
+	EarlyLeaveMinutes int       `json:"early_leave_minutes"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type GetTimesheetRequest struct {
+	UserID *uuid.UUID `query:"user_id" validate:"omitempty,uuid"` // Kosong berarti timesheet diri sendiri
+	Month  string     `query:"month" validate:"required,datetime=2006-01"`
+}
+
+// Satu baris timesheet per tanggal kalender
+type TimesheetDayResponse struct {
+	Date              string     `json:"date"` // YYYY-MM-DD
+	Weekday           string     `json:"weekday"`
+	DayType           string     `json:"day_type"` // "worked", "absent", "leave", "holiday", "weekend", "upcoming" or "not_joined"
+	WorkingDay        bool       `json:"working_day"`
+	HolidayName       *string    `json:"holiday_name,omitempty"`
+	AttendanceID      *string    `json:"attendance_id,omitempty"`
+	ShiftName         string     `json:"shift_name,omitempty"`
+	Mode              string     `json:"mode,omitempty"`
+	ClockIn           *time.Time `json:"clock_in"`
+	ClockOut          *time.Time `json:"clock_out"`
+	WorkedMinutes     int        `json:"worked_minutes"`
+	LateMinutes       int        `json:"late_minutes"`
+	EarlyLeaveMinutes int        `json:"early_leave_minutes"`
+	OvertimeMinutes   int        `json:"overtime_minutes"`
+	InPunctuality     string     `json:"in_punctuality"`
+	OutPunctuality    string     `json:"out_punctuality"`
+}
+
+type TimesheetTotalsResponse struct {
+	WorkingDays       int `json:"working_days"` // Hari kerja sesuai kalender (tanpa libur dan akhir pekan)
+	WorkedDays        int `json:"worked_days"`
+	AbsentDays        int `json:"absent_days"`
+	LeaveDays         int `json:"leave_days"`
+	HolidayDays       int `json:"holiday_days"`
+	WeekendDays       int `json:"weekend_days"`
+	LateDays          int `json:"late_days"`
+	WorkedMinutes     int `json:"worked_minutes"`
+	LateMinutes       int `json:"late_minutes"`
+	EarlyLeaveMinutes int `json:"early_leave_minutes"`
+	OvertimeMinutes   int `json:"overtime_minutes"`
+}
+
+type TimesheetResponse struct {
+	UserID       uuid.UUID               `json:"user_id"`
+	EmployeeCode string                  `json:"employee_code"`
+	FullName     string                  `json:"full_name"`
+	Department   string                  `json:"department,omitempty"`
+	Month        string                  `json:"month"` // YYYY-MM
+	Days         []TimesheetDayResponse  `json:"days"`
+	Totals       TimesheetTotalsResponse `json:"totals"`
+}
+
+// Laporan attendance per departemen atau per karyawan (salah satu wajib diisi)
+type AttendanceReportRequest struct {
+	DepartmentID *uuid.UUID `query:"department_id" validate:"required_without=UserID,omitempty,uuid"`
+	UserID       *uuid.UUID `query:"user_id" validate:"omitempty,uuid"`
+	StartDate    string     `query:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string     `query:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+// Ringkasan per karyawan
+type AttendanceReportEmployee struct {
+	EmployeeCode      string `json:"employee_code"`
+	FullName          string `json:"full_name"`
+	DepartmentName    string `json:"department_name"`
+	PresentDays       int    `json:"present_days"`
+	AbsentDays        int    `json:"absent_days"`
+	LeaveDays         int    `json:"leave_days"`
+	LateDays          int    `json:"late_days"`
+	LateMinutes       int    `json:"late_minutes"`
+	EarlyLeaveDays    int    `json:"early_leave_days"`
+	EarlyLeaveMinutes int    `json:"early_leave_minutes"`
+	WorkedMinutes     int    `json:"worked_minutes"`
+	OvertimeMinutes   int    `json:"overtime_minutes"`
+}
+
+// Statistik keterlambatan untuk seluruh laporan
+type AttendanceReportLateness struct {
+	PresentDays        int     `json:"present_days"`
+	LateDays           int     `json:"late_days"`
+	LateRate           float64 `json:"late_rate"` // Persentase hari hadir yang terlambat
+	TotalLateMinutes   int     `json:"total_late_minutes"`
+	AverageLateMinutes float64 `json:"average_late_minutes"` // Rata-rata per hari terlambat
+	MaxLateMinutes     int     `json:"max_late_minutes"`
+	EarlyLeaveDays     int     `json:"early_leave_days"`
+}
+
+// Rekap per tanggal
+type AttendanceReportDay struct {
+	Date            string `json:"date"` // YYYY-MM-DD
+	Present         int    `json:"present"`
+	Late            int    `json:"late"`
+	EarlyLeave      int    `json:"early_leave"`
+	Absent          int    `json:"absent"`
+	OnLeave         int    `json:"on_leave"`
+	WorkedMinutes   int    `json:"worked_minutes"`
+	OvertimeMinutes int    `json:"overtime_minutes"`
+}
+
+type AttendanceReportResponse struct {
+	Title       string                     `json:"title"` // Nama departemen atau karyawan
+	Scope       string                     `json:"scope"` // "department" or "employee"
+	StartDate   string                     `json:"start_date"`
+	EndDate     string                     `json:"end_date"`
+	GeneratedAt time.Time                  `json:"generated_at"`
+	Employees   []AttendanceReportEmployee `json:"employees"`
+	Lateness    AttendanceReportLateness   `json:"lateness"`
+	Days        []AttendanceReportDay      `json:"days"`
 }
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateBadgeRequest struct {
+	Kind         string `json:"kind" validate:"omitempty,oneof=rfid qr"` // Default rfid
+	UID          string `json:"uid" validate:"omitempty,min=4,max=64"`   // Wajib untuk rfid, kosong untuk qr
+	EmployeeCode string `json:"employee_code" validate:"required,max=50"`
+	Label        string `json:"label" validate:"omitempty,max=100"`
+}
+
+type DeactivateBadgeRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=1000"`
+}
+
+type ListBadgesRequest struct {
+	EmployeeCode string `query:"employee_code" validate:"omitempty,max=50"`
+	Status       string `query:"status" validate:"omitempty,oneof=active lost deactivated"`
+	Page         int    `query:"page" validate:"omitempty,min=1"`
+	Limit        int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type BadgeResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	Kind              string     `json:"kind"`
+	UID               string     `json:"uid,omitempty"`
+	EmployeeCode      string     `json:"employee_code"`
+	Status            string     `json:"status"`
+	Label             string     `json:"label"`
+	LastScanAt        *time.Time `json:"last_scan_at"`
+	DeactivatedAt     *time.Time `json:"deactivated_at"`
+	DeactivatedReason string     `json:"deactivated_reason,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type CreateBadgeReaderRequest struct {
+	Name             string     `json:"name" validate:"required,min=2,max=255"`
+	OfficeLocationID *uuid.UUID `json:"office_location_id" validate:"omitempty"`
+}
+
+type UpdateBadgeReaderRequest struct {
+	Name             string     `json:"name" validate:"omitempty,min=2,max=255"`
+	OfficeLocationID *uuid.UUID `json:"office_location_id" validate:"omitempty"`
+	IsActive         *bool      `json:"is_active"`
+}
+
+type BadgeReaderResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	OfficeLocationID *uuid.UUID `json:"office_location_id"`
+	APIKey           string     `json:"api_key,omitempty"` // Hanya dikembalikan saat dibuat atau di-rotate
+	IsActive         bool       `json:"is_active"`
+	LastSeenAt       *time.Time `json:"last_seen_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// BadgeTapRequest dikirim reader setiap kali kartu ditempel
+type BadgeTapRequest struct {
+	UID string `json:"uid" validate:"required,min=4,max=64"`
+}
+
+type BadgeTapResponse struct {
+	EmployeeCode string              `json:"employee_code"`
+	FullName     string              `json:"full_name"`
+	Action       string              `json:"action"` // clock_in / clock_out
+	Attendance   *AttendanceResponse `json:"attendance"`
+}
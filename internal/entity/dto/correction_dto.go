@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Untuk Attendance Correction
+type CreateCorrectionRequest struct {
+	WorkDate string `json:"work_date" validate:"required,datetime=2006-01-02"`
+	Type     string `json:"type" validate:"required,oneof=missing_clock_in missing_clock_out wrong_time"`
+	ClockIn  string `json:"clock_in" validate:"omitempty,datetime=2006-01-02 15:04:05"`  // e.g., "2025-09-15 08:05:00"
+	ClockOut string `json:"clock_out" validate:"omitempty,datetime=2006-01-02 15:04:05"` // e.g., "2025-09-15 17:10:00"
+	Reason   string `json:"reason" validate:"required,min=5,max=1000"`
+}
+
+type ReviewCorrectionRequest struct {
+	Note string `json:"note" validate:"omitempty,max=1000"`
+}
+
+type ListCorrectionsRequest struct {
+	UserID *uuid.UUID `query:"user_id" validate:"omitempty"`
+	Status string     `query:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+	Page   int        `query:"page" validate:"omitempty,min=1"`          // Default 1
+	Limit  int        `query:"limit" validate:"omitempty,min=1,max=100"` // Default 10
+}
+
+type CorrectionResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	EmployeeCode     string     `json:"employee_code"`
+	AttendanceID     string     `json:"attendance_id"`
+	WorkDate         string     `json:"work_date"`
+	Type             string     `json:"type"`
+	ClockIn          *time.Time `json:"clock_in"`
+	ClockOut         *time.Time `json:"clock_out"`
+	OriginalClockIn  *time.Time `json:"original_clock_in"`
+	OriginalClockOut *time.Time `json:"original_clock_out"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"`
+	ReviewedBy       *uuid.UUID `json:"reviewed_by"`
+	ReviewedAt       *time.Time `json:"reviewed_at"`
+	ReviewNote       string     `json:"review_note"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
@@ -11,36 +11,143 @@ type CreateDepartmentRequest struct {
 	Name            string `json:"name" validate:"required,min=3,max=255"`
 	MaxClockInTime  string `json:"max_clock_in_time" validate:"required"`  // e.g., "09:00:00"
 	MaxClockOutTime string `json:"max_clock_out_time" validate:"required"` // e.g., "17:00:00"
+	HolidayPolicy   string `json:"holiday_policy" validate:"omitempty,oneof=block overtime"`
+	// Auto clock out: fixed_time butuh auto_clock_out_time, after_hours butuh auto_clock_out_hours
+	AutoClockOutMode  string `json:"auto_clock_out_mode" validate:"omitempty,oneof=none fixed_time after_hours"`
+	AutoClockOutTime  string `json:"auto_clock_out_time" validate:"omitempty,datetime=15:04:05"` // e.g., "23:00:00"
+	AutoClockOutHours int    `json:"auto_clock_out_hours" validate:"omitempty,min=1,max=24"`
+	MaxBreakMinutes   int    `json:"max_break_minutes" validate:"omitempty,min=0,max=480"` // 0 = tanpa batas
+	RequireClockPhoto bool   `json:"require_clock_photo"`                                  // Foto selfie wajib saat clock in/out
+	GraceMinutes      int    `json:"grace_minutes" validate:"omitempty,min=0,max=120"`     // Toleransi terlambat untuk karyawan tanpa shift
+	// Punctuality rule: flexitime butuh core_start_time dan core_end_time, minimum_duration memakai min_work_minutes (default 480)
+	PunctualityRule     string      `json:"punctuality_rule" validate:"omitempty,oneof=fixed_window flexitime minimum_duration"`
+	CoreStartTime       string      `json:"core_start_time" validate:"omitempty,datetime=15:04:05"` // e.g., "10:00:00"
+	CoreEndTime         string      `json:"core_end_time" validate:"omitempty,datetime=15:04:05"`   // e.g., "15:00:00"
+	MinWorkMinutes      int         `json:"min_work_minutes" validate:"omitempty,min=1,max=1440"`
+	WeekdayGraceMinutes map[int]int `json:"weekday_grace_minutes" validate:"omitempty,dive,keys,min=0,max=6,endkeys,min=0,max=120"` // e.g., {"1": 15} = Senin 15 menit
+	// Lembur: default minimal 30 menit, dibulatkan ke bawah per 15 menit
+	OvertimeMinMinutes      *int `json:"overtime_min_minutes" validate:"omitempty,min=0,max=240"`
+	OvertimeRoundingMinutes *int `json:"overtime_rounding_minutes" validate:"omitempty,min=0,max=60"` // 0 = tanpa pembulatan
 }
 
 type UpdateDepartmentRequest struct {
 	Name            string    `json:"name" validate:"omitempty,min=3,max=255"`
 	MaxClockInTime  time.Time `json:"max_clock_in_time" validate:"omitempty"`  // hanya jam
 	MaxClockOutTime time.Time `json:"max_clock_out_time" validate:"omitempty"` // hanya jam
+	HolidayPolicy   string    `json:"holiday_policy" validate:"omitempty,oneof=block overtime"`
+	// Auto clock out: fixed_time butuh auto_clock_out_time, after_hours butuh auto_clock_out_hours
+	AutoClockOutMode  string `json:"auto_clock_out_mode" validate:"omitempty,oneof=none fixed_time after_hours"`
+	AutoClockOutTime  string `json:"auto_clock_out_time" validate:"omitempty,datetime=15:04:05"` // e.g., "23:00:00"
+	AutoClockOutHours *int   `json:"auto_clock_out_hours" validate:"omitempty,min=1,max=24"`
+	MaxBreakMinutes   *int   `json:"max_break_minutes" validate:"omitempty,min=0,max=480"` // 0 = tanpa batas
+	RequireClockPhoto *bool  `json:"require_clock_photo"`                                  // Foto selfie wajib saat clock in/out
+	GraceMinutes      *int   `json:"grace_minutes" validate:"omitempty,min=0,max=120"`     // Toleransi terlambat untuk karyawan tanpa shift
+	// Punctuality rule: flexitime butuh core_start_time dan core_end_time, minimum_duration memakai min_work_minutes (default 480)
+	PunctualityRule     string      `json:"punctuality_rule" validate:"omitempty,oneof=fixed_window flexitime minimum_duration"`
+	CoreStartTime       string      `json:"core_start_time" validate:"omitempty,datetime=15:04:05"` // e.g., "10:00:00"
+	CoreEndTime         string      `json:"core_end_time" validate:"omitempty,datetime=15:04:05"`   // e.g., "15:00:00"
+	MinWorkMinutes      *int        `json:"min_work_minutes" validate:"omitempty,min=1,max=1440"`
+	WeekdayGraceMinutes map[int]int `json:"weekday_grace_minutes" validate:"omitempty,dive,keys,min=0,max=6,endkeys,min=0,max=120"` // e.g., {"1": 15} = Senin 15 menit
+	// Lembur: default minimal 30 menit, dibulatkan ke bawah per 15 menit
+	OvertimeMinMinutes      *int `json:"overtime_min_minutes" validate:"omitempty,min=0,max=240"`
+	OvertimeRoundingMinutes *int `json:"overtime_rounding_minutes" validate:"omitempty,min=0,max=60"` // 0 = tanpa pembulatan
 }
 
 type DepartmentResponse struct {
-	ID              uuid.UUID `json:"id"`
-	Name            string    `json:"name"`
-	MaxClockInTime  time.Time `json:"max_clock_in_time"`
-	MaxClockOutTime time.Time `json:"max_clock_out_time"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID                      uuid.UUID   `json:"id"`
+	Name                    string      `json:"name"`
+	MaxClockInTime          time.Time   `json:"max_clock_in_time"`
+	MaxClockOutTime         time.Time   `json:"max_clock_out_time"`
+	HolidayPolicy           string      `json:"holiday_policy"`
+	AutoClockOutMode        string      `json:"auto_clock_out_mode"`
+	AutoClockOutTime        *string     `json:"auto_clock_out_time"`
+	AutoClockOutHours       int         `json:"auto_clock_out_hours"`
+	MaxBreakMinutes         int         `json:"max_break_minutes"`
+	RequireClockPhoto       bool        `json:"require_clock_photo"`
+	GraceMinutes            int         `json:"grace_minutes"`
+	PunctualityRule         string      `json:"punctuality_rule"`
+	CoreStartTime           *string     `json:"core_start_time"`
+	CoreEndTime             *string     `json:"core_end_time"`
+	MinWorkMinutes          int         `json:"min_work_minutes"`
+	WeekdayGraceMinutes     map[int]int `json:"weekday_grace_minutes"`
+	OvertimeMinMinutes      int         `json:"overtime_min_minutes"`
+	OvertimeRoundingMinutes int         `json:"overtime_rounding_minutes"`
+	CreatedAt               time.Time   `json:"created_at"`
+	UpdatedAt               time.Time   `json:"updated_at"`
 }
 
 // Untuk Attendance
+// Lokasi wajib dikirim jika departemen karyawan punya office location
 type ClockInRequest struct {
-	// Kosong, karena auto-detect dari user
+	Mode      string      `json:"mode" validate:"omitempty,oneof=office remote field_visit business_trip"` // Default office
+	Latitude  *float64    `json:"latitude" validate:"omitempty,latitude,required_with=Longitude"`
+	Longitude *float64    `json:"longitude" validate:"omitempty,longitude,required_with=Latitude"`
+	Accuracy  *float64    `json:"accuracy" validate:"omitempty,min=0"` // Akurasi GPS dalam meter
+	Source    ClockSource `json:"-"`
+	Photo     *ClockPhoto `json:"-"` // Foto selfie dari upload multipart
 }
 
 type ClockOutRequest struct {
-	// Kosong, sama
+	Latitude  *float64    `json:"latitude" validate:"omitempty,latitude,required_with=Longitude"`
+	Longitude *float64    `json:"longitude" validate:"omitempty,longitude,required_with=Latitude"`
+	Accuracy  *float64    `json:"accuracy" validate:"omitempty,min=0"`
+	Source    ClockSource `json:"-"`
+	Photo     *ClockPhoto `json:"-"`
+}
+
+// ClockSource diisi server (bukan dari body) saat clock in/out dilakukan lewat perangkat
+// bersama atau dari sinkronisasi offline. Kehadiran lewat perangkat sudah dibuktikan
+// perangkat, jadi geofence GPS tidak dicek.
+type ClockSource struct {
+	KioskID          *uuid.UUID
+	ReaderID         *uuid.UUID
+	OfficeLocationID *uuid.UUID
+	OccurredAt       *time.Time // Waktu event offline dari client; kosong = waktu server
+}
+
+// ClockPhoto adalah foto selfie bukti clock in/out. Jenis file ditentukan dari isinya, bukan header client.
+type ClockPhoto struct {
+	Content []byte
+}
+
+// Device mengembalikan nama perangkat untuk deskripsi history, kosong jika dari device karyawan
+func (s ClockSource) Device() string {
+	switch {
+	case s.KioskID != nil:
+		return "kiosk"
+	case s.ReaderID != nil:
+		return "badge reader"
+	}
+	return ""
+}
+
+// Time mengembalikan waktu clock in/out: waktu client untuk event offline, selain itu sekarang
+func (s ClockSource) Time() time.Time {
+	if s.OccurredAt != nil {
+		return *s.OccurredAt
+	}
+	return time.Now()
+}
+
+type BreakStartRequest struct {
+	Type string `json:"type" validate:"required,oneof=lunch prayer other"`
+}
+
+type AttendanceBreakResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	AttendanceID string     `json:"attendance_id"`
+	Type         string     `json:"type"`
+	StartedAt    time.Time  `json:"started_at"`
+	EndedAt      *time.Time `json:"ended_at"`
+	Minutes      int        `json:"minutes"` // Durasi istirahat, 0 jika masih berjalan
 }
 
 type AttendanceResponse struct {
 	ID           uuid.UUID  `json:"id"`
 	EmployeeCode string     `json:"employee_code"`
 	AttendanceID string     `json:"attendance_id"`
+	WorkDate     *time.Time `json:"work_date"`
+	Mode         string     `json:"mode"`
 	ClockIn      *time.Time `json:"clock_in"`
 	ClockOut     *time.Time `json:"clock_out"`
 	CreatedAt    time.Time  `json:"created_at"`
@@ -48,24 +155,48 @@ type AttendanceResponse struct {
 }
 
 type AttendanceLogResponse struct {
-	AttendanceID    string     `json:"attendance_id"`
-	EmployeeCode    string     `json:"employee_code"`
-	FullName        string     `json:"full_name"`
-	DepartmentName  string     `json:"department_name"`
-	ClockIn         *time.Time `json:"clock_in"`
-	ClockOut        *time.Time `json:"clock_out"`
-	InPunctuality   string     `json:"in_punctuality"`  // "On Time" or "Late"
-	OutPunctuality  string     `json:"out_punctuality"` // "On Time" or "Early Leave"
-	MaxClockInTime  *time.Time `json:"-"`               // hanya untuk perhitungan, tidak dikirim ke client
-	MaxClockOutTime *time.Time `json:"-"`
+	AttendanceID      string     `json:"attendance_id"`
+	EmployeeCode      string     `json:"employee_code"`
+	FullName          string     `json:"full_name"`
+	DepartmentName    string     `json:"department_name"`
+	ShiftName         string     `json:"shift_name,omitempty"`
+	WorkDate          string     `json:"work_date"` // YYYY-MM-DD, tanggal mulai shift
+	ClockIn           *time.Time `json:"clock_in"`
+	ClockOut          *time.Time `json:"clock_out"`
+	HolidayWork       bool       `json:"holiday_work"`        // true jika masuk di hari libur (lembur)
+	AutoClosed        bool       `json:"auto_closed"`         // true jika clock out diisi otomatis oleh sistem
+	Mode              string     `json:"mode"`                // "office", "remote", "field_visit" or "business_trip"
+	LateMinutes       int        `json:"late_minutes"`        // Menit terlambat dari jam masuk, 0 jika masih dalam grace period
+	EarlyLeaveMinutes int        `json:"early_leave_minutes"` // Menit pulang lebih cepat dari jam pulang
+	BreakMinutes      int        `json:"break_minutes"`       // Total istirahat
+	WorkedMinutes     *int       `json:"worked_minutes"`      // Clock out - clock in dikurangi istirahat, nil jika belum clock out
+	BreakViolation    bool       `json:"break_violation"`     // true jika istirahat melebihi batas departemen
+	OvertimeMinutes   int        `json:"overtime_minutes"`    // Lembur aktual setelah threshold dan pembulatan departemen
+	Status            string     `json:"status"`              // "present", "on_leave" or "absent"
+	InPunctuality     string     `json:"in_punctuality"`      // "On Time", "Late", "On Leave" or "Absent"
+	OutPunctuality    string     `json:"out_punctuality"`     // "On Time", "Overtime", "Early Leave", "Auto Closed", "On Leave" or "Absent"
+	MaxClockInTime    *time.Time `json:"-"`                   // hanya untuk perhitungan, tidak dikirim ke client
+	MaxClockOutTime   *time.Time `json:"-"`
 }
 
 // Untuk filters di GET logs
 type GetAttendanceLogsRequest struct {
-	Date         string     `query:"date" validate:"omitempty,datetime=2006-01-02"` // YYYY-MM-DD
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02"` // YYYY-MM-DD
+	// Rentang hari kerja (inklusif), e.g. untuk export setahun
+	StartDate    string     `query:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate      string     `query:"end_date" validate:"omitempty,datetime=2006-01-02"`
 	DepartmentID *uuid.UUID `query:"department_id" validate:"omitempty,uuid"`
-	Page         int        `query:"page" validate:"omitempty,min=1"`          // Default 1
-	Limit        int        `query:"limit" validate:"omitempty,min=1,max=100"` // Default 10
+	Status       string     `query:"status" validate:"omitempty,oneof=present on_leave absent"`
+	Mode         string     `query:"mode" validate:"omitempty,oneof=office remote field_visit business_trip"`
+	// Filter berdasarkan menit, nil berarti tidak difilter
+	MinLateMinutes       *int   `query:"min_late_minutes" validate:"omitempty,min=0"`
+	MinEarlyLeaveMinutes *int   `query:"min_early_leave_minutes" validate:"omitempty,min=0"`
+	MinWorkedMinutes     *int   `query:"min_worked_minutes" validate:"omitempty,min=0"`
+	MaxWorkedMinutes     *int   `query:"max_worked_minutes" validate:"omitempty,min=0"`
+	SortBy               string `query:"sort_by" validate:"omitempty,oneof=work_date late_minutes early_leave_minutes worked_minutes"` // Default work_date
+	SortOrder            string `query:"sort_order" validate:"omitempty,oneof=asc desc"`                                               // Default desc
+	Page                 int    `query:"page" validate:"omitempty,min=1"`                                                              // Default 1
+	Limit                int    `query:"limit" validate:"omitempty,min=1,max=100"`                                                     // Default 10
 }
 type AssignmentDepartementRequest struct {
 	DepartmentID uuid.UUID `json:"department_id" validate:"omitempty,uuid"`
@@ -77,8 +208,46 @@ type RawAttendanceLog struct {
 	EmployeeCode    string     `gorm:"column:employee_code"`
 	FullName        string     `gorm:"column:full_name"`
 	DepartmentName  string     `gorm:"column:department_name"`
+	WorkDate        time.Time  `gorm:"column:work_date"`
 	ClockIn         *time.Time `gorm:"column:clock_in"`
 	ClockOut        *time.Time `gorm:"column:clock_out"`
-	MaxClockInTime  *time.Time `gorm:"column:max_clock_in_time"`  // Menggunakan pointer untuk handle NULL
-	MaxClockOutTime *time.Time `gorm:"column:max_clock_out_time"` // Menggunakan pointer untuk handle NULL
+	Status          string     `gorm:"column:status"`
+	HolidayWork     bool       `gorm:"column:holiday_work"`
+	AutoClosed      bool       `gorm:"column:auto_closed"`
+	Mode            string     `gorm:"column:mode"`
+	BreakSeconds    int        `gorm:"column:break_seconds"`
+	MaxBreakMinutes int        `gorm:"column:max_break_minutes"`
+
+	OvertimeMinMinutes      int         `gorm:"column:overtime_min_minutes"`
+	OvertimeRoundingMinutes int         `gorm:"column:overtime_rounding_minutes"`
+	MaxClockInTime          *time.Time  `gorm:"column:max_clock_in_time"`  // Menggunakan pointer untuk handle NULL
+	MaxClockOutTime         *time.Time  `gorm:"column:max_clock_out_time"` // Menggunakan pointer untuk handle NULL
+	DepartmentGraceMinutes  int         `gorm:"column:department_grace_minutes"`
+	PunctualityRule         string      `gorm:"column:punctuality_rule"`
+	CoreStartTime           *time.Time  `gorm:"column:core_start_time"`
+	CoreEndTime             *time.Time  `gorm:"column:core_end_time"`
+	MinWorkMinutes          int         `gorm:"column:min_work_minutes"`
+	WeekdayGraceMinutes     map[int]int `gorm:"column:weekday_grace_minutes;serializer:json"`
+
+	// Shift yang berlaku pada hari tersebut, NULL jika karyawan tidak punya shift
+	ShiftName         *string    `gorm:"column:shift_name"`
+	ShiftStartTime    *time.Time `gorm:"column:shift_start_time"`
+	ShiftEndTime      *time.Time `gorm:"column:shift_end_time"`
+	ShiftGraceMinutes *int       `gorm:"column:shift_grace_minutes"`
+	ShiftApplies      *bool      `gorm:"column:shift_applies"`
+}
+
+// Untuk Attendance Mode Rule per departemen
+type DepartmentModeRuleRequest struct {
+	Mode     string `json:"mode" validate:"required,oneof=office remote field_visit business_trip"`
+	Weekdays []int  `json:"weekdays" validate:"required,min=1,max=7,dive,min=0,max=6"` // 0 = Minggu ... 6 = Sabtu
+}
+
+type SetDepartmentModeRulesRequest struct {
+	Rules []DepartmentModeRuleRequest `json:"rules" validate:"omitempty,dive"` // Kosong = hanya mode office
+}
+
+type DepartmentModeRuleResponse struct {
+	Mode     string `json:"mode"`
+	Weekdays []int  `json:"weekdays"`
 }
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Untuk Holiday
+type CreateHolidayRequest struct {
+	Date         string     `json:"date" validate:"required,datetime=2006-01-02"`
+	Name         string     `json:"name" validate:"required,min=2,max=255"`
+	Type         string     `json:"type" validate:"omitempty,oneof=national company"` // Default national
+	DepartmentID *uuid.UUID `json:"department_id" validate:"omitempty"`               // Kosong = semua departemen
+}
+
+type UpdateHolidayRequest struct {
+	Date         string     `json:"date" validate:"omitempty,datetime=2006-01-02"`
+	Name         string     `json:"name" validate:"omitempty,min=2,max=255"`
+	Type         string     `json:"type" validate:"omitempty,oneof=national company"`
+	DepartmentID *uuid.UUID `json:"department_id" validate:"omitempty"`
+}
+
+type ListHolidaysRequest struct {
+	Year         int        `query:"year" validate:"omitempty,min=2000,max=2100"`
+	DepartmentID *uuid.UUID `query:"department_id" validate:"omitempty"`
+	Page         int        `query:"page" validate:"omitempty,min=1"`          // Default 1
+	Limit        int        `query:"limit" validate:"omitempty,min=1,max=100"` // Default 10
+}
+
+type ImportHolidaysRequest struct {
+	Type         string     `query:"type" validate:"omitempty,oneof=national company"`
+	DepartmentID *uuid.UUID `query:"department_id" validate:"omitempty"`
+}
+
+type HolidayResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Date         string     `json:"date"`
+	Name         string     `json:"name"`
+	Type         string     `json:"type"`
+	DepartmentID *uuid.UUID `json:"department_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type ImportHolidaysResponse struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
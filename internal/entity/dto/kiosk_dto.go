@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateKioskRequest struct {
+	Name             string     `json:"name" validate:"required,min=2,max=255"`
+	DepartmentID     *uuid.UUID `json:"department_id" validate:"omitempty"`      // Kosong = bisa dipakai semua departemen
+	OfficeLocationID *uuid.UUID `json:"office_location_id" validate:"omitempty"` // Lokasi fisik kiosk
+}
+
+type UpdateKioskRequest struct {
+	Name             string     `json:"name" validate:"omitempty,min=2,max=255"`
+	DepartmentID     *uuid.UUID `json:"department_id" validate:"omitempty"`
+	OfficeLocationID *uuid.UUID `json:"office_location_id" validate:"omitempty"`
+	IsActive         *bool      `json:"is_active"`
+}
+
+type KioskResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	DepartmentID     *uuid.UUID `json:"department_id"`
+	OfficeLocationID *uuid.UUID `json:"office_location_id"`
+	APIKey           string     `json:"api_key,omitempty"` // Hanya dikembalikan saat dibuat atau di-rotate
+	IsActive         bool       `json:"is_active"`
+	LastSeenAt       *time.Time `json:"last_seen_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// KioskQRResponse adalah QR yang ditampilkan kiosk; kiosk harus meminta QR baru sebelum expires_at
+type KioskQRResponse struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// KioskScanRequest dikirim karyawan setelah scan QR kiosk dari HP
+type KioskScanRequest struct {
+	Code   string `json:"code" validate:"required,max=255"`
+	Action string `json:"action" validate:"required,oneof=clock_in clock_out"`
+}
+
+// KioskBadgeScanRequest dikirim kiosk setelah scan QR badge karyawan
+type KioskBadgeScanRequest struct {
+	BadgeCode string `json:"badge_code" validate:"required,max=255"`
+	Action    string `json:"action" validate:"required,oneof=clock_in clock_out"`
+}
+
+type KioskBadgeResponse struct {
+	BadgeID      uuid.UUID `json:"badge_id"`
+	EmployeeCode string    `json:"employee_code"`
+	BadgeCode    string    `json:"badge_code"`
+}
+
+type KioskScanResponse struct {
+	EmployeeCode string              `json:"employee_code"`
+	FullName     string              `json:"full_name"`
+	Action       string              `json:"action"`
+	Attendance   *AttendanceResponse `json:"attendance"`
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Untuk Leave Type
+type CreateLeaveTypeRequest struct {
+	Code        string `json:"code" validate:"required,min=2,max=30"`
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	IsPaid      *bool  `json:"is_paid" validate:"omitempty"`                    // Default true
+	AnnualQuota int    `json:"annual_quota" validate:"omitempty,min=0,max=366"` // 0 = tanpa batas kuota
+}
+
+type UpdateLeaveTypeRequest struct {
+	Name        string `json:"name" validate:"omitempty,min=2,max=100"`
+	IsPaid      *bool  `json:"is_paid" validate:"omitempty"`
+	AnnualQuota *int   `json:"annual_quota" validate:"omitempty,min=0,max=366"`
+}
+
+type LeaveTypeResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	IsPaid      bool      `json:"is_paid"`
+	AnnualQuota int       `json:"annual_quota"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Untuk Leave Balance
+type GetLeaveBalancesRequest struct {
+	UserID uuid.UUID `query:"user_id" validate:"required"`
+	Year   int       `query:"year" validate:"required,min=2000,max=2100"`
+}
+
+type SetLeaveBalanceRequest struct {
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	LeaveTypeID uuid.UUID `json:"leave_type_id" validate:"required"`
+	Year        int       `json:"year" validate:"required,min=2000,max=2100"`
+	Entitled    int       `json:"entitled" validate:"min=0,max=366"`
+}
+
+type LeaveBalanceResponse struct {
+	LeaveTypeID   uuid.UUID `json:"leave_type_id"`
+	LeaveTypeCode string    `json:"leave_type_code"`
+	LeaveTypeName string    `json:"leave_type_name"`
+	Year          int       `json:"year"`
+	Entitled      int       `json:"entitled"`
+	Used          int       `json:"used"`
+	Pending       int       `json:"pending"`
+	Remaining     *int      `json:"remaining"` // nil jika jenis cuti tanpa batas kuota
+}
+
+// Untuk Leave Request
+type CreateLeaveRequest struct {
+	LeaveTypeID uuid.UUID `json:"leave_type_id" validate:"required"`
+	StartDate   string    `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate     string    `json:"end_date" validate:"required,datetime=2006-01-02"`
+	Reason      string    `json:"reason" validate:"omitempty,max=1000"`
+}
+
+type ReviewLeaveRequest struct {
+	Note string `json:"note" validate:"omitempty,max=1000"`
+}
+
+type ListLeaveRequestsRequest struct {
+	UserID *uuid.UUID `query:"user_id" validate:"omitempty"`
+	Status string     `query:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+	Page   int        `query:"page" validate:"omitempty,min=1"`          // Default 1
+	Limit  int        `query:"limit" validate:"omitempty,min=1,max=100"` // Default 10
+}
+
+type LeaveRequestResponse struct {
+	ID           uuid.UUID          `json:"id"`
+	EmployeeCode string             `json:"employee_code"`
+	LeaveType    *LeaveTypeResponse `json:"leave_type,omitempty"`
+	StartDate    string             `json:"start_date"`
+	EndDate      string             `json:"end_date"`
+	Days         int                `json:"days"`
+	Reason       string             `json:"reason"`
+	Status       string             `json:"status"`
+	ReviewedBy   *uuid.UUID         `json:"reviewed_by"`
+	ReviewedAt   *time.Time         `json:"reviewed_at"`
+	ReviewNote   string             `json:"review_note"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Untuk Office Location
+type CreateOfficeLocationRequest struct {
+	Name          string      `json:"name" validate:"required,min=2,max=255"`
+	Address       string      `json:"address" validate:"omitempty,max=1000"`
+	Latitude      *float64    `json:"latitude" validate:"required,latitude"`
+	Longitude     *float64    `json:"longitude" validate:"required,longitude"`
+	RadiusMeters  int         `json:"radius_meters" validate:"required,min=10,max=10000"`
+	DepartmentIDs []uuid.UUID `json:"department_ids" validate:"omitempty,dive,required"`
+}
+
+type UpdateOfficeLocationRequest struct {
+	Name          string       `json:"name" validate:"omitempty,min=2,max=255"`
+	Address       *string      `json:"address" validate:"omitempty,max=1000"`
+	Latitude      *float64     `json:"latitude" validate:"omitempty,latitude"`
+	Longitude     *float64     `json:"longitude" validate:"omitempty,longitude"`
+	RadiusMeters  int          `json:"radius_meters" validate:"omitempty,min=10,max=10000"`
+	DepartmentIDs *[]uuid.UUID `json:"department_ids" validate:"omitempty"` // Jika diisi, menggantikan daftar departemen
+}
+
+type OfficeLocationResponse struct {
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	Address       string      `json:"address"`
+	Latitude      float64     `json:"latitude"`
+	Longitude     float64     `json:"longitude"`
+	RadiusMeters  int         `json:"radius_meters"`
+	DepartmentIDs []uuid.UUID `json:"department_ids"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OfflineClockEventRequest adalah satu event clock in/out yang direkam aplikasi saat offline
+type OfflineClockEventRequest struct {
+	EventID    uuid.UUID `json:"event_id" validate:"required"` // Dibuat client, dipakai untuk deduplikasi
+	Action     string    `json:"action" validate:"required,oneof=clock_in clock_out"`
+	ClientTime time.Time `json:"client_time" validate:"required"` // RFC3339 lengkap dengan offset zona waktu
+	Mode       string    `json:"mode" validate:"omitempty,oneof=office remote field_visit business_trip"`
+	Latitude   *float64  `json:"latitude" validate:"omitempty,latitude,required_with=Longitude"`
+	Longitude  *float64  `json:"longitude" validate:"omitempty,longitude,required_with=Latitude"`
+	Accuracy   *float64  `json:"accuracy" validate:"omitempty,min=0"`
+}
+
+type OfflineSyncRequest struct {
+	Events []OfflineClockEventRequest `json:"events" validate:"required,min=1,max=100,dive"`
+}
+
+// OfflineClockEventResult adalah hasil per event. Status applied / rejected sudah final;
+// pending berarti event sedang diproses request lain dan boleh dikirim ulang nanti.
+type OfflineClockEventResult struct {
+	EventID      uuid.UUID           `json:"event_id"`
+	Action       string              `json:"action"`
+	ClientTime   time.Time           `json:"client_time"`
+	Status       string              `json:"status"`
+	Duplicate    bool                `json:"duplicate"` // Event sudah pernah diterima, hasil lama dikembalikan
+	Error        string              `json:"error,omitempty"`
+	Conflict     string              `json:"conflict,omitempty"` // auto_clock_out: attendance sudah ditutup sistem sebelum waktu event
+	AttendanceID string              `json:"attendance_id,omitempty"`
+	Attendance   *AttendanceResponse `json:"attendance,omitempty"` // Hanya untuk event yang baru diterapkan
+}
+
+// OfflineSyncResponse berisi hasil tiap event, diurutkan sesuai client_time
+type OfflineSyncResponse struct {
+	Results    []OfflineClockEventResult `json:"results"`
+	Applied    int                       `json:"applied"`
+	Rejected   int                       `json:"rejected"`
+	Duplicates int                       `json:"duplicates"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Untuk Overtime
+type CreateOvertimeRequest struct {
+	WorkDate string `json:"work_date" validate:"required,datetime=2006-01-02"`
+	Kind     string `json:"kind" validate:"required,oneof=pre_approval post_approval"`
+	Minutes  int    `json:"minutes" validate:"omitempty,min=1,max=720"` // Wajib untuk pre_approval, default lembur aktual untuk post_approval
+	Reason   string `json:"reason" validate:"required,min=5,max=1000"`
+}
+
+type ReviewOvertimeRequest struct {
+	ApprovedMinutes int    `json:"approved_minutes" validate:"omitempty,min=1,max=720"` // Default sama dengan menit yang diajukan
+	Note            string `json:"note" validate:"omitempty,max=1000"`
+}
+
+type ListOvertimeRequest struct {
+	UserID *uuid.UUID `query:"user_id" validate:"omitempty"`
+	Status string     `query:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+	Page   int        `query:"page" validate:"omitempty,min=1"`          // Default 1
+	Limit  int        `query:"limit" validate:"omitempty,min=1,max=100"` // Default 10
+}
+
+type OvertimeResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	EmployeeCode    string     `json:"employee_code"`
+	WorkDate        string     `json:"work_date"`
+	Kind            string     `json:"kind"`
+	Minutes         int        `json:"minutes"`
+	ApprovedMinutes int        `json:"approved_minutes"`
+	Reason          string     `json:"reason"`
+	Status          string     `json:"status"`
+	ReviewedBy      *uuid.UUID `json:"reviewed_by"`
+	ReviewedAt      *time.Time `json:"reviewed_at"`
+	ReviewNote      string     `json:"review_note"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Total lembur per karyawan untuk satu periode (payroll)
+type OvertimeSummaryRequest struct {
+	StartDate    string     `query:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string     `query:"end_date" validate:"required,datetime=2006-01-02"`
+	UserID       *uuid.UUID `query:"user_id" validate:"omitempty"`
+	DepartmentID *uuid.UUID `query:"department_id" validate:"omitempty"`
+}
+
+type OvertimeSummaryResponse struct {
+	EmployeeCode    string `json:"employee_code"`
+	FullName        string `json:"full_name"`
+	DepartmentName  string `json:"department_name"`
+	OvertimeDays    int    `json:"overtime_days"`    // Hari dengan lembur aktual
+	ActualMinutes   int    `json:"actual_minutes"`   // Lembur dari attendance setelah threshold dan pembulatan
+	ApprovedMinutes int    `json:"approved_minutes"` // Disetujui, dibatasi lembur aktual per hari (untuk payroll)
+	PendingMinutes  int    `json:"pending_minutes"`
+}
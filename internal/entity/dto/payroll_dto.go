@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PayrollExportRequest struct {
+	StartDate    string     `query:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string     `query:"end_date" validate:"required,datetime=2006-01-02"`
+	DepartmentID *uuid.UUID `query:"department_id" validate:"omitempty,uuid"`
+	Format       string     `query:"format" validate:"omitempty,oneof=csv json"` // Default csv
+}
+
+// LockPayrollExportRequest mengunci pay period lalu mengirim rekapnya. Lock berlaku untuk semua
+// departemen, jadi department_id hanya diterima untuk ditolak dengan pesan yang jelas.
+type LockPayrollExportRequest struct {
+	StartDate    string     `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string     `json:"end_date" validate:"required,datetime=2006-01-02"`
+	DepartmentID *uuid.UUID `json:"department_id"`
+	Format       string     `json:"format" validate:"omitempty,oneof=csv json"` // Default csv
+	Note         string     `json:"note" validate:"omitempty,max=500"`
+}
+
+// Rekap satu karyawan untuk satu pay period
+type PayrollEmployeeSummary struct {
+	EmployeeCode          string `json:"employee_code"`
+	FullName              string `json:"full_name"`
+	DepartmentName        string `json:"department_name"`
+	WorkedDays            int    `json:"worked_days"`
+	WorkedMinutes         int    `json:"worked_minutes"`
+	LateCount             int    `json:"late_count"`
+	LateMinutes           int    `json:"late_minutes"`
+	AbsentDays            int    `json:"absent_days"`
+	PaidLeaveDays         int    `json:"paid_leave_days"`
+	UnpaidLeaveDays       int    `json:"unpaid_leave_days"`
+	OvertimeMinutes       int    `json:"overtime_minutes"`        // Lembur yang disetujui, dibatasi lembur aktual
+	ActualOvertimeMinutes int    `json:"actual_overtime_minutes"` // Lembur aktual dari attendance
+}
+
+type PayrollSummary struct {
+	StartDate   string                   `json:"start_date"`
+	EndDate     string                   `json:"end_date"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Employees   []PayrollEmployeeSummary `json:"employees"`
+}
+
+// File hasil export yang dikirim ke client
+type PayrollExportFile struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Jumlah hari cuti per karyawan dari attendance on_leave
+type PayrollLeaveCount struct {
+	EmployeeCode    string `gorm:"column:employee_code"`
+	PaidLeaveDays   int    `gorm:"column:paid_leave_days"`
+	UnpaidLeaveDays int    `gorm:"column:unpaid_leave_days"`
+}
+
+type LockPayPeriodRequest struct {
+	StartDate string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	Note      string `json:"note" validate:"omitempty,max=500"`
+}
+
+type ListPayPeriodsRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=open locked"`
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type PayPeriodResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	StartDate      string     `json:"start_date"`
+	EndDate        string     `json:"end_date"`
+	Status         string     `json:"status"`
+	Note           string     `json:"note"`
+	LockedBy       *uuid.UUID `json:"locked_by"`
+	LockedAt       *time.Time `json:"locked_at"`
+	UnlockedBy     *uuid.UUID `json:"unlocked_by"`
+	UnlockedAt     *time.Time `json:"unlocked_at"`
+	LastExportedAt *time.Time `json:"last_exported_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Untuk Shift
+type CreateShiftRequest struct {
+	Name         string `json:"name" validate:"required,min=2,max=100"`
+	StartTime    string `json:"start_time" validate:"required"` // e.g., "22:00:00"
+	EndTime      string `json:"end_time" validate:"required"`   // e.g., "06:00:00"
+	GraceMinutes int    `json:"grace_minutes" validate:"omitempty,min=0,max=240"`
+	Weekdays     []int  `json:"weekdays" validate:"required,min=1,max=7,dive,min=0,max=6"` // 0 = Minggu ... 6 = Sabtu
+}
+
+type UpdateShiftRequest struct {
+	Name         string `json:"name" validate:"omitempty,min=2,max=100"`
+	StartTime    string `json:"start_time" validate:"omitempty"`
+	EndTime      string `json:"end_time" validate:"omitempty"`
+	GraceMinutes *int   `json:"grace_minutes" validate:"omitempty,min=0,max=240"`
+	Weekdays     []int  `json:"weekdays" validate:"omitempty,min=1,max=7,dive,min=0,max=6"`
+}
+
+type ShiftResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	StartTime    string    `json:"start_time"`
+	EndTime      string    `json:"end_time"`
+	GraceMinutes int       `json:"grace_minutes"`
+	Weekdays     []int     `json:"weekdays"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Untuk Shift Assignment
+type AssignShiftRequest struct {
+	UserID        uuid.UUID `json:"user_id" validate:"required"`
+	ShiftID       uuid.UUID `json:"shift_id" validate:"required"`
+	EffectiveFrom string    `json:"effective_from" validate:"required,datetime=2006-01-02"`
+	EffectiveTo   string    `json:"effective_to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type GetShiftAssignmentsRequest struct {
+	UserID uuid.UUID `query:"user_id" validate:"required"`
+	Page   int       `query:"page" validate:"omitempty,min=1"`          // Default 1
+	Limit  int       `query:"limit" validate:"omitempty,min=1,max=100"` // Default 10
+}
+
+type ShiftAssignmentResponse struct {
+	ID            uuid.UUID      `json:"id"`
+	EmployeeCode  string         `json:"employee_code"`
+	ShiftID       uuid.UUID      `json:"shift_id"`
+	EffectiveFrom string         `json:"effective_from"`
+	EffectiveTo   *string        `json:"effective_to"`
+	Shift         *ShiftResponse `json:"shift,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
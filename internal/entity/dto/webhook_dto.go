@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateWebhookRequest struct {
+	Name       string   `json:"name" validate:"required,min=2,max=255"`
+	URL        string   `json:"url" validate:"required,http_url,max=2000"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255"` // Kosong = dibuatkan otomatis
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=attendance.clock_in attendance.clock_out attendance.correction_approved user.signup department.assigned"`
+}
+
+type UpdateWebhookRequest struct {
+	Name       string    `json:"name" validate:"omitempty,min=2,max=255"`
+	URL        string    `json:"url" validate:"omitempty,http_url,max=2000"`
+	Secret     string    `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes *[]string `json:"event_types" validate:"omitempty,min=1,dive,oneof=attendance.clock_in attendance.clock_out attendance.correction_approved user.signup department.assigned"`
+	IsActive   *bool     `json:"is_active"`
+}
+
+type WebhookResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"` // Hanya dikembalikan saat dibuat atau diganti
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	EventID        uuid.UUID  `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	ResponseBody   string     `json:"response_body"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	ReplayOf       *uuid.UUID `json:"replay_of"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ReplayWebhookDeliveriesResponse struct {
+	Replayed int `json:"replayed"`
+}
+
+// WebhookPayload adalah body yang dikirim ke endpoint subscriber
+type WebhookPayload struct {
+	ID        uuid.UUID `json:"id"` // Sama untuk semua subscription, dipakai subscriber untuk deduplikasi
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+type UserSignupEvent struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Email        string    `json:"email"`
+	FullName     string    `json:"full_name"`
+	EmployeeCode string    `json:"employee_code"`
+}
+
+type DepartmentAssignedEvent struct {
+	UserID               uuid.UUID  `json:"user_id"`
+	EmployeeCode         string     `json:"employee_code"`
+	FullName             string     `json:"full_name"`
+	DepartmentID         uuid.UUID  `json:"department_id"`
+	DepartmentName       string     `json:"department_name"`
+	PreviousDepartmentID *uuid.UUID `json:"previous_department_id"`
+}
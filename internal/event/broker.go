@@ -0,0 +1,69 @@
+// broker.go
+package event
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// subscriptionBuffer adalah jumlah event yang boleh tertahan per subscriber sebelum event dibuang
+const subscriptionBuffer = 64
+
+// Subscription menerima event attendance dari Broker sampai Close dipanggil
+type Subscription struct {
+	Events       <-chan dto.AttendanceEvent
+	departmentID *uuid.UUID // nil = semua departemen
+	events       chan dto.AttendanceEvent
+	broker       *Broker
+	once         sync.Once
+}
+
+// Close berhenti berlangganan dan menutup channel Events
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.broker.mu.Lock()
+		delete(s.broker.subscribers, s)
+		s.broker.mu.Unlock()
+		close(s.events)
+	})
+}
+
+// Broker membagikan event attendance ke semua subscriber SSE feed di proses ini.
+// Publish tidak pernah blocking: subscriber yang lambat kehilangan event, bukan menahan clock in.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	log         *logrus.Logger
+}
+
+func NewBroker(log *logrus.Logger) *Broker {
+	return &Broker{subscribers: make(map[*Subscription]struct{}), log: log}
+}
+
+// Subscribe mendaftarkan subscriber untuk satu departemen, atau semua departemen jika departmentID nil
+func (b *Broker) Subscribe(departmentID *uuid.UUID) *Subscription {
+	events := make(chan dto.AttendanceEvent, subscriptionBuffer)
+	s := &Subscription{Events: events, departmentID: departmentID, events: events, broker: b}
+	b.mu.Lock()
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+func (b *Broker) Publish(e dto.AttendanceEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subscribers {
+		if s.departmentID != nil && (e.DepartmentID == nil || *s.departmentID != *e.DepartmentID) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			b.log.WithFields(logrus.Fields{"event_id": e.ID, "type": e.Type}).Warn("Dropping attendance event for slow subscriber")
+		}
+	}
+}
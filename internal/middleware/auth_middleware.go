@@ -4,6 +4,7 @@ import (
 	"employee-attendance-system/internal/usecase"
 	utils "employee-attendance-system/internal/util"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -18,11 +19,12 @@ type AuthMiddleware struct {
 	log      *logrus.Logger
 	config   *viper.Viper
 	jwtUtils *utils.JWTConfig
+	tickets  *streamTicketStore
 }
 
 func NewAuth(usecase usecase.AuthUseCase, log *logrus.Logger, config *viper.Viper, jwtUtils *utils.JWTConfig) *AuthMiddleware {
 	return &AuthMiddleware{usecase: usecase, log: log, config: config,
-		jwtUtils: jwtUtils}
+		jwtUtils: jwtUtils, tickets: newStreamTicketStore()}
 }
 
 func (m *AuthMiddleware) Authenticate(c *fiber.Ctx) error {
@@ -36,7 +38,49 @@ func (m *AuthMiddleware) Authenticate(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnauthorized, "Invalid authorization header format")
 	}
 
-	m.log.Printf("token: %v", tokenString)
+	return m.authenticateToken(c, tokenString)
+}
+
+// AuthenticateStream sama seperti Authenticate, tetapi juga menerima query `ticket` dari
+// IssueStreamTicket karena EventSource di browser tidak bisa mengirim header Authorization.
+// JWT sengaja tidak diterima lewat query supaya tidak tercatat di log URL.
+func (m *AuthMiddleware) AuthenticateStream(c *fiber.Ctx) error {
+	if c.Get("Authorization") != "" || c.Query("ticket") == "" {
+		return m.Authenticate(c)
+	}
+	ticket, ok := m.tickets.redeem(c.Query("ticket"), time.Now())
+	if !ok {
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired stream ticket")
+	}
+	c.Locals("userID", ticket.userID)
+	c.Locals("email", ticket.email)
+	c.Locals("role", ticket.role)
+	c.Locals("tokenExpiresAt", ticket.tokenExpiresAt)
+	return c.Next()
+}
+
+// IssueStreamTicket menukar JWT (dari Authenticate) dengan ticket sekali pakai untuk
+// membuka stream SSE
+func (m *AuthMiddleware) IssueStreamTicket(c *fiber.Ctx) error {
+	now := time.Now()
+	localKeys := GetLocalKeys(c)
+	expiresAt := now.Add(streamTicketTTL)
+	id, err := m.tickets.issue(streamTicket{
+		userID:         localKeys.UserID,
+		email:          localKeys.Email,
+		role:           localKeys.Role,
+		tokenExpiresAt: GetTokenExpiresAt(c),
+		expiresAt:      expiresAt,
+	}, now)
+	if err != nil {
+		m.log.WithError(err).Error("Failed to issue stream ticket")
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, "Failed to issue stream ticket", nil))
+	}
+	return c.Status(fiber.StatusCreated).JSON(utils.SuccessResponse(fiber.StatusCreated, "Stream ticket issued",
+		fiber.Map{"ticket": id, "expires_at": expiresAt}, struct{}{}))
+}
+
+func (m *AuthMiddleware) authenticateToken(c *fiber.Ctx, tokenString string) error {
 	token, err := m.jwtUtils.ValidateToken(c.Context(), tokenString, utils.AccessToken)
 	if err != nil || !token.Valid {
 		m.log.Printf("error: %v", err)
@@ -56,6 +100,9 @@ func (m *AuthMiddleware) Authenticate(c *fiber.Ctx) error {
 	c.Locals("userID", userID)
 	c.Locals("email", claims["email"].(string))
 	c.Locals("role", claims["role"].(string))
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		c.Locals("tokenExpiresAt", exp.Time)
+	}
 
 	return c.Next()
 }
@@ -76,3 +123,9 @@ func GetLocalKeys(c *fiber.Ctx) *LocalKeys {
 		Role:   role,
 	}
 }
+
+// GetTokenExpiresAt mengembalikan waktu kedaluwarsa JWT request ini, atau zero time jika tidak ada
+func GetTokenExpiresAt(c *fiber.Ctx) time.Time {
+	expiresAt, _ := c.Locals("tokenExpiresAt").(time.Time)
+	return expiresAt
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"employee-attendance-system/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// BadgeReaderMiddleware mengautentikasi reader RFID lewat header X-Reader-Key (bukan JWT karyawan)
+type BadgeReaderMiddleware struct {
+	usecase usecase.BadgeUseCase
+	auth    deviceKeyAuth
+}
+
+func NewBadgeReaderAuth(usecase usecase.BadgeUseCase, log *logrus.Logger) *BadgeReaderMiddleware {
+	return &BadgeReaderMiddleware{
+		usecase: usecase,
+		auth:    deviceKeyAuth{header: "X-Reader-Key", keyName: "reader", name: "badge reader", localKey: "readerID", log: log},
+	}
+}
+
+func (m *BadgeReaderMiddleware) Authenticate(c *fiber.Ctx) error {
+	return m.auth.authenticate(c, func(ctx context.Context, apiKey string) (uuid.UUID, error) {
+		reader, err := m.usecase.AuthenticateReader(ctx, apiKey)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return reader.ID, nil
+	})
+}
+
+func GetReaderID(c *fiber.Ctx) uuid.UUID {
+	return deviceID(c, "readerID")
+}
@@ -8,8 +8,9 @@ import (
 // SetupCORS mengembalikan instance middleware CORS yang siap digunakan
 func SetupCORS() fiber.Handler {
 	return cors.New(cors.Config{
-		AllowOrigins: "http://localhost:6969, http://localhost:1456",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		AllowOrigins:  "http://localhost:6969, http://localhost:1456",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, Idempotency-Key",
+		AllowMethods:  "GET, POST, PUT, DELETE, OPTIONS",
+		ExposeHeaders: "Idempotent-Replayed",
 	})
 }
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// deviceKeyAuth mengautentikasi perangkat (kiosk, reader RFID) lewat API key di header,
+// bukan JWT karyawan. ID perangkat disimpan di c.Locals dengan localKey.
+type deviceKeyAuth struct {
+	header   string // mis. "X-Kiosk-Key"
+	keyName  string // nama key di pesan error, mis. "kiosk"
+	name     string // nama perangkat di pesan error, mis. "kiosk"
+	localKey string
+	log      *logrus.Logger
+}
+
+func (a deviceKeyAuth) authenticate(c *fiber.Ctx, verify func(ctx context.Context, apiKey string) (uuid.UUID, error)) error {
+	apiKey := c.Get(a.header)
+	if apiKey == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "Missing "+a.keyName+" key")
+	}
+
+	id, err := verify(c.Context(), apiKey)
+	if err != nil {
+		a.log.WithError(err).WithField("device", a.name).Warn("Device authentication failed")
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid or inactive "+a.name)
+	}
+
+	c.Locals(a.localKey, id)
+	return c.Next()
+}
+
+func deviceID(c *fiber.Ctx, localKey string) uuid.UUID {
+	id, _ := c.Locals(localKey).(uuid.UUID)
+	return id
+}
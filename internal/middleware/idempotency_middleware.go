@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/usecase"
+	utils "employee-attendance-system/internal/util"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+)
+
+// IdempotencyMiddleware memutar ulang response pertama untuk request dengan header
+// Idempotency-Key yang sama. Request tanpa header diproses seperti biasa. Harus dipasang
+// setelah AuthMiddleware supaya key dibatasi per user; tanpa JWT key dibatasi per IP.
+type IdempotencyMiddleware struct {
+	usecase usecase.IdempotencyUseCase
+	log     *logrus.Logger
+}
+
+func NewIdempotency(usecase usecase.IdempotencyUseCase, log *logrus.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{usecase: usecase, log: log}
+}
+
+func (m *IdempotencyMiddleware) Handle(c *fiber.Ctx) error {
+	key := strings.TrimSpace(c.Get(IdempotencyKeyHeader))
+	if key == "" {
+		return c.Next()
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorResponse(fiber.StatusBadRequest, "Idempotency-Key is too long", nil))
+	}
+
+	scope := idempotencyScope(c)
+	record, err := m.usecase.Begin(c.Context(), scope, key, m.usecase.HashRequest(requestFingerprint(c)))
+	if err != nil {
+		switch err.Error() {
+		case "idempotency key was already used for a different request":
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(utils.ErrorResponse(fiber.StatusUnprocessableEntity, err.Error(), nil))
+		case "request with this idempotency key is still being processed":
+			return c.Status(fiber.StatusConflict).JSON(utils.ErrorResponse(fiber.StatusConflict, err.Error(), nil))
+		}
+		m.log.WithError(err).Error("Failed to check idempotency key")
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorResponse(fiber.StatusInternalServerError, "Failed to check idempotency key", nil))
+	}
+	if record.StatusCode != 0 {
+		c.Set(IdempotencyReplayedHeader, "true")
+		c.Set(fiber.HeaderContentType, record.ContentType)
+		return c.Status(record.StatusCode).Send(record.Body)
+	}
+
+	if err := c.Next(); err != nil {
+		m.release(c, record)
+		return err
+	}
+
+	// Error server tidak disimpan supaya retry bisa berhasil
+	status := c.Response().StatusCode()
+	if status >= fiber.StatusInternalServerError {
+		m.release(c, record)
+		return nil
+	}
+	body := append([]byte(nil), c.Response().Body()...)
+	if err := m.usecase.Complete(c.Context(), record, status, string(c.Response().Header.ContentType()), body); err != nil {
+		m.log.WithError(err).WithField("scope", scope).Error("Failed to store idempotent response")
+	}
+	return nil
+}
+
+func (m *IdempotencyMiddleware) release(c *fiber.Ctx, record *domain.IdempotencyRecord) {
+	if err := m.usecase.Release(c.Context(), record); err != nil {
+		m.log.WithError(err).WithField("scope", record.Scope).Warn("Failed to release idempotency key")
+	}
+}
+
+func idempotencyScope(c *fiber.Ctx) string {
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		return "user:" + userID.String()
+	}
+	return "ip:" + c.IP()
+}
+
+// requestFingerprint membedakan request lain yang memakai key yang sama; hasilnya di-HMAC
+// oleh usecase. Body multipart tidak ikut karena boundary-nya berubah setiap kali form dikirim ulang.
+func requestFingerprint(c *fiber.Ctx) []byte {
+	fingerprint := []byte(c.Method() + " " + c.Path() + "\n")
+	if !strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEMultipartForm) {
+		fingerprint = append(fingerprint, c.Body()...)
+	}
+	return fingerprint
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"employee-attendance-system/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// KioskMiddleware mengautentikasi perangkat kiosk lewat header X-Kiosk-Key (bukan JWT karyawan)
+type KioskMiddleware struct {
+	usecase usecase.KioskUseCase
+	auth    deviceKeyAuth
+}
+
+func NewKioskAuth(usecase usecase.KioskUseCase, log *logrus.Logger) *KioskMiddleware {
+	return &KioskMiddleware{
+		usecase: usecase,
+		auth:    deviceKeyAuth{header: "X-Kiosk-Key", keyName: "kiosk", name: "kiosk", localKey: "kioskID", log: log},
+	}
+}
+
+func (m *KioskMiddleware) Authenticate(c *fiber.Ctx) error {
+	return m.auth.authenticate(c, func(ctx context.Context, apiKey string) (uuid.UUID, error) {
+		kiosk, err := m.usecase.AuthenticateKiosk(ctx, apiKey)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return kiosk.ID, nil
+	})
+}
+
+func GetKioskID(c *fiber.Ctx) uuid.UUID {
+	return deviceID(c, "kioskID")
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// streamTicketTTL cukup untuk membuka EventSource setelah ticket diminta
+const streamTicketTTL = 30 * time.Second
+
+// streamTicket menggantikan JWT di query string SSE. Ticket hanya berlaku sekali dan sebentar,
+// jadi URL yang tercatat di log proxy atau riwayat browser tidak bisa dipakai ulang.
+type streamTicket struct {
+	userID         uuid.UUID
+	email          string
+	role           string
+	tokenExpiresAt time.Time // Stream ditutup saat JWT asal ticket kedaluwarsa
+	expiresAt      time.Time
+}
+
+// streamTicketStore menyimpan ticket di memory proses, sama seperti broker SSE-nya
+type streamTicketStore struct {
+	mu      sync.Mutex
+	tickets map[string]streamTicket
+}
+
+func newStreamTicketStore() *streamTicketStore {
+	return &streamTicketStore{tickets: make(map[string]streamTicket)}
+}
+
+func (s *streamTicketStore) issue(ticket streamTicket, now time.Time) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Ticket yang tidak pernah dipakai dibersihkan di sini, tanpa goroutine terpisah
+	for key, t := range s.tickets {
+		if !now.Before(t.expiresAt) {
+			delete(s.tickets, key)
+		}
+	}
+	s.tickets[id] = ticket
+	return id, nil
+}
+
+// redeem mengambil ticket sekaligus menghapusnya, sehingga ticket yang sama tidak bisa dipakai dua kali
+func (s *streamTicketStore) redeem(id string, now time.Time) (streamTicket, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ticket, ok := s.tickets[id]
+	if !ok {
+		return streamTicket{}, false
+	}
+	delete(s.tickets, id)
+	if !now.Before(ticket.expiresAt) {
+		return streamTicket{}, false
+	}
+	return ticket, true
+}
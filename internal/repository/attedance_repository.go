@@ -3,10 +3,13 @@ package repository
 
 import (
 	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AttendanceRepository interface {
@@ -15,8 +18,27 @@ type AttendanceRepository interface {
 	UpdateAttendanceWithHistory(attendance *domain.Attendance, history *domain.AttendanceHistory) error
 	GetAttendanceQuery() *gorm.DB
 	FindAttendanceHistoryByEmployeeCode(employeeCode string, page, limit int) ([]*domain.AttendanceHistory, int64, error)
+	FindAttendanceHistoryByID(id uuid.UUID) (*domain.AttendanceHistory, error)
 
 	FindCurrentAttendance(employeeCode string) (*domain.Attendance, error)
+	FindOpenAttendance(employeeCode string, since time.Time) (*domain.Attendance, error)
+	FindLatestAttendanceBefore(employeeCode string, since, before time.Time) (*domain.Attendance, error)
+	CreateMissingAttendances(attendances []*domain.Attendance) (int64, error)
+	FindOpenAttendancesWithAutoClockOut() ([]*domain.Attendance, error)
+	AutoCloseAttendance(attendance *domain.Attendance, history *domain.AttendanceHistory) (bool, error)
+
+	FindOpenBreak(attendanceID string) (*domain.AttendanceBreak, error)
+	CreateBreakWithHistory(brk *domain.AttendanceBreak, history *domain.AttendanceHistory) (bool, error)
+	UpdateBreakWithHistory(brk *domain.AttendanceBreak, history *domain.AttendanceHistory) error
+	SumBreakSeconds(attendanceID string) (int, error)
+
+	SavePunctuality(attendanceID string, lateMinutes, earlyLeaveMinutes int, workedMinutes *int, evaluatedAt time.Time) (bool, error)
+
+	GetAttendanceStats(start, end time.Time) (*dto.DashboardAttendanceStats, error)
+	GetDepartmentAttendanceStats(start, end time.Time) ([]dto.DashboardAttendanceStats, error)
+	GetDailyAttendanceTrend(start, end time.Time) ([]dto.DashboardTrendPoint, error)
+	GetTopLateEmployees(start, end time.Time, limit int) ([]dto.DashboardLateEmployee, error)
+	CountClockedIn(since time.Time) (int, error)
 }
 
 type attendanceRepository struct {
@@ -33,6 +55,7 @@ func (r *attendanceRepository) FindAttendanceByID(attendanceID string, attendanc
 }
 
 func (r *attendanceRepository) CreateAttendanceWithHistory(attendance *domain.Attendance, history *domain.AttendanceHistory) error {
+	attendance.PunctualityEvaluatedAt = nil
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(attendance).Error; err != nil {
 			return err
@@ -41,14 +64,60 @@ func (r *attendanceRepository) CreateAttendanceWithHistory(attendance *domain.At
 	})
 }
 
+// UpdateAttendanceWithHistory juga menandai punctuality attendance untuk dihitung ulang job punctuality
 func (r *attendanceRepository) UpdateAttendanceWithHistory(attendance *domain.Attendance, history *domain.AttendanceHistory) error {
+	attendance.PunctualityEvaluatedAt = nil
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Save(attendance).Error; err != nil {
 			return err
 		}
+		// Istirahat yang masih berjalan (atau selesai setelah clock out yang dimundurkan) ikut selesai saat clock out
+		if attendance.ClockOut != nil {
+			if err := tx.Model(&domain.AttendanceBreak{}).
+				Where("attendance_id = ? AND (ended_at IS NULL OR ended_at > ?)", attendance.AttendanceID, *attendance.ClockOut).
+				Update("ended_at", *attendance.ClockOut).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(history).Error
+	})
+}
+
+// AutoCloseAttendance hanya mengisi clock_out dan auto_closed jika attendance masih open, supaya
+// clock out atau akhir istirahat yang terjadi bersamaan tidak tertimpa. Mengembalikan false jika
+// attendance sudah ditutup; history tidak dicatat dalam kasus itu.
+func (r *attendanceRepository) AutoCloseAttendance(attendance *domain.Attendance, history *domain.AttendanceHistory) (bool, error) {
+	closed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Attendance{}).
+			Where("attendance_id = ? AND clock_out IS NULL", attendance.AttendanceID).
+			Updates(map[string]interface{}{
+				"clock_out":                attendance.ClockOut,
+				"auto_closed":              true,
+				"punctuality_evaluated_at": nil,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		closed = true
+		if err := tx.Model(&domain.AttendanceBreak{}).
+			Where("attendance_id = ? AND (ended_at IS NULL OR ended_at > ?)", attendance.AttendanceID, *attendance.ClockOut).
+			Update("ended_at", *attendance.ClockOut).Error; err != nil {
+			return err
+		}
 		return tx.Create(history).Error
 	})
+	return closed, err
 }
+
+func (r *attendanceRepository) FindAttendanceHistoryByID(id uuid.UUID) (*domain.AttendanceHistory, error) {
+	var history domain.AttendanceHistory
+	if err := r.db.First(&history, id).Error; err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
 func (r *attendanceRepository) FindAttendanceHistoryByEmployeeCode(employeeCode string, page, limit int) ([]*domain.AttendanceHistory, int64, error) {
 	var histories []*domain.AttendanceHistory
 	query := r.db.Model(&domain.AttendanceHistory{}).
@@ -70,24 +139,70 @@ func (r *attendanceRepository) GetAttendanceQuery() *gorm.DB {
 	return r.db.Table("attendances a").
 		Joins("JOIN user_profiles up ON a.employee_code = up.employee_code").
 		Joins("JOIN departments d ON up.department_id = d.id").
+		// Shift yang berlaku untuk karyawan pada hari kerja attendance
+		Joins(`LEFT JOIN LATERAL (
+			SELECT s.name, s.start_time, s.end_time, s.grace_minutes, s.weekdays
+			FROM shift_assignments sa
+			JOIN shifts s ON s.id = sa.shift_id AND s.deleted_at IS NULL
+			WHERE sa.employee_code = a.employee_code
+				AND sa.deleted_at IS NULL
+				AND sa.effective_from <= COALESCE(a.work_date, DATE(a.clock_in))
+				AND (sa.effective_to IS NULL OR sa.effective_to >= COALESCE(a.work_date, DATE(a.clock_in)))
+			ORDER BY sa.effective_from DESC
+			LIMIT 1
+		) sh ON TRUE`).
+		// Total istirahat; istirahat yang belum selesai dihitung sampai clock out
+		Joins(`LEFT JOIN LATERAL (
+			SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (COALESCE(b.ended_at, a.clock_out) - b.started_at))), 0)::int AS break_seconds
+			FROM attendance_breaks b
+			WHERE b.attendance_id = a.attendance_id
+				AND b.deleted_at IS NULL
+				AND COALESCE(b.ended_at, a.clock_out) IS NOT NULL
+		) br ON TRUE`).
 		Select(`
 			a.attendance_id,
 			a.employee_code,
 			up.full_name,
 			d.department_name,
+			COALESCE(a.work_date, DATE(a.clock_in)) AS work_date,
 			a.clock_in,
 			a.clock_out,
+			a.status,
+			a.holiday_work,
+			a.auto_closed,
+			a.mode,
+			br.break_seconds,
+			a.late_minutes,
+			a.early_leave_minutes,
+			a.worked_minutes,
+			d.max_break_minutes,
+			d.overtime_min_minutes,
+			d.overtime_rounding_minutes,
 			d.max_clock_in_time,
-			d.max_clock_out_time
+			d.max_clock_out_time,
+			d.grace_minutes AS department_grace_minutes,
+			d.punctuality_rule,
+			d.core_start_time,
+			d.core_end_time,
+			d.min_work_minutes,
+			d.weekday_grace_minutes,
+			sh.name AS shift_name,
+			sh.start_time AS shift_start_time,
+			sh.end_time AS shift_end_time,
+			sh.grace_minutes AS shift_grace_minutes,
+			sh.weekdays @> to_jsonb(EXTRACT(DOW FROM COALESCE(a.work_date, DATE(a.clock_in)))::int) AS shift_applies
 		`)
 }
 
+// FindCurrentAttendance mengembalikan attendance hari kerja ini, atau attendance
+// shift malam dari hari sebelumnya yang belum clock out.
 func (r *attendanceRepository) FindCurrentAttendance(employeeCode string) (*domain.Attendance, error) {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()) // 10:06 AM WIB, 17 Sept 2025
 
 	var attendance domain.Attendance
-	err := r.db.Where("employee_code = ? AND DATE(created_at) = ? AND deleted_at IS NULL", employeeCode, today).
+	err := r.db.Where("employee_code = ? AND deleted_at IS NULL", employeeCode).
+		Where("COALESCE(work_date, DATE(created_at)) = ? OR (clock_out IS NULL AND clock_in >= ?)", today, now.Add(-24*time.Hour)).
 		Order("created_at DESC").First(&attendance).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -97,3 +212,218 @@ func (r *attendanceRepository) FindCurrentAttendance(employeeCode string) (*doma
 	}
 	return &attendance, nil
 }
+
+// FindOpenAttendance mencari attendance terakhir yang sudah clock in tapi belum clock out sejak `since`
+func (r *attendanceRepository) FindOpenAttendance(employeeCode string, since time.Time) (*domain.Attendance, error) {
+	var attendance domain.Attendance
+	err := r.db.Where("employee_code = ? AND clock_in IS NOT NULL AND clock_out IS NULL", employeeCode).
+		Where("clock_in >= ?", since).
+		Order("clock_in DESC").First(&attendance).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// FindLatestAttendanceBefore mencari attendance terakhir dengan clock in antara `since` dan `before`,
+// sudah clock out atau belum
+func (r *attendanceRepository) FindLatestAttendanceBefore(employeeCode string, since, before time.Time) (*domain.Attendance, error) {
+	var attendance domain.Attendance
+	err := r.db.Where("employee_code = ? AND clock_in IS NOT NULL", employeeCode).
+		Where("clock_in >= ? AND clock_in < ?", since, before).
+		Order("clock_in DESC").First(&attendance).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// CreateMissingAttendances membuat attendance yang belum ada; hari yang sudah punya
+// record (clock in, cuti, atau absent sebelumnya) dilewati. Mengembalikan jumlah record baru.
+func (r *attendanceRepository) CreateMissingAttendances(attendances []*domain.Attendance) (int64, error) {
+	if len(attendances) == 0 {
+		return 0, nil
+	}
+	// Backfill absence bisa berisi banyak hari x karyawan, jadi insert per batch
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "attendance_id"}},
+		DoNothing: true,
+	}).CreateInBatches(&attendances, 500)
+	return result.RowsAffected, result.Error
+}
+
+// FindOpenAttendancesWithAutoClockOut mengambil attendance yang belum clock out milik
+// karyawan yang departemennya punya kebijakan auto clock out
+func (r *attendanceRepository) FindOpenAttendancesWithAutoClockOut() ([]*domain.Attendance, error) {
+	var attendances []*domain.Attendance
+	err := r.db.Model(&domain.Attendance{}).
+		Joins("JOIN user_profiles up ON up.employee_code = attendances.employee_code AND up.deleted_at IS NULL").
+		Joins("JOIN departments d ON d.id = up.department_id AND d.deleted_at IS NULL").
+		Where("attendances.clock_in IS NOT NULL AND attendances.clock_out IS NULL").
+		Where("attendances.status = ?", domain.AttendanceStatusPresent).
+		Where("d.auto_clock_out_mode <> ?", domain.AutoClockOutNone).
+		Order("attendances.clock_in ASC").
+		Find(&attendances).Error
+	return attendances, err
+}
+
+// FindOpenBreak mengembalikan istirahat yang belum selesai, nil jika tidak ada
+func (r *attendanceRepository) FindOpenBreak(attendanceID string) (*domain.AttendanceBreak, error) {
+	var brk domain.AttendanceBreak
+	err := r.db.Where("attendance_id = ? AND ended_at IS NULL", attendanceID).
+		Order("started_at DESC").First(&brk).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &brk, nil
+}
+
+// CreateBreakWithHistory mengembalikan false jika attendance sudah punya istirahat terbuka
+// (bentrok dengan idx_attendance_breaks_open); history tidak dicatat dalam kasus itu
+func (r *attendanceRepository) CreateBreakWithHistory(brk *domain.AttendanceBreak, history *domain.AttendanceHistory) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(brk)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		created = true
+		return tx.Create(history).Error
+	})
+	return created, err
+}
+
+func (r *attendanceRepository) UpdateBreakWithHistory(brk *domain.AttendanceBreak, history *domain.AttendanceHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(brk).Error; err != nil {
+			return err
+		}
+		return tx.Create(history).Error
+	})
+}
+
+// SumBreakSeconds menjumlahkan durasi istirahat yang sudah selesai pada satu attendance
+func (r *attendanceRepository) SumBreakSeconds(attendanceID string) (int, error) {
+	var seconds int
+	err := r.db.Model(&domain.AttendanceBreak{}).
+		Where("attendance_id = ? AND ended_at IS NOT NULL", attendanceID).
+		Select("COALESCE(SUM(EXTRACT(EPOCH FROM (ended_at - started_at))), 0)::int").
+		Scan(&seconds).Error
+	return seconds, err
+}
+
+// SavePunctuality menyimpan hasil punctualityRule. Attendance yang diubah setelah evaluatedAt
+// tidak ditimpa dan tetap menunggu dihitung ulang.
+func (r *attendanceRepository) SavePunctuality(attendanceID string, lateMinutes, earlyLeaveMinutes int, workedMinutes *int, evaluatedAt time.Time) (bool, error) {
+	result := r.db.Model(&domain.Attendance{}).
+		Where("attendance_id = ? AND punctuality_evaluated_at IS NULL AND updated_at <= ?", attendanceID, evaluatedAt).
+		UpdateColumns(map[string]interface{}{
+			"late_minutes":             lateMinutes,
+			"early_leave_minutes":      earlyLeaveMinutes,
+			"worked_minutes":           workedMinutes,
+			"punctuality_evaluated_at": evaluatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// invalidatePunctuality menandai attendance karyawan untuk dihitung ulang job punctuality,
+// dipanggil saat jadwal atau rule yang dipakai berubah. employeeCodes boleh berupa subquery.
+func invalidatePunctuality(tx *gorm.DB, employeeCodes interface{}) error {
+	return tx.Model(&domain.Attendance{}).
+		Where("employee_code IN (?) AND punctuality_evaluated_at IS NOT NULL", employeeCodes).
+		UpdateColumn("punctuality_evaluated_at", nil).Error
+}
+
+// Agregasi dashboard dihitung di atas GetAttendanceQuery dari menit punctuality yang disimpan
+// job punctuality. Hanya angka hasil GROUP BY yang dibaca ke aplikasi.
+const (
+	dashboardPresent = "l.status = 'present'"
+	dashboardLate    = "l.status = 'present' AND l.late_minutes > 0"
+	dashboardStats   = `
+		COUNT(*) FILTER (WHERE l.status = 'present') AS present_days,
+		COUNT(*) FILTER (WHERE l.status = 'absent') AS absent_days,
+		COUNT(*) FILTER (WHERE l.status = 'on_leave') AS leave_days,
+		COUNT(*) FILTER (WHERE ` + dashboardLate + `) AS late_days,
+		COALESCE(ROUND(100.0 * COUNT(*) FILTER (WHERE ` + dashboardPresent + `)
+			/ NULLIF(COUNT(*) FILTER (WHERE l.status IN ('present', 'absent')), 0), 2), 0) AS attendance_rate,
+		COALESCE(ROUND(100.0 * COUNT(*) FILTER (WHERE ` + dashboardLate + `)
+			/ NULLIF(COUNT(*) FILTER (WHERE ` + dashboardPresent + `), 0), 2), 0) AS late_rate,
+		COALESCE(ROUND(AVG(l.late_minutes) FILTER (WHERE ` + dashboardLate + `), 2), 0) AS average_late_minutes`
+)
+
+func (r *attendanceRepository) dashboardQuery(start, end time.Time) *gorm.DB {
+	logs := r.GetAttendanceQuery().
+		Where("COALESCE(a.work_date, DATE(a.clock_in)) BETWEEN ? AND ?", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	return r.db.Table("(?) l", logs)
+}
+
+func (r *attendanceRepository) GetAttendanceStats(start, end time.Time) (*dto.DashboardAttendanceStats, error) {
+	var stats dto.DashboardAttendanceStats
+	err := r.dashboardQuery(start, end).Select(dashboardStats).Scan(&stats).Error
+	return &stats, err
+}
+
+func (r *attendanceRepository) GetDepartmentAttendanceStats(start, end time.Time) ([]dto.DashboardAttendanceStats, error) {
+	var stats []dto.DashboardAttendanceStats
+	err := r.dashboardQuery(start, end).
+		Select("l.department_name," + dashboardStats).
+		Group("l.department_name").
+		Order("l.department_name ASC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// GetDailyAttendanceTrend mengembalikan satu baris per tanggal yang punya attendance
+func (r *attendanceRepository) GetDailyAttendanceTrend(start, end time.Time) ([]dto.DashboardTrendPoint, error) {
+	var trend []dto.DashboardTrendPoint
+	err := r.dashboardQuery(start, end).
+		Select(`TO_CHAR(l.work_date, 'YYYY-MM-DD') AS date,
+			COUNT(*) FILTER (WHERE ` + dashboardPresent + `) AS present,
+			COUNT(*) FILTER (WHERE ` + dashboardLate + `) AS late,
+			COUNT(*) FILTER (WHERE l.status = 'absent') AS absent,
+			COUNT(*) FILTER (WHERE l.status = 'on_leave') AS on_leave,
+			COALESCE(ROUND(100.0 * COUNT(*) FILTER (WHERE ` + dashboardPresent + `)
+				/ NULLIF(COUNT(*) FILTER (WHERE l.status IN ('present', 'absent')), 0), 2), 0) AS attendance_rate`).
+		Group("l.work_date").
+		Order("l.work_date ASC").
+		Scan(&trend).Error
+	return trend, err
+}
+
+// GetTopLateEmployees mengurutkan karyawan berdasarkan jumlah hari terlambat, lalu total menitnya
+func (r *attendanceRepository) GetTopLateEmployees(start, end time.Time, limit int) ([]dto.DashboardLateEmployee, error) {
+	var employees []dto.DashboardLateEmployee
+	err := r.dashboardQuery(start, end).
+		Select(`l.employee_code, l.full_name, l.department_name,
+			COUNT(*) AS late_days,
+			SUM(l.late_minutes) AS total_late_minutes,
+			ROUND(AVG(l.late_minutes), 2) AS average_late_minutes`).
+		Where(dashboardLate).
+		Group("l.employee_code, l.full_name, l.department_name").
+		Order("late_days DESC, total_late_minutes DESC, l.employee_code ASC").
+		Limit(limit).
+		Scan(&employees).Error
+	return employees, err
+}
+
+// CountClockedIn menghitung attendance yang sudah clock in sejak `since` dan belum clock out
+func (r *attendanceRepository) CountClockedIn(since time.Time) (int, error) {
+	var count int64
+	err := r.db.Model(&domain.Attendance{}).
+		Where("clock_in IS NOT NULL AND clock_out IS NULL AND clock_in >= ?", since).
+		Where("status = ?", domain.AttendanceStatusPresent).
+		Count(&count).Error
+	return int(count), err
+}
@@ -0,0 +1,140 @@
+// badge_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type BadgeRepository interface {
+	CreateBadge(badge *domain.BadgeCard) error
+	FindBadgeByID(id uuid.UUID) (*domain.BadgeCard, error)
+	FindBadgeByUID(uid string) (*domain.BadgeCard, error)
+	FindActiveBadge(employeeCode string, kind domain.BadgeKind) (*domain.BadgeCard, error)
+	FindBadges(employeeCode, status string, offset, limit int) ([]*domain.BadgeCard, int64, error)
+	UpdateBadge(badge *domain.BadgeCard) error
+	ClaimBadgeScan(id uuid.UUID, now time.Time, debounce time.Duration) (bool, error)
+
+	CreateReader(reader *domain.BadgeReader) error
+	FindReaderByID(id uuid.UUID) (*domain.BadgeReader, error)
+	FindReaderByKeyHash(keyHash string) (*domain.BadgeReader, error)
+	FindReaders(offset, limit int) ([]*domain.BadgeReader, int64, error)
+	UpdateReader(reader *domain.BadgeReader) error
+	DeleteReader(id uuid.UUID) error
+	TouchReader(id uuid.UUID, seenAt time.Time) error
+}
+
+type badgeRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewBadgeRepository(db *gorm.DB, log *logrus.Logger) BadgeRepository {
+	return &badgeRepository{db: db, log: log}
+}
+
+func (r *badgeRepository) CreateBadge(badge *domain.BadgeCard) error {
+	return r.db.Create(badge).Error
+}
+
+func (r *badgeRepository) FindBadgeByID(id uuid.UUID) (*domain.BadgeCard, error) {
+	var badge domain.BadgeCard
+	if err := r.db.First(&badge, id).Error; err != nil {
+		return nil, err
+	}
+	return &badge, nil
+}
+
+func (r *badgeRepository) FindBadgeByUID(uid string) (*domain.BadgeCard, error) {
+	var badge domain.BadgeCard
+	if err := r.db.Where("uid = ?", uid).First(&badge).Error; err != nil {
+		return nil, err
+	}
+	return &badge, nil
+}
+
+func (r *badgeRepository) FindActiveBadge(employeeCode string, kind domain.BadgeKind) (*domain.BadgeCard, error) {
+	var badge domain.BadgeCard
+	err := r.db.Where("employee_code = ? AND kind = ? AND status = ?", employeeCode, kind, domain.BadgeStatusActive).
+		Order("created_at DESC").First(&badge).Error
+	if err != nil {
+		return nil, err
+	}
+	return &badge, nil
+}
+
+func (r *badgeRepository) FindBadges(employeeCode, status string, offset, limit int) ([]*domain.BadgeCard, int64, error) {
+	var badges []*domain.BadgeCard
+	query := r.db.Model(&domain.BadgeCard{})
+	if employeeCode != "" {
+		query = query.Where("employee_code = ?", employeeCode)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&badges).Error
+	return badges, total, err
+}
+
+func (r *badgeRepository) UpdateBadge(badge *domain.BadgeCard) error {
+	return r.db.Save(badge).Error
+}
+
+// ClaimBadgeScan mencatat waktu scan kartu jika scan sebelumnya sudah lewat dari debounce.
+// Update bersyarat ini juga mencegah dua reader memproses kartu yang sama bersamaan.
+func (r *badgeRepository) ClaimBadgeScan(id uuid.UUID, now time.Time, debounce time.Duration) (bool, error) {
+	result := r.db.Model(&domain.BadgeCard{}).
+		Where("id = ? AND (last_scan_at IS NULL OR last_scan_at <= ?)", id, now.Add(-debounce)).
+		UpdateColumn("last_scan_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *badgeRepository) CreateReader(reader *domain.BadgeReader) error {
+	return r.db.Create(reader).Error
+}
+
+func (r *badgeRepository) FindReaderByID(id uuid.UUID) (*domain.BadgeReader, error) {
+	var reader domain.BadgeReader
+	if err := r.db.First(&reader, id).Error; err != nil {
+		return nil, err
+	}
+	return &reader, nil
+}
+
+func (r *badgeRepository) FindReaderByKeyHash(keyHash string) (*domain.BadgeReader, error) {
+	return findDeviceByKeyHash[domain.BadgeReader](r.db, keyHash)
+}
+
+func (r *badgeRepository) FindReaders(offset, limit int) ([]*domain.BadgeReader, int64, error) {
+	var readers []*domain.BadgeReader
+	var total int64
+	if err := r.db.Model(&domain.BadgeReader{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := r.db.Order("created_at DESC").Offset(offset).Limit(limit).Find(&readers).Error
+	return readers, total, err
+}
+
+func (r *badgeRepository) UpdateReader(reader *domain.BadgeReader) error {
+	return r.db.Save(reader).Error
+}
+
+func (r *badgeRepository) DeleteReader(id uuid.UUID) error {
+	return r.db.Delete(&domain.BadgeReader{}, id).Error
+}
+
+func (r *badgeRepository) TouchReader(id uuid.UUID, seenAt time.Time) error {
+	return touchDevice[domain.BadgeReader](r.db, id, seenAt)
+}
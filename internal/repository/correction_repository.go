@@ -0,0 +1,90 @@
+// correction_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CorrectionRepository interface {
+	CreateCorrection(correction *domain.AttendanceCorrection) error
+	FindCorrectionByID(id uuid.UUID) (*domain.AttendanceCorrection, error)
+	FindCorrections(employeeCode string, status string, offset, limit int) ([]*domain.AttendanceCorrection, int64, error)
+	HasPendingCorrection(attendanceID string) (bool, error)
+	UpdateCorrection(correction *domain.AttendanceCorrection) error
+	ApplyCorrection(correction *domain.AttendanceCorrection, attendance *domain.Attendance, history *domain.AttendanceHistory) error
+}
+
+type correctionRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewCorrectionRepository(db *gorm.DB, log *logrus.Logger) CorrectionRepository {
+	return &correctionRepository{db: db, log: log}
+}
+
+func (r *correctionRepository) CreateCorrection(correction *domain.AttendanceCorrection) error {
+	return r.db.Create(correction).Error
+}
+
+func (r *correctionRepository) FindCorrectionByID(id uuid.UUID) (*domain.AttendanceCorrection, error) {
+	var correction domain.AttendanceCorrection
+	if err := r.db.First(&correction, id).Error; err != nil {
+		return nil, err
+	}
+	return &correction, nil
+}
+
+func (r *correctionRepository) FindCorrections(employeeCode string, status string, offset, limit int) ([]*domain.AttendanceCorrection, int64, error) {
+	var corrections []*domain.AttendanceCorrection
+	query := r.db.Model(&domain.AttendanceCorrection{})
+	if employeeCode != "" {
+		query = query.Where("employee_code = ?", employeeCode)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&corrections).Error
+	return corrections, total, err
+}
+
+func (r *correctionRepository) HasPendingCorrection(attendanceID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.AttendanceCorrection{}).
+		Where("attendance_id = ? AND status = ?", attendanceID, domain.ApprovalPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *correctionRepository) UpdateCorrection(correction *domain.AttendanceCorrection) error {
+	return r.db.Save(correction).Error
+}
+
+// ApplyCorrection menyimpan hasil review, membuat/mengubah attendance, dan mencatat
+// history "adjustment" dalam satu transaksi. Attendance dengan ID kosong dibuat baru.
+func (r *correctionRepository) ApplyCorrection(correction *domain.AttendanceCorrection, attendance *domain.Attendance, history *domain.AttendanceHistory) error {
+	attendance.PunctualityEvaluatedAt = nil // Jam berubah, menit punctuality dihitung ulang job punctuality
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(correction).Error; err != nil {
+			return err
+		}
+		if attendance.ID == uuid.Nil {
+			if err := tx.Create(attendance).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Save(attendance).Error; err != nil {
+			return err
+		}
+		return tx.Create(history).Error
+	})
+}
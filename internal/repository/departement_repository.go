@@ -21,6 +21,9 @@ type DepartmentRepository interface {
 	AssignmentDepartement(userID uuid.UUID, departmentID uuid.UUID) error
 
 	CountUpdatedDepartments(startDate, endDate time.Time) (int, error)
+
+	FindModeRulesByDepartmentID(departmentID uuid.UUID) ([]*domain.DepartmentModeRule, error)
+	ReplaceModeRules(departmentID uuid.UUID, rules []*domain.DepartmentModeRule) error
 }
 
 type departmentRepository struct {
@@ -31,21 +34,25 @@ type departmentRepository struct {
 func NewDepartmentRepository(db *gorm.DB, log *logrus.Logger) DepartmentRepository {
 	return &departmentRepository{db: db, log: log}
 }
+
+// AssignmentDepartement juga menandai attendance karyawan untuk dihitung ulang dengan rule departemen baru
 func (r *departmentRepository) AssignmentDepartement(userID uuid.UUID, departmentID uuid.UUID) error {
-	result := r.db.Model(&domain.UserProfile{}).
-		Where("source_user_id = ?", userID).
-		Updates(map[string]interface{}{
-			"department_id": departmentID,
-			"updated_at":    r.db.NowFunc(),
-		})
-
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("no user profile updated")
-	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.UserProfile{}).
+			Where("source_user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"department_id": departmentID,
+				"updated_at":    tx.NowFunc(),
+			})
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("no user profile updated")
+		}
+		return invalidatePunctuality(tx, tx.Model(&domain.UserProfile{}).Select("employee_code").Where("source_user_id = ?", userID))
+	})
 }
 func (r *departmentRepository) IsDepartmentExist(departmentID uuid.UUID) (bool, error) {
 	var exists bool
@@ -74,8 +81,15 @@ func (r *departmentRepository) FindDepartmentByID(id uuid.UUID) (*domain.Departm
 	return &dept, nil
 }
 
+// UpdateDepartment juga menandai attendance karyawan departemen untuk dihitung ulang, karena
+// jam kerja, grace dan rule punctuality departemen ikut menentukan menit terlambat
 func (r *departmentRepository) UpdateDepartment(dept *domain.Department) error {
-	return r.db.Save(dept).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(dept).Error; err != nil {
+			return err
+		}
+		return invalidatePunctuality(tx, tx.Model(&domain.UserProfile{}).Select("employee_code").Where("department_id = ?", dept.ID))
+	})
 }
 
 func (r *departmentRepository) DeleteDepartment(id uuid.UUID) error {
@@ -99,3 +113,23 @@ func (r *departmentRepository) CountUpdatedDepartments(startDate, endDate time.T
 		Count(&count).Error
 	return int(count), err
 }
+
+func (r *departmentRepository) FindModeRulesByDepartmentID(departmentID uuid.UUID) ([]*domain.DepartmentModeRule, error) {
+	var rules []*domain.DepartmentModeRule
+	err := r.db.Where("department_id = ?", departmentID).Order("mode ASC").Find(&rules).Error
+	return rules, err
+}
+
+// ReplaceModeRules mengganti seluruh rule attendance mode departemen dalam satu transaksi
+func (r *departmentRepository) ReplaceModeRules(departmentID uuid.UUID, rules []*domain.DepartmentModeRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Hard delete supaya unique index (department_id, mode) bisa dipakai ulang
+		if err := tx.Unscoped().Where("department_id = ?", departmentID).Delete(&domain.DepartmentModeRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
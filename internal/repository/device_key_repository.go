@@ -0,0 +1,23 @@
+// device_key_repository.go
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// findDeviceByKeyHash dan touchDevice dipakai bersama oleh semua tabel perangkat yang
+// meng-embed domain.DeviceKey (kiosk, reader RFID)
+func findDeviceByKeyHash[D any](db *gorm.DB, keyHash string) (*D, error) {
+	var device D
+	if err := db.Where("key_hash = ?", keyHash).First(&device).Error; err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+func touchDevice[D any](db *gorm.DB, id uuid.UUID, seenAt time.Time) error {
+	return db.Model(new(D)).Where("id = ?", id).UpdateColumn("last_seen_at", seenAt).Error
+}
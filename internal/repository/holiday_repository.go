@@ -0,0 +1,121 @@
+// holiday_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type HolidayRepository interface {
+	CreateHoliday(holiday *domain.Holiday) error
+	CreateHolidays(holidays []*domain.Holiday) error
+	FindHolidayByID(id uuid.UUID) (*domain.Holiday, error)
+	UpdateHoliday(holiday *domain.Holiday) error
+	DeleteHoliday(id uuid.UUID) error
+	FindAllHolidays(req dto.ListHolidaysRequest) ([]*domain.Holiday, int64, error)
+	FindHolidayOn(day time.Time, departmentID *uuid.UUID) (*domain.Holiday, error)
+	FindHolidaysBetween(start, end time.Time) ([]*domain.Holiday, error)
+	IsHolidayImported(externalUID string, day time.Time, departmentID *uuid.UUID) (bool, error)
+}
+
+type holidayRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewHolidayRepository(db *gorm.DB, log *logrus.Logger) HolidayRepository {
+	return &holidayRepository{db: db, log: log}
+}
+
+func (r *holidayRepository) CreateHoliday(holiday *domain.Holiday) error {
+	return r.db.Omit("Department").Create(holiday).Error
+}
+
+func (r *holidayRepository) CreateHolidays(holidays []*domain.Holiday) error {
+	if len(holidays) == 0 {
+		return nil
+	}
+	return r.db.Omit("Department").CreateInBatches(holidays, 100).Error
+}
+
+func (r *holidayRepository) FindHolidayByID(id uuid.UUID) (*domain.Holiday, error) {
+	var holiday domain.Holiday
+	if err := r.db.First(&holiday, id).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *holidayRepository) UpdateHoliday(holiday *domain.Holiday) error {
+	return r.db.Omit("Department").Save(holiday).Error
+}
+
+func (r *holidayRepository) DeleteHoliday(id uuid.UUID) error {
+	return r.db.Delete(&domain.Holiday{}, id).Error
+}
+
+func (r *holidayRepository) FindAllHolidays(req dto.ListHolidaysRequest) ([]*domain.Holiday, int64, error) {
+	var holidays []*domain.Holiday
+	query := r.db.Model(&domain.Holiday{})
+	if req.Year != 0 {
+		query = query.Where("EXTRACT(YEAR FROM date) = ?", req.Year)
+	}
+	if req.DepartmentID != nil {
+		query = query.Where("department_id IS NULL OR department_id = ?", *req.DepartmentID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	err := query.Order("date ASC").Offset(offset).Limit(req.Limit).Find(&holidays).Error
+	return holidays, total, err
+}
+
+// FindHolidayOn mencari hari libur yang berlaku untuk departemen pada tanggal day
+func (r *holidayRepository) FindHolidayOn(day time.Time, departmentID *uuid.UUID) (*domain.Holiday, error) {
+	var holiday domain.Holiday
+	query := r.db.Where("date = ?", day.Format("2006-01-02"))
+	if departmentID != nil {
+		query = query.Where("department_id IS NULL OR department_id = ?", *departmentID)
+	} else {
+		query = query.Where("department_id IS NULL")
+	}
+	err := query.Order("department_id NULLS LAST").First(&holiday).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// FindHolidaysBetween mengambil semua hari libur nasional dan departemen di rentang [start, end]
+func (r *holidayRepository) FindHolidaysBetween(start, end time.Time) ([]*domain.Holiday, error) {
+	var holidays []*domain.Holiday
+	err := r.db.Where("date BETWEEN ? AND ?", start.Format("2006-01-02"), end.Format("2006-01-02")).
+		Order("date, department_id NULLS LAST").
+		Find(&holidays).Error
+	return holidays, err
+}
+
+func (r *holidayRepository) IsHolidayImported(externalUID string, day time.Time, departmentID *uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.Model(&domain.Holiday{}).
+		Where("external_uid = ? AND date = ?", externalUID, day.Format("2006-01-02"))
+	if departmentID != nil {
+		query = query.Where("department_id = ?", *departmentID)
+	} else {
+		query = query.Where("department_id IS NULL")
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,86 @@
+// idempotency_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository interface {
+	CreateRecord(record *domain.IdempotencyRecord) (bool, error)
+	ReclaimRecord(record *domain.IdempotencyRecord, lockedBefore time.Time) (bool, error)
+	FindRecord(scope, key string) (*domain.IdempotencyRecord, error)
+	SaveResponse(scope, key string, lockedAt time.Time, statusCode int, contentType string, body []byte) error
+	DeleteRecord(scope, key string, lockedAt time.Time) error
+	DeleteExpiredRecords(now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewIdempotencyRepository(db *gorm.DB, log *logrus.Logger) IdempotencyRepository {
+	return &idempotencyRepository{db: db, log: log}
+}
+
+// CreateRecord mengembalikan false jika key sudah pernah dipakai di scope yang sama
+func (r *idempotencyRepository) CreateRecord(record *domain.IdempotencyRecord) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReclaimRecord mengambil alih key yang sudah kedaluwarsa, atau yang masih diproses sejak
+// sebelum lockedBefore (request pertama terhenti tanpa sempat menyimpan response)
+func (r *idempotencyRepository) ReclaimRecord(record *domain.IdempotencyRecord, lockedBefore time.Time) (bool, error) {
+	result := r.db.Model(&domain.IdempotencyRecord{}).
+		Where("scope = ? AND key = ?", record.Scope, record.Key).
+		Where("expires_at <= ? OR (status_code = 0 AND locked_at < ?)", record.LockedAt, lockedBefore).
+		Updates(map[string]interface{}{
+			"request_hash": record.RequestHash,
+			"status_code":  0,
+			"content_type": "",
+			"body":         nil,
+			"locked_at":    record.LockedAt,
+			"expires_at":   record.ExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *idempotencyRepository) FindRecord(scope, key string) (*domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	if err := r.db.Where("scope = ? AND key = ?", scope, key).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// SaveResponse dan DeleteRecord dibatasi ke locked_at milik request pemegang kunci
+func (r *idempotencyRepository) SaveResponse(scope, key string, lockedAt time.Time, statusCode int, contentType string, body []byte) error {
+	return r.db.Model(&domain.IdempotencyRecord{}).
+		Where("scope = ? AND key = ? AND locked_at = ? AND status_code = 0", scope, key, lockedAt).
+		Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+		}).Error
+}
+
+func (r *idempotencyRepository) DeleteRecord(scope, key string, lockedAt time.Time) error {
+	return r.db.Where("scope = ? AND key = ? AND locked_at = ? AND status_code = 0", scope, key, lockedAt).Delete(&domain.IdempotencyRecord{}).Error
+}
+
+func (r *idempotencyRepository) DeleteExpiredRecords(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&domain.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,45 @@
+// job_run_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRunRepository interface {
+	FindLastSuccess(name string) (*time.Time, error)
+	SaveSuccess(name string, at time.Time) error
+}
+
+type jobRunRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewJobRunRepository(db *gorm.DB, log *logrus.Logger) JobRunRepository {
+	return &jobRunRepository{db: db, log: log}
+}
+
+// FindLastSuccess mengembalikan nil jika job belum pernah berhasil
+func (r *jobRunRepository) FindLastSuccess(name string) (*time.Time, error) {
+	var run domain.JobRun
+	if err := r.db.Where("name = ?", name).First(&run).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run.LastSuccessAt, nil
+}
+
+func (r *jobRunRepository) SaveSuccess(name string, at time.Time) error {
+	run := domain.JobRun{Name: name, LastSuccessAt: at, UpdatedAt: time.Now()}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_success_at", "updated_at"}),
+	}).Create(&run).Error
+}
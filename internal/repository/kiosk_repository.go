@@ -0,0 +1,86 @@
+// kiosk_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type KioskRepository interface {
+	CreateKiosk(kiosk *domain.KioskDevice) error
+	FindKioskByID(id uuid.UUID) (*domain.KioskDevice, error)
+	FindKioskByKeyHash(keyHash string) (*domain.KioskDevice, error)
+	FindKiosks(offset, limit int) ([]*domain.KioskDevice, int64, error)
+	UpdateKiosk(kiosk *domain.KioskDevice) error
+	DeleteKiosk(id uuid.UUID) error
+	TouchKiosk(id uuid.UUID, seenAt time.Time) error
+
+	CreateScan(scan *domain.KioskScan) (bool, error)
+	DeleteScan(id uuid.UUID) error
+}
+
+type kioskRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewKioskRepository(db *gorm.DB, log *logrus.Logger) KioskRepository {
+	return &kioskRepository{db: db, log: log}
+}
+
+func (r *kioskRepository) CreateKiosk(kiosk *domain.KioskDevice) error {
+	return r.db.Create(kiosk).Error
+}
+
+func (r *kioskRepository) FindKioskByID(id uuid.UUID) (*domain.KioskDevice, error) {
+	var kiosk domain.KioskDevice
+	if err := r.db.First(&kiosk, id).Error; err != nil {
+		return nil, err
+	}
+	return &kiosk, nil
+}
+
+func (r *kioskRepository) FindKioskByKeyHash(keyHash string) (*domain.KioskDevice, error) {
+	return findDeviceByKeyHash[domain.KioskDevice](r.db, keyHash)
+}
+
+func (r *kioskRepository) FindKiosks(offset, limit int) ([]*domain.KioskDevice, int64, error) {
+	var kiosks []*domain.KioskDevice
+	var total int64
+	if err := r.db.Model(&domain.KioskDevice{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := r.db.Order("created_at DESC").Offset(offset).Limit(limit).Find(&kiosks).Error
+	return kiosks, total, err
+}
+
+func (r *kioskRepository) UpdateKiosk(kiosk *domain.KioskDevice) error {
+	return r.db.Omit("Department", "OfficeLocation").Save(kiosk).Error
+}
+
+func (r *kioskRepository) DeleteKiosk(id uuid.UUID) error {
+	return r.db.Delete(&domain.KioskDevice{}, id).Error
+}
+
+func (r *kioskRepository) TouchKiosk(id uuid.UUID, seenAt time.Time) error {
+	return touchDevice[domain.KioskDevice](r.db, id, seenAt)
+}
+
+// CreateScan mengembalikan false jika kombinasi nonce + employee code sudah pernah dipakai (replay)
+func (r *kioskRepository) CreateScan(scan *domain.KioskScan) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(scan)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteScan menghapus scan yang clock in/out-nya gagal supaya nonce bisa dipakai lagi
+func (r *kioskRepository) DeleteScan(id uuid.UUID) error {
+	return r.db.Delete(&domain.KioskScan{}, id).Error
+}
@@ -0,0 +1,223 @@
+// leave_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LeaveRepository interface {
+	CreateLeaveType(leaveType *domain.LeaveType) error
+	FindLeaveTypeByID(id uuid.UUID) (*domain.LeaveType, error)
+	UpdateLeaveType(leaveType *domain.LeaveType) error
+	DeleteLeaveType(id uuid.UUID) error
+	FindAllLeaveTypes() ([]*domain.LeaveType, error)
+
+	FindOrCreateBalance(employeeCode string, leaveType *domain.LeaveType, year int) (*domain.LeaveBalance, error)
+	UpdateBalance(balance *domain.LeaveBalance) error
+	FindBalancesByEmployeeCode(employeeCode string, year int) ([]*domain.LeaveBalance, error)
+	SumPendingDays(employeeCode string, leaveTypeID uuid.UUID, year int) (int, error)
+
+	CreateLeaveRequest(req *domain.LeaveRequest) error
+	FindLeaveRequestByID(id uuid.UUID) (*domain.LeaveRequest, error)
+	FindLeaveRequests(employeeCode string, status string, offset, limit int) ([]*domain.LeaveRequest, int64, error)
+	HasOverlappingLeave(employeeCode string, start, end time.Time) (bool, error)
+	ApproveLeaveRequest(req *domain.LeaveRequest, balance *domain.LeaveBalance, attendances []*domain.Attendance) error
+	RejectLeaveRequest(req *domain.LeaveRequest) error
+	CancelLeaveRequest(req *domain.LeaveRequest, balance *domain.LeaveBalance, today time.Time) error
+}
+
+type leaveRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewLeaveRepository(db *gorm.DB, log *logrus.Logger) LeaveRepository {
+	return &leaveRepository{db: db, log: log}
+}
+
+func (r *leaveRepository) CreateLeaveType(leaveType *domain.LeaveType) error {
+	return r.db.Create(leaveType).Error
+}
+
+func (r *leaveRepository) FindLeaveTypeByID(id uuid.UUID) (*domain.LeaveType, error) {
+	var leaveType domain.LeaveType
+	if err := r.db.First(&leaveType, id).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+func (r *leaveRepository) UpdateLeaveType(leaveType *domain.LeaveType) error {
+	return r.db.Save(leaveType).Error
+}
+
+func (r *leaveRepository) DeleteLeaveType(id uuid.UUID) error {
+	return r.db.Delete(&domain.LeaveType{}, id).Error
+}
+
+func (r *leaveRepository) FindAllLeaveTypes() ([]*domain.LeaveType, error) {
+	var leaveTypes []*domain.LeaveType
+	err := r.db.Order("code ASC").Find(&leaveTypes).Error
+	return leaveTypes, err
+}
+
+// FindOrCreateBalance mengambil saldo cuti, atau membuatnya dari AnnualQuota jenis cuti
+func (r *leaveRepository) FindOrCreateBalance(employeeCode string, leaveType *domain.LeaveType, year int) (*domain.LeaveBalance, error) {
+	balance := domain.LeaveBalance{
+		EmployeeCode: employeeCode,
+		LeaveTypeID:  leaveType.ID,
+		Year:         year,
+	}
+	err := r.db.Where(&balance).
+		Attrs(domain.LeaveBalance{Entitled: leaveType.AnnualQuota}).
+		FirstOrCreate(&balance).Error
+	if err != nil {
+		return nil, err
+	}
+	balance.LeaveType = leaveType
+	return &balance, nil
+}
+
+func (r *leaveRepository) UpdateBalance(balance *domain.LeaveBalance) error {
+	return r.db.Omit("LeaveType").Save(balance).Error
+}
+
+func (r *leaveRepository) FindBalancesByEmployeeCode(employeeCode string, year int) ([]*domain.LeaveBalance, error) {
+	var balances []*domain.LeaveBalance
+	err := r.db.Preload("LeaveType").
+		Where("employee_code = ? AND year = ?", employeeCode, year).
+		Find(&balances).Error
+	return balances, err
+}
+
+func (r *leaveRepository) SumPendingDays(employeeCode string, leaveTypeID uuid.UUID, year int) (int, error) {
+	var total int64
+	err := r.db.Model(&domain.LeaveRequest{}).
+		Select("COALESCE(SUM(days), 0)").
+		Where("employee_code = ? AND leave_type_id = ? AND status = ?", employeeCode, leaveTypeID, domain.ApprovalPending).
+		Where("EXTRACT(YEAR FROM start_date) = ?", year).
+		Scan(&total).Error
+	return int(total), err
+}
+
+func (r *leaveRepository) CreateLeaveRequest(req *domain.LeaveRequest) error {
+	return r.db.Omit("LeaveType").Create(req).Error
+}
+
+func (r *leaveRepository) FindLeaveRequestByID(id uuid.UUID) (*domain.LeaveRequest, error) {
+	var req domain.LeaveRequest
+	if err := r.db.Preload("LeaveType", withDeletedLeaveType).First(&req, id).Error; err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// withDeletedLeaveType tetap memuat jenis cuti yang sudah dihapus untuk pengajuan cuti lama
+func withDeletedLeaveType(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+func (r *leaveRepository) FindLeaveRequests(employeeCode string, status string, offset, limit int) ([]*domain.LeaveRequest, int64, error) {
+	var requests []*domain.LeaveRequest
+	query := r.db.Model(&domain.LeaveRequest{})
+	if employeeCode != "" {
+		query = query.Where("employee_code = ?", employeeCode)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("LeaveType", withDeletedLeaveType).Order("start_date DESC").Offset(offset).Limit(limit).Find(&requests).Error
+	return requests, total, err
+}
+
+func (r *leaveRepository) HasOverlappingLeave(employeeCode string, start, end time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.LeaveRequest{}).
+		Where("employee_code = ? AND status IN ?", employeeCode, []domain.ApprovalStatus{domain.ApprovalPending, domain.ApprovalApproved}).
+		Where("start_date <= ? AND end_date >= ?", end, start).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ApproveLeaveRequest menyetujui cuti, membuat record attendance "on_leave" untuk setiap hari
+// kerja yang tercakup, lalu memotong saldo sebanyak hari yang benar-benar ditulis. Record
+// "absent" dari absence job (cuti yang diajukan belakangan) diubah menjadi "on_leave"; hari
+// yang sudah ada clock in dilewati dan tidak dipotong. req.Days diisi jumlah hari tersebut.
+func (r *leaveRepository) ApproveLeaveRequest(req *domain.LeaveRequest, balance *domain.LeaveBalance, attendances []*domain.Attendance) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		written := int64(0)
+		if len(attendances) > 0 {
+			created := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "attendance_id"}},
+				DoNothing: true,
+			}).Create(&attendances)
+			if created.Error != nil {
+				return created.Error
+			}
+			ids := make([]string, len(attendances))
+			for i, attendance := range attendances {
+				ids[i] = attendance.AttendanceID
+			}
+			converted := tx.Model(&domain.Attendance{}).
+				Where("attendance_id IN ? AND status = ? AND clock_in IS NULL", ids, domain.AttendanceStatusAbsent).
+				Updates(map[string]interface{}{
+					"status":           domain.AttendanceStatusOnLeave,
+					"leave_request_id": req.ID,
+				})
+			if converted.Error != nil {
+				return converted.Error
+			}
+			written = created.RowsAffected + converted.RowsAffected
+		}
+
+		req.Days = int(written)
+		balance.Used += req.Days
+		if err := tx.Omit("LeaveType").Save(req).Error; err != nil {
+			return err
+		}
+		return tx.Omit("LeaveType").Save(balance).Error
+	})
+}
+
+func (r *leaveRepository) RejectLeaveRequest(req *domain.LeaveRequest) error {
+	return r.db.Omit("LeaveType").Save(req).Error
+}
+
+// CancelLeaveRequest membatalkan cuti. Record attendance "on_leave" dari cuti ini yang sudah
+// lewat (before today) dikembalikan menjadi "absent", seperti yang akan dibuat absence job;
+// sisanya dihapus permanen supaya hari tersebut bisa diisi clock in lagi.
+func (r *leaveRepository) CancelLeaveRequest(req *domain.LeaveRequest, balance *domain.LeaveBalance, today time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("LeaveType").Save(req).Error; err != nil {
+			return err
+		}
+		if balance != nil {
+			if err := tx.Omit("LeaveType").Save(balance).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&domain.Attendance{}).
+			Where("leave_request_id = ? AND status = ? AND work_date < ?", req.ID, domain.AttendanceStatusOnLeave, today.Format("2006-01-02")).
+			Updates(map[string]interface{}{
+				"status":           domain.AttendanceStatusAbsent,
+				"leave_request_id": nil,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().
+			Where("leave_request_id = ? AND status = ?", req.ID, domain.AttendanceStatusOnLeave).
+			Delete(&domain.Attendance{}).Error
+	})
+}
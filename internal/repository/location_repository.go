@@ -0,0 +1,81 @@
+// location_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LocationRepository interface {
+	CreateLocation(location *domain.OfficeLocation) error
+	FindLocationByID(id uuid.UUID) (*domain.OfficeLocation, error)
+	UpdateLocation(location *domain.OfficeLocation, replaceDepartments bool) error
+	DeleteLocation(id uuid.UUID) error
+	FindAllLocations(offset, limit int) ([]*domain.OfficeLocation, int64, error)
+	FindLocationsByDepartmentID(departmentID uuid.UUID) ([]*domain.OfficeLocation, error)
+}
+
+type locationRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewLocationRepository(db *gorm.DB, log *logrus.Logger) LocationRepository {
+	return &locationRepository{db: db, log: log}
+}
+
+func (r *locationRepository) CreateLocation(location *domain.OfficeLocation) error {
+	// Departemen hanya di-link, tidak ikut di-upsert
+	return r.db.Omit("Departments.*").Create(location).Error
+}
+
+func (r *locationRepository) FindLocationByID(id uuid.UUID) (*domain.OfficeLocation, error) {
+	var location domain.OfficeLocation
+	if err := r.db.Preload("Departments").First(&location, id).Error; err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (r *locationRepository) UpdateLocation(location *domain.OfficeLocation, replaceDepartments bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Departments").Save(location).Error; err != nil {
+			return err
+		}
+		if !replaceDepartments {
+			return nil
+		}
+		return tx.Model(location).Omit("Departments.*").Association("Departments").Replace(location.Departments)
+	})
+}
+
+func (r *locationRepository) DeleteLocation(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		location := &domain.OfficeLocation{ID: id}
+		if err := tx.Model(location).Association("Departments").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(location).Error
+	})
+}
+
+func (r *locationRepository) FindAllLocations(offset, limit int) ([]*domain.OfficeLocation, int64, error) {
+	var locations []*domain.OfficeLocation
+	var total int64
+	if err := r.db.Model(&domain.OfficeLocation{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := r.db.Preload("Departments").Order("name ASC").Offset(offset).Limit(limit).Find(&locations).Error
+	return locations, total, err
+}
+
+func (r *locationRepository) FindLocationsByDepartmentID(departmentID uuid.UUID) ([]*domain.OfficeLocation, error) {
+	var locations []*domain.OfficeLocation
+	err := r.db.Joins("JOIN department_office_locations dol ON dol.office_location_id = office_locations.id").
+		Where("dol.department_id = ?", departmentID).
+		Find(&locations).Error
+	return locations, err
+}
@@ -0,0 +1,79 @@
+// offline_sync_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OfflineSyncRepository interface {
+	ClaimEvent(event *domain.OfflineClockEvent, staleBefore time.Time) (bool, error)
+	FindEvent(employeeCode string, eventID uuid.UUID) (*domain.OfflineClockEvent, error)
+	CompleteEvent(event *domain.OfflineClockEvent) error
+	ReleaseEvent(event *domain.OfflineClockEvent) error
+}
+
+type offlineSyncRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewOfflineSyncRepository(db *gorm.DB, log *logrus.Logger) OfflineSyncRepository {
+	return &offlineSyncRepository{db: db, log: log}
+}
+
+// ClaimEvent mengembalikan true jika event baru pertama kali diterima, atau masih pending
+// sejak sebelum staleBefore (proses sebelumnya terhenti) sehingga boleh diproses ulang
+func (r *offlineSyncRepository) ClaimEvent(event *domain.OfflineClockEvent, staleBefore time.Time) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	result = r.db.Model(&domain.OfflineClockEvent{}).
+		Where("employee_code = ? AND event_id = ?", event.EmployeeCode, event.EventID).
+		Where("status = ? AND claimed_at < ?", domain.OfflineEventPending, staleBefore).
+		UpdateColumn("claimed_at", event.ClaimedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *offlineSyncRepository) FindEvent(employeeCode string, eventID uuid.UUID) (*domain.OfflineClockEvent, error) {
+	var event domain.OfflineClockEvent
+	if err := r.db.Where("employee_code = ? AND event_id = ?", employeeCode, eventID).First(&event).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CompleteEvent menyimpan hasil akhir event (applied / rejected)
+func (r *offlineSyncRepository) CompleteEvent(event *domain.OfflineClockEvent) error {
+	return r.db.Model(&domain.OfflineClockEvent{}).
+		Where("employee_code = ? AND event_id = ?", event.EmployeeCode, event.EventID).
+		Updates(map[string]interface{}{
+			"status":        event.Status,
+			"message":       event.Message,
+			"conflict":      event.Conflict,
+			"attendance_id": event.AttendanceID,
+			"processed_at":  event.ProcessedAt,
+		}).Error
+}
+
+// ReleaseEvent menghapus claim event yang gagal karena error infrastruktur, supaya event yang
+// sama bisa dikirim ulang. Hanya claim milik proses ini (claimed_at sama) yang dihapus.
+func (r *offlineSyncRepository) ReleaseEvent(event *domain.OfflineClockEvent) error {
+	return r.db.
+		Where("employee_code = ? AND event_id = ?", event.EmployeeCode, event.EventID).
+		Where("status = ? AND claimed_at = ?", domain.OfflineEventPending, event.ClaimedAt).
+		Delete(&domain.OfflineClockEvent{}).Error
+}
@@ -0,0 +1,87 @@
+// overtime_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type OvertimeRepository interface {
+	CreateOvertimeRequest(request *domain.OvertimeRequest) error
+	FindOvertimeRequestByID(id uuid.UUID) (*domain.OvertimeRequest, error)
+	FindOvertimeRequests(employeeCode string, status string, offset, limit int) ([]*domain.OvertimeRequest, int64, error)
+	FindOvertimeRequestsBetween(employeeCode string, start, end time.Time) ([]*domain.OvertimeRequest, error)
+	HasActiveOvertimeRequest(employeeCode string, workDate time.Time) (bool, error)
+	UpdateOvertimeRequest(request *domain.OvertimeRequest) error
+}
+
+type overtimeRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewOvertimeRepository(db *gorm.DB, log *logrus.Logger) OvertimeRepository {
+	return &overtimeRepository{db: db, log: log}
+}
+
+func (r *overtimeRepository) CreateOvertimeRequest(request *domain.OvertimeRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *overtimeRepository) FindOvertimeRequestByID(id uuid.UUID) (*domain.OvertimeRequest, error) {
+	var request domain.OvertimeRequest
+	if err := r.db.First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *overtimeRepository) FindOvertimeRequests(employeeCode string, status string, offset, limit int) ([]*domain.OvertimeRequest, int64, error) {
+	var requests []*domain.OvertimeRequest
+	query := r.db.Model(&domain.OvertimeRequest{})
+	if employeeCode != "" {
+		query = query.Where("employee_code = ?", employeeCode)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("work_date DESC, created_at DESC").Offset(offset).Limit(limit).Find(&requests).Error
+	return requests, total, err
+}
+
+// FindOvertimeRequestsBetween mengambil request pending dan approved di rentang [start, end].
+// employeeCode kosong berarti semua karyawan.
+func (r *overtimeRepository) FindOvertimeRequestsBetween(employeeCode string, start, end time.Time) ([]*domain.OvertimeRequest, error) {
+	var requests []*domain.OvertimeRequest
+	query := r.db.Where("work_date BETWEEN ? AND ?", start, end).
+		Where("status IN ?", []domain.ApprovalStatus{domain.ApprovalPending, domain.ApprovalApproved})
+	if employeeCode != "" {
+		query = query.Where("employee_code = ?", employeeCode)
+	}
+	err := query.Find(&requests).Error
+	return requests, err
+}
+
+// HasActiveOvertimeRequest mengecek apakah sudah ada request pending/approved untuk hari kerja tersebut
+func (r *overtimeRepository) HasActiveOvertimeRequest(employeeCode string, workDate time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.OvertimeRequest{}).
+		Where("employee_code = ? AND work_date = ?", employeeCode, workDate).
+		Where("status IN ?", []domain.ApprovalStatus{domain.ApprovalPending, domain.ApprovalApproved}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *overtimeRepository) UpdateOvertimeRequest(request *domain.OvertimeRequest) error {
+	return r.db.Save(request).Error
+}
@@ -0,0 +1,118 @@
+// payroll_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PayrollRepository interface {
+	CreatePayPeriod(period *domain.PayPeriod) error
+	FindPayPeriodByID(id uuid.UUID) (*domain.PayPeriod, error)
+	FindPayPeriodByRange(start, end time.Time) (*domain.PayPeriod, error)
+	FindPayPeriods(status string, offset, limit int) ([]*domain.PayPeriod, int64, error)
+	FindLockedPayPeriod(start, end time.Time, excludeID *uuid.UUID) (*domain.PayPeriod, error)
+	UpdatePayPeriod(period *domain.PayPeriod) error
+	CountLeaveDays(start, end time.Time, departmentID *uuid.UUID) ([]dto.PayrollLeaveCount, error)
+}
+
+type payrollRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewPayrollRepository(db *gorm.DB, log *logrus.Logger) PayrollRepository {
+	return &payrollRepository{db: db, log: log}
+}
+
+func (r *payrollRepository) CreatePayPeriod(period *domain.PayPeriod) error {
+	return r.db.Create(period).Error
+}
+
+func (r *payrollRepository) FindPayPeriodByID(id uuid.UUID) (*domain.PayPeriod, error) {
+	var period domain.PayPeriod
+	if err := r.db.First(&period, id).Error; err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+// FindPayPeriodByRange mencari pay period dengan rentang persis sama, nil jika belum ada
+func (r *payrollRepository) FindPayPeriodByRange(start, end time.Time) (*domain.PayPeriod, error) {
+	var period domain.PayPeriod
+	err := r.db.Where("start_date = ? AND end_date = ?", start.Format("2006-01-02"), end.Format("2006-01-02")).
+		First(&period).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (r *payrollRepository) FindPayPeriods(status string, offset, limit int) ([]*domain.PayPeriod, int64, error) {
+	var periods []*domain.PayPeriod
+	query := r.db.Model(&domain.PayPeriod{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("start_date DESC").Offset(offset).Limit(limit).Find(&periods).Error
+	return periods, total, err
+}
+
+// FindLockedPayPeriod mengembalikan pay period terkunci yang beririsan dengan [start, end], nil jika tidak ada.
+// Tanggal dibandingkan sebagai string supaya tidak terpengaruh zona waktu.
+func (r *payrollRepository) FindLockedPayPeriod(start, end time.Time, excludeID *uuid.UUID) (*domain.PayPeriod, error) {
+	var period domain.PayPeriod
+	query := r.db.Where("status = ? AND start_date <= ? AND end_date >= ?",
+		domain.PayPeriodLocked, end.Format("2006-01-02"), start.Format("2006-01-02"))
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	if err := query.Order("start_date ASC").First(&period).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (r *payrollRepository) UpdatePayPeriod(period *domain.PayPeriod) error {
+	return r.db.Save(period).Error
+}
+
+// CountLeaveDays menghitung hari cuti per karyawan dari attendance on_leave, dipisah berdasarkan
+// is_paid jenis cutinya. Record cuti tanpa leave request dianggap cuti berbayar.
+func (r *payrollRepository) CountLeaveDays(start, end time.Time, departmentID *uuid.UUID) ([]dto.PayrollLeaveCount, error) {
+	var counts []dto.PayrollLeaveCount
+	query := r.db.Table("attendances a").
+		Joins("JOIN user_profiles up ON up.employee_code = a.employee_code").
+		Joins("LEFT JOIN leave_requests lr ON lr.id = a.leave_request_id").
+		Joins("LEFT JOIN leave_types lt ON lt.id = lr.leave_type_id").
+		Where("a.deleted_at IS NULL AND a.status = ?", domain.AttendanceStatusOnLeave).
+		Where("COALESCE(a.work_date, DATE(a.created_at)) BETWEEN ? AND ?", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if departmentID != nil {
+		query = query.Where("up.department_id = ?", *departmentID)
+	}
+	err := query.Select(`
+			a.employee_code,
+			COUNT(*) FILTER (WHERE COALESCE(lt.is_paid, TRUE)) AS paid_leave_days,
+			COUNT(*) FILTER (WHERE lt.is_paid = FALSE) AS unpaid_leave_days
+		`).
+		Group("a.employee_code").
+		Scan(&counts).Error
+	return counts, err
+}
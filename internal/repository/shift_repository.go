@@ -0,0 +1,170 @@
+// shift_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ShiftRepository interface {
+	CreateShift(shift *domain.Shift) error
+	FindShiftByID(id uuid.UUID) (*domain.Shift, error)
+	UpdateShift(shift *domain.Shift) error
+	DeleteShift(id uuid.UUID) error
+	FindAllShifts(offset, limit int) ([]*domain.Shift, int64, error)
+
+	CreateAssignment(assignment *domain.ShiftAssignment, closePrevious *domain.ShiftAssignment) error
+	FindAssignmentByID(id uuid.UUID) (*domain.ShiftAssignment, error)
+	DeleteAssignment(id uuid.UUID) error
+	FindAssignmentsByEmployeeCode(employeeCode string, offset, limit int) ([]*domain.ShiftAssignment, int64, error)
+	FindOverlappingAssignments(employeeCode string, from time.Time, to *time.Time) ([]*domain.ShiftAssignment, error)
+	FindActiveAssignment(employeeCode string, day time.Time) (*domain.ShiftAssignment, error)
+	FindAssignmentsBetween(employeeCodes []string, start, end time.Time) ([]*domain.ShiftAssignment, error)
+}
+
+type shiftRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewShiftRepository(db *gorm.DB, log *logrus.Logger) ShiftRepository {
+	return &shiftRepository{db: db, log: log}
+}
+
+func (r *shiftRepository) CreateShift(shift *domain.Shift) error {
+	return r.db.Create(shift).Error
+}
+
+func (r *shiftRepository) FindShiftByID(id uuid.UUID) (*domain.Shift, error) {
+	var shift domain.Shift
+	if err := r.db.First(&shift, id).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+// UpdateShift dan DeleteShift menandai attendance karyawan yang memakai shift untuk dihitung ulang
+func (r *shiftRepository) UpdateShift(shift *domain.Shift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(shift).Error; err != nil {
+			return err
+		}
+		return invalidatePunctuality(tx, shiftEmployees(tx, shift.ID))
+	})
+}
+
+func (r *shiftRepository) DeleteShift(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.Shift{}, id).Error; err != nil {
+			return err
+		}
+		return invalidatePunctuality(tx, shiftEmployees(tx, id))
+	})
+}
+
+func shiftEmployees(tx *gorm.DB, shiftID uuid.UUID) *gorm.DB {
+	return tx.Model(&domain.ShiftAssignment{}).Select("employee_code").Where("shift_id = ?", shiftID)
+}
+
+func (r *shiftRepository) FindAllShifts(offset, limit int) ([]*domain.Shift, int64, error) {
+	var shifts []*domain.Shift
+	var total int64
+	if err := r.db.Model(&domain.Shift{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := r.db.Order("start_time ASC").Offset(offset).Limit(limit).Find(&shifts).Error
+	return shifts, total, err
+}
+
+// CreateAssignment menyimpan assignment baru dan (opsional) menutup assignment
+// sebelumnya yang masih open-ended dalam satu transaksi.
+func (r *shiftRepository) CreateAssignment(assignment *domain.ShiftAssignment, closePrevious *domain.ShiftAssignment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if closePrevious != nil {
+			if err := tx.Model(closePrevious).Update("effective_to", closePrevious.EffectiveTo).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(assignment).Error; err != nil {
+			return err
+		}
+		return invalidatePunctuality(tx, []string{assignment.EmployeeCode})
+	})
+}
+
+func (r *shiftRepository) FindAssignmentByID(id uuid.UUID) (*domain.ShiftAssignment, error) {
+	var assignment domain.ShiftAssignment
+	if err := r.db.Preload("Shift").First(&assignment, id).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *shiftRepository) DeleteAssignment(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.ShiftAssignment{}, id).Error; err != nil {
+			return err
+		}
+		// Unscoped supaya assignment yang baru dihapus tetap ditemukan
+		return invalidatePunctuality(tx, tx.Unscoped().Model(&domain.ShiftAssignment{}).Select("employee_code").Where("id = ?", id))
+	})
+}
+
+func (r *shiftRepository) FindAssignmentsByEmployeeCode(employeeCode string, offset, limit int) ([]*domain.ShiftAssignment, int64, error) {
+	var assignments []*domain.ShiftAssignment
+	query := r.db.Model(&domain.ShiftAssignment{}).Where("employee_code = ?", employeeCode)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Shift").Order("effective_from DESC").Offset(offset).Limit(limit).Find(&assignments).Error
+	return assignments, total, err
+}
+
+func (r *shiftRepository) FindOverlappingAssignments(employeeCode string, from time.Time, to *time.Time) ([]*domain.ShiftAssignment, error) {
+	var assignments []*domain.ShiftAssignment
+	query := r.db.Where("employee_code = ?", employeeCode).
+		Where("effective_to IS NULL OR effective_to >= ?", from)
+	if to != nil {
+		query = query.Where("effective_from <= ?", *to)
+	}
+	err := query.Order("effective_from ASC").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *shiftRepository) FindActiveAssignment(employeeCode string, day time.Time) (*domain.ShiftAssignment, error) {
+	var assignment domain.ShiftAssignment
+	err := r.db.Preload("Shift").
+		Where("employee_code = ? AND effective_from <= ?", employeeCode, day).
+		Where("effective_to IS NULL OR effective_to >= ?", day).
+		Order("effective_from DESC").
+		First(&assignment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// FindAssignmentsBetween mengambil assignment (beserta shift) karyawan yang berlaku di sebagian
+// rentang [start, end], terbaru dulu seperti FindActiveAssignment
+func (r *shiftRepository) FindAssignmentsBetween(employeeCodes []string, start, end time.Time) ([]*domain.ShiftAssignment, error) {
+	var assignments []*domain.ShiftAssignment
+	if len(employeeCodes) == 0 {
+		return assignments, nil
+	}
+	err := r.db.Preload("Shift").
+		Where("employee_code IN ? AND effective_from <= ?", employeeCodes, end).
+		Where("effective_to IS NULL OR effective_to >= ?", start).
+		Order("effective_from DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
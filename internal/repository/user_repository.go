@@ -25,8 +25,10 @@ type UserRepository interface {
 	UpdateRefreshToken(token *domain.RefreshToken) error
 	UpdateUserProfile(profile *domain.UserProfile) error
 	FindUserProfileByUserID(userID uuid.UUID) (*domain.UserProfile, error)
+	FindUserProfileByEmployeeCode(employeeCode string) (*domain.UserProfile, error)
 	IsUserExist(userID uuid.UUID) (bool, error)
 	FindAllUsers(req dto.ListUsersRequest) ([]*domain.UserProfile, int64, error)
+	FindActiveProfilesWithDepartment() ([]*domain.UserProfile, error)
 
 	CountEmployeesPerDepartment() (map[string]int, error)
 	CountTodayRegistrations(today time.Time) (int, error)
@@ -212,6 +214,24 @@ func (r *userRepository) FindUserProfileByUserID(userID uuid.UUID) (*domain.User
 	return &profile, nil
 }
 
+func (r *userRepository) FindUserProfileByEmployeeCode(employeeCode string) (*domain.UserProfile, error) {
+	var profile domain.UserProfile
+	err := r.db.
+		Model(&domain.UserProfile{}).
+		Preload("Department").
+		Preload("ApplicationRole").
+		Where("employee_code = ?", employeeCode).
+		First(&profile).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
 func (r *userRepository) FindAllUsers(req dto.ListUsersRequest) ([]*domain.UserProfile, int64, error) {
 	var users []*domain.UserProfile
 
@@ -251,3 +271,14 @@ func (r *userRepository) FindAllUsers(req dto.ListUsersRequest) ([]*domain.UserP
 
 	return users, total, nil
 }
+
+// FindActiveProfilesWithDepartment mengambil semua profile user aktif yang sudah punya departemen
+func (r *userRepository) FindActiveProfilesWithDepartment() ([]*domain.UserProfile, error) {
+	var profiles []*domain.UserProfile
+	err := r.db.Model(&domain.UserProfile{}).
+		Preload("Department").
+		Joins("JOIN users u ON u.id = user_profiles.source_user_id").
+		Where("u.status = ? AND user_profiles.department_id IS NOT NULL", "active").
+		Find(&profiles).Error
+	return profiles, err
+}
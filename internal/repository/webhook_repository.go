@@ -0,0 +1,141 @@
+// webhook_repository.go
+package repository
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(subscription *domain.WebhookSubscription) error
+	FindSubscriptionByID(id uuid.UUID) (*domain.WebhookSubscription, error)
+	FindSubscriptions(offset, limit int) ([]*domain.WebhookSubscription, int64, error)
+	FindActiveSubscriptionsByEvent(eventType string) ([]*domain.WebhookSubscription, error)
+	UpdateSubscription(subscription *domain.WebhookSubscription) error
+	DeleteSubscription(id uuid.UUID) error
+
+	CreateDeliveries(deliveries []*domain.WebhookDelivery) error
+	FindDeliveryByID(id uuid.UUID) (*domain.WebhookDelivery, error)
+	FindDeliveries(subscriptionID uuid.UUID, status string, offset, limit int) ([]*domain.WebhookDelivery, int64, error)
+	FindFailedDeliveries(subscriptionID uuid.UUID) ([]*domain.WebhookDelivery, error)
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error)
+	UpdateDelivery(delivery *domain.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewWebhookRepository(db *gorm.DB, log *logrus.Logger) WebhookRepository {
+	return &webhookRepository{db: db, log: log}
+}
+
+func (r *webhookRepository) CreateSubscription(subscription *domain.WebhookSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+func (r *webhookRepository) FindSubscriptionByID(id uuid.UUID) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	if err := r.db.First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookRepository) FindSubscriptions(offset, limit int) ([]*domain.WebhookSubscription, int64, error) {
+	var subscriptions []*domain.WebhookSubscription
+	var total int64
+	if err := r.db.Model(&domain.WebhookSubscription{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := r.db.Order("created_at DESC").Offset(offset).Limit(limit).Find(&subscriptions).Error
+	return subscriptions, total, err
+}
+
+func (r *webhookRepository) FindActiveSubscriptionsByEvent(eventType string) ([]*domain.WebhookSubscription, error) {
+	var subscriptions []*domain.WebhookSubscription
+	err := r.db.Where("is_active AND event_types @> jsonb_build_array(?::text)", eventType).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *webhookRepository) UpdateSubscription(subscription *domain.WebhookSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+func (r *webhookRepository) DeleteSubscription(id uuid.UUID) error {
+	return r.db.Delete(&domain.WebhookSubscription{}, id).Error
+}
+
+func (r *webhookRepository) CreateDeliveries(deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Create(&deliveries).Error
+}
+
+func (r *webhookRepository) FindDeliveryByID(id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	if err := r.db.First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) FindDeliveries(subscriptionID uuid.UUID, status string, offset, limit int) ([]*domain.WebhookDelivery, int64, error) {
+	var deliveries []*domain.WebhookDelivery
+	query := r.db.Model(&domain.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+func (r *webhookRepository) FindFailedDeliveries(subscriptionID uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := r.db.Where("subscription_id = ? AND status = ?", subscriptionID, domain.WebhookDeliveryFailed).
+		Order("created_at ASC").Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDueDeliveries mengambil delivery pending yang sudah jatuh tempo dan menggeser next_attempt_at
+// sejauh lease, supaya instance lain (SKIP LOCKED) tidak mengirim delivery yang sama bersamaan.
+// Jika proses mati di tengah pengiriman, delivery dicoba lagi setelah lease habis.
+func (r *webhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Model(&domain.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC").Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Model(&domain.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Subscription").Where("id IN ?", ids).Find(&deliveries).Error
+	})
+	return deliveries, err
+}
+
+func (r *webhookRepository) UpdateDelivery(delivery *domain.WebhookDelivery) error {
+	return r.db.Omit("Subscription").Save(delivery).Error
+}
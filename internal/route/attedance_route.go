@@ -11,17 +11,26 @@ type AttendanceRouteConfig struct {
 	App                  *fiber.App
 	AttendanceController controller.AttendanceController
 	AuthMiddleware       *middleware.AuthMiddleware
+	Idempotency          *middleware.IdempotencyMiddleware
 }
 
 func (r *AttendanceRouteConfig) Setup() {
 	api := r.App.Group("/api/v1")
 	att := api.Group("/attendance")
-	att.Post("/clock-in", r.AuthMiddleware.Authenticate, r.AttendanceController.ClockIn)
-	att.Put("/clock-out", r.AuthMiddleware.Authenticate, r.AttendanceController.ClockOut)
+	att.Post("/clock-in", r.AuthMiddleware.Authenticate, r.Idempotency.Handle, r.AttendanceController.ClockIn)
+	att.Put("/clock-out", r.AuthMiddleware.Authenticate, r.Idempotency.Handle, r.AttendanceController.ClockOut)
+	att.Post("/break-start", r.AuthMiddleware.Authenticate, r.AttendanceController.BreakStart)
+	att.Put("/break-end", r.AuthMiddleware.Authenticate, r.AttendanceController.BreakEnd)
 	att.Get("/logs", r.AuthMiddleware.Authenticate, r.AttendanceController.GetAttendanceLogs)
+	att.Get("/logs/export", r.AuthMiddleware.Authenticate, r.AttendanceController.ExportAttendanceLogs)
 
 	att.Get("/history", r.AuthMiddleware.Authenticate, r.AttendanceController.GetAttendanceHistory)
+	att.Get("/history/:id/photo", r.AuthMiddleware.Authenticate, r.AttendanceController.GetAttendancePhoto) // Signed URL foto selfie
+	att.Get("/timesheet", r.AuthMiddleware.Authenticate, r.AttendanceController.GetTimesheet)
+	att.Get("/report", r.AuthMiddleware.Authenticate, r.AttendanceController.GetAttendanceReport)
 
 	att.Get("/admin", r.AuthMiddleware.Authenticate, r.AttendanceController.GetAdminDashboard)
 	att.Get("/current-status", r.AuthMiddleware.Authenticate, r.AttendanceController.CheckCurrentStatus)
+	att.Post("/feed/ticket", r.AuthMiddleware.Authenticate, r.AuthMiddleware.IssueStreamTicket)
+	att.Get("/feed", r.AuthMiddleware.AuthenticateStream, r.AttendanceController.StreamAttendanceFeed) // Server-Sent Events
 }
@@ -11,13 +11,14 @@ type RouteConfig struct {
 	App            *fiber.App
 	AuthController controller.AuthController
 	AuthMiddleware *middleware.AuthMiddleware
+	Idempotency    *middleware.IdempotencyMiddleware
 }
 
 func (r *RouteConfig) Setup() {
 	api := r.App.Group("/api/v1")
 
 	auth := api.Group("/auth")
-	auth.Post("/signup", r.AuthController.Signup)
+	auth.Post("/signup", r.Idempotency.Handle, r.AuthController.Signup)
 	auth.Post("/signin", r.AuthController.Signin)
 	auth.Post("/change-password", r.AuthMiddleware.Authenticate, r.AuthController.ChangePassword)
 	auth.Post("/refresh-token", r.AuthController.RefreshToken)
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BadgeRouteConfig struct {
+	App                   *fiber.App
+	BadgeController       controller.BadgeController
+	AuthMiddleware        *middleware.AuthMiddleware
+	BadgeReaderMiddleware *middleware.BadgeReaderMiddleware
+}
+
+func (r *BadgeRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	badges := api.Group("/badges")
+	badges.Post("", r.AuthMiddleware.Authenticate, r.BadgeController.CreateBadge)
+	badges.Get("", r.AuthMiddleware.Authenticate, r.BadgeController.GetBadges) // List
+	badges.Get("/me", r.AuthMiddleware.Authenticate, r.BadgeController.GetMyBadges)
+	badges.Post("/:id/deactivate", r.AuthMiddleware.Authenticate, r.BadgeController.DeactivateBadge)
+	badges.Post("/:id/report-lost", r.AuthMiddleware.Authenticate, r.BadgeController.ReportBadgeLost)
+	badges.Post("/:id/reactivate", r.AuthMiddleware.Authenticate, r.BadgeController.ReactivateBadge)
+	badges.Post("/:id/rotate-secret", r.AuthMiddleware.Authenticate, r.BadgeController.RotateBadgeSecret)
+
+	readers := api.Group("/badge-readers")
+	readers.Post("", r.AuthMiddleware.Authenticate, r.BadgeController.CreateReader)
+	readers.Get("", r.AuthMiddleware.Authenticate, r.BadgeController.GetReaders) // List
+	readers.Put("/:id", r.AuthMiddleware.Authenticate, r.BadgeController.UpdateReader)
+	readers.Delete("/:id", r.AuthMiddleware.Authenticate, r.BadgeController.DeleteReader)
+	readers.Post("/:id/rotate-key", r.AuthMiddleware.Authenticate, r.BadgeController.RotateReaderKey)
+
+	// Dipanggil reader RFID dengan header X-Reader-Key
+	reader := api.Group("/badge-reader")
+	reader.Post("/tap", r.BadgeReaderMiddleware.Authenticate, r.BadgeController.Tap)
+}
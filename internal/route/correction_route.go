@@ -0,0 +1,25 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CorrectionRouteConfig struct {
+	App                  *fiber.App
+	CorrectionController controller.CorrectionController
+	AuthMiddleware       *middleware.AuthMiddleware
+}
+
+func (r *CorrectionRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	correction := api.Group("/attendance/corrections")
+	correction.Post("", r.AuthMiddleware.Authenticate, r.CorrectionController.SubmitCorrection)
+	correction.Get("", r.AuthMiddleware.Authenticate, r.CorrectionController.ListCorrections) // List
+	correction.Get("/:id", r.AuthMiddleware.Authenticate, r.CorrectionController.GetCorrection)
+	correction.Post("/:id/approve", r.AuthMiddleware.Authenticate, r.CorrectionController.ApproveCorrection)
+	correction.Post("/:id/reject", r.AuthMiddleware.Authenticate, r.CorrectionController.RejectCorrection)
+	correction.Post("/:id/cancel", r.AuthMiddleware.Authenticate, r.CorrectionController.CancelCorrection)
+}
@@ -11,16 +11,19 @@ type DepartmentRouteConfig struct {
 	App                  *fiber.App
 	DepartmentController controller.DepartmentController
 	AuthMiddleware       *middleware.AuthMiddleware
+	Idempotency          *middleware.IdempotencyMiddleware
 }
 
 func (r *DepartmentRouteConfig) Setup() {
 	api := r.App.Group("/api/v1")
 	dept := api.Group("/departments")
-	dept.Post("", r.AuthMiddleware.Authenticate, r.DepartmentController.CreateDepartment)
+	dept.Post("", r.AuthMiddleware.Authenticate, r.Idempotency.Handle, r.DepartmentController.CreateDepartment)
 	dept.Get("/:id", r.AuthMiddleware.Authenticate, r.DepartmentController.GetDepartment)
 	dept.Put("/:id", r.AuthMiddleware.Authenticate, r.DepartmentController.UpdateDepartment)
 	dept.Delete("/:id", r.AuthMiddleware.Authenticate, r.DepartmentController.DeleteDepartment)
 	dept.Get("", r.AuthMiddleware.Authenticate, r.DepartmentController.GetDepartments) // List
-	dept.Post("/assignment", r.AuthMiddleware.Authenticate, r.DepartmentController.AssignmentDepartement)
+	dept.Post("/assignment", r.AuthMiddleware.Authenticate, r.Idempotency.Handle, r.DepartmentController.AssignmentDepartement)
+	dept.Get("/:id/attendance-modes", r.AuthMiddleware.Authenticate, r.DepartmentController.GetAttendanceModeRules)
+	dept.Put("/:id/attendance-modes", r.AuthMiddleware.Authenticate, r.DepartmentController.SetAttendanceModeRules)
 
 }
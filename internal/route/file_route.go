@@ -0,0 +1,18 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FileRouteConfig struct {
+	App            *fiber.App
+	FileController controller.FileController
+}
+
+func (r *FileRouteConfig) Setup() {
+	// Tanpa JWT: URL sudah ditandatangani dan punya masa berlaku
+	r.App.Get(storage.LocalFilesPath, r.FileController.ServeSignedFile)
+}
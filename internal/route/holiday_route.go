@@ -0,0 +1,26 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HolidayRouteConfig struct {
+	App               *fiber.App
+	HolidayController controller.HolidayController
+	AuthMiddleware    *middleware.AuthMiddleware
+}
+
+func (r *HolidayRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	holiday := api.Group("/holidays")
+	holiday.Post("/import", r.AuthMiddleware.Authenticate, r.HolidayController.ImportHolidays)
+
+	holiday.Post("", r.AuthMiddleware.Authenticate, r.HolidayController.CreateHoliday)
+	holiday.Get("/:id", r.AuthMiddleware.Authenticate, r.HolidayController.GetHoliday)
+	holiday.Put("/:id", r.AuthMiddleware.Authenticate, r.HolidayController.UpdateHoliday)
+	holiday.Delete("/:id", r.AuthMiddleware.Authenticate, r.HolidayController.DeleteHoliday)
+	holiday.Get("", r.AuthMiddleware.Authenticate, r.HolidayController.GetHolidays) // List
+}
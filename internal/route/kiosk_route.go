@@ -0,0 +1,34 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type KioskRouteConfig struct {
+	App             *fiber.App
+	KioskController controller.KioskController
+	AuthMiddleware  *middleware.AuthMiddleware
+	KioskMiddleware *middleware.KioskMiddleware
+}
+
+func (r *KioskRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	kiosks := api.Group("/kiosks")
+	kiosks.Post("", r.AuthMiddleware.Authenticate, r.KioskController.CreateKiosk)
+	kiosks.Get("", r.AuthMiddleware.Authenticate, r.KioskController.GetKiosks) // List
+	kiosks.Put("/:id", r.AuthMiddleware.Authenticate, r.KioskController.UpdateKiosk)
+	kiosks.Delete("/:id", r.AuthMiddleware.Authenticate, r.KioskController.DeleteKiosk)
+	kiosks.Post("/:id/rotate-key", r.AuthMiddleware.Authenticate, r.KioskController.RotateKioskKey)
+
+	// Dipanggil perangkat kiosk dengan header X-Kiosk-Key
+	kiosk := api.Group("/kiosk")
+	kiosk.Get("/qr", r.KioskMiddleware.Authenticate, r.KioskController.GetQRCode)
+	kiosk.Post("/badge-scan", r.KioskMiddleware.Authenticate, r.KioskController.ScanBadge)
+
+	// Dipanggil karyawan dengan JWT
+	kiosk.Post("/scan", r.AuthMiddleware.Authenticate, r.KioskController.ScanKioskQR)
+	kiosk.Get("/badge", r.AuthMiddleware.Authenticate, r.KioskController.GetBadge)
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LeaveRouteConfig struct {
+	App             *fiber.App
+	LeaveController controller.LeaveController
+	AuthMiddleware  *middleware.AuthMiddleware
+}
+
+func (r *LeaveRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	leave := api.Group("/leaves")
+	leave.Get("/types", r.AuthMiddleware.Authenticate, r.LeaveController.GetLeaveTypes)
+	leave.Post("/types", r.AuthMiddleware.Authenticate, r.LeaveController.CreateLeaveType)
+	leave.Put("/types/:id", r.AuthMiddleware.Authenticate, r.LeaveController.UpdateLeaveType)
+	leave.Delete("/types/:id", r.AuthMiddleware.Authenticate, r.LeaveController.DeleteLeaveType)
+
+	leave.Get("/balances", r.AuthMiddleware.Authenticate, r.LeaveController.GetLeaveBalances)
+	leave.Put("/balances", r.AuthMiddleware.Authenticate, r.LeaveController.SetLeaveBalance)
+
+	leave.Post("", r.AuthMiddleware.Authenticate, r.LeaveController.SubmitLeaveRequest)
+	leave.Get("", r.AuthMiddleware.Authenticate, r.LeaveController.ListLeaveRequests) // List
+	leave.Get("/:id", r.AuthMiddleware.Authenticate, r.LeaveController.GetLeaveRequest)
+	leave.Post("/:id/approve", r.AuthMiddleware.Authenticate, r.LeaveController.ApproveLeaveRequest)
+	leave.Post("/:id/reject", r.AuthMiddleware.Authenticate, r.LeaveController.RejectLeaveRequest)
+	leave.Post("/:id/cancel", r.AuthMiddleware.Authenticate, r.LeaveController.CancelLeaveRequest)
+}
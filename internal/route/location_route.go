@@ -0,0 +1,24 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LocationRouteConfig struct {
+	App                *fiber.App
+	LocationController controller.LocationController
+	AuthMiddleware     *middleware.AuthMiddleware
+}
+
+func (r *LocationRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	location := api.Group("/office-locations")
+	location.Post("", r.AuthMiddleware.Authenticate, r.LocationController.CreateLocation)
+	location.Get("/:id", r.AuthMiddleware.Authenticate, r.LocationController.GetLocation)
+	location.Put("/:id", r.AuthMiddleware.Authenticate, r.LocationController.UpdateLocation)
+	location.Delete("/:id", r.AuthMiddleware.Authenticate, r.LocationController.DeleteLocation)
+	location.Get("", r.AuthMiddleware.Authenticate, r.LocationController.GetLocations) // List
+}
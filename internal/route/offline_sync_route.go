@@ -0,0 +1,19 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OfflineSyncRouteConfig struct {
+	App                   *fiber.App
+	OfflineSyncController controller.OfflineSyncController
+	AuthMiddleware        *middleware.AuthMiddleware
+}
+
+func (r *OfflineSyncRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	api.Post("/attendance/sync", r.AuthMiddleware.Authenticate, r.OfflineSyncController.SyncClockEvents) // Batch event offline
+}
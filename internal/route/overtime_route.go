@@ -0,0 +1,26 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OvertimeRouteConfig struct {
+	App                *fiber.App
+	OvertimeController controller.OvertimeController
+	AuthMiddleware     *middleware.AuthMiddleware
+}
+
+func (r *OvertimeRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	overtime := api.Group("/overtime")
+	overtime.Post("", r.AuthMiddleware.Authenticate, r.OvertimeController.SubmitOvertime)
+	overtime.Get("", r.AuthMiddleware.Authenticate, r.OvertimeController.ListOvertime) // List
+	overtime.Get("/summary", r.AuthMiddleware.Authenticate, r.OvertimeController.GetOvertimeSummary)
+	overtime.Get("/:id", r.AuthMiddleware.Authenticate, r.OvertimeController.GetOvertime)
+	overtime.Post("/:id/approve", r.AuthMiddleware.Authenticate, r.OvertimeController.ApproveOvertime)
+	overtime.Post("/:id/reject", r.AuthMiddleware.Authenticate, r.OvertimeController.RejectOvertime)
+	overtime.Post("/:id/cancel", r.AuthMiddleware.Authenticate, r.OvertimeController.CancelOvertime)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PayrollRouteConfig struct {
+	App               *fiber.App
+	PayrollController controller.PayrollController
+	AuthMiddleware    *middleware.AuthMiddleware
+}
+
+func (r *PayrollRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	payroll := api.Group("/payroll")
+	payroll.Get("/export", r.AuthMiddleware.Authenticate, r.PayrollController.ExportPayroll)
+	payroll.Post("/export", r.AuthMiddleware.Authenticate, r.PayrollController.LockAndExportPayroll) // Lock lalu export
+	payroll.Post("/periods", r.AuthMiddleware.Authenticate, r.PayrollController.LockPayPeriod)
+	payroll.Get("/periods", r.AuthMiddleware.Authenticate, r.PayrollController.ListPayPeriods) // List
+	payroll.Post("/periods/:id/unlock", r.AuthMiddleware.Authenticate, r.PayrollController.UnlockPayPeriod)
+	payroll.Post("/periods/:id/lock", r.AuthMiddleware.Authenticate, r.PayrollController.RelockPayPeriod)
+}
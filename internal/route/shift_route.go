@@ -0,0 +1,28 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ShiftRouteConfig struct {
+	App             *fiber.App
+	ShiftController controller.ShiftController
+	AuthMiddleware  *middleware.AuthMiddleware
+}
+
+func (r *ShiftRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	shift := api.Group("/shifts")
+	shift.Post("/assignments", r.AuthMiddleware.Authenticate, r.ShiftController.AssignShift)
+	shift.Get("/assignments", r.AuthMiddleware.Authenticate, r.ShiftController.GetShiftAssignments)
+	shift.Delete("/assignments/:id", r.AuthMiddleware.Authenticate, r.ShiftController.DeleteShiftAssignment)
+
+	shift.Post("", r.AuthMiddleware.Authenticate, r.ShiftController.CreateShift)
+	shift.Get("/:id", r.AuthMiddleware.Authenticate, r.ShiftController.GetShift)
+	shift.Put("/:id", r.AuthMiddleware.Authenticate, r.ShiftController.UpdateShift)
+	shift.Delete("/:id", r.AuthMiddleware.Authenticate, r.ShiftController.DeleteShift)
+	shift.Get("", r.AuthMiddleware.Authenticate, r.ShiftController.GetShifts) // List
+}
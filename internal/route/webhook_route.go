@@ -0,0 +1,26 @@
+package routes
+
+import (
+	controller "employee-attendance-system/internal/controllers"
+	"employee-attendance-system/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookRouteConfig struct {
+	App               *fiber.App
+	WebhookController controller.WebhookController
+	AuthMiddleware    *middleware.AuthMiddleware
+}
+
+func (r *WebhookRouteConfig) Setup() {
+	api := r.App.Group("/api/v1")
+	webhooks := api.Group("/webhooks")
+	webhooks.Post("", r.AuthMiddleware.Authenticate, r.WebhookController.CreateWebhook)
+	webhooks.Get("", r.AuthMiddleware.Authenticate, r.WebhookController.GetWebhooks) // List
+	webhooks.Post("/deliveries/:id/replay", r.AuthMiddleware.Authenticate, r.WebhookController.ReplayDelivery)
+	webhooks.Put("/:id", r.AuthMiddleware.Authenticate, r.WebhookController.UpdateWebhook)
+	webhooks.Delete("/:id", r.AuthMiddleware.Authenticate, r.WebhookController.DeleteWebhook)
+	webhooks.Get("/:id/deliveries", r.AuthMiddleware.Authenticate, r.WebhookController.GetDeliveries)
+	webhooks.Post("/:id/replay", r.AuthMiddleware.Authenticate, r.WebhookController.ReplayFailedDeliveries)
+}
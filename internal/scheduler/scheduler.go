@@ -0,0 +1,90 @@
+// scheduler.go
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JobFunc adalah pekerjaan background yang dijalankan berkala. now adalah waktu tick.
+type JobFunc func(ctx context.Context, now time.Time) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Scheduler menjalankan job berkala di dalam proses server. Setiap job punya
+// goroutine sendiri dan langsung dijalankan sekali saat Start.
+type Scheduler struct {
+	jobs   []job
+	log    *logrus.Logger
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(log *logrus.Logger) *Scheduler {
+	return &Scheduler{log: log}
+}
+
+// Every mendaftarkan job yang dijalankan setiap interval. Harus dipanggil sebelum Start.
+func (s *Scheduler) Every(name string, interval time.Duration, run JobFunc) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+	s.log.WithField("jobs", len(s.jobs)).Info("Scheduler started")
+}
+
+// Stop menghentikan semua job dan menunggu job yang sedang berjalan selesai
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	s.runOnce(ctx, j, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.runOnce(ctx, j, now)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, j job, now time.Time) {
+	// Panic di satu job tidak boleh mematikan server
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.WithField("job", j.name).Errorf("Scheduled job panicked: %v", r)
+		}
+	}()
+
+	start := time.Now()
+	if err := j.run(ctx, now); err != nil {
+		s.log.WithField("job", j.name).WithError(err).Error("Scheduled job failed")
+		return
+	}
+	s.log.WithFields(logrus.Fields{
+		"job":      j.name,
+		"duration": time.Since(start).String(),
+	}).Debug("Scheduled job finished")
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalFilesPath adalah route yang melayani file LocalStorage lewat signed URL
+const LocalFilesPath = "/api/v1/files"
+
+// LocalStorage menyimpan file di filesystem server. Signed URL mengarah ke LocalFilesPath
+// dengan query expires & signature (HMAC-SHA256 dari key + expires).
+type LocalStorage struct {
+	root    string
+	baseURL string
+	secret  []byte
+}
+
+func NewLocalStorage(root, baseURL, secret string) *LocalStorage {
+	return &LocalStorage{root: root, baseURL: strings.TrimRight(baseURL, "/"), secret: []byte(secret)}
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	// Tulis ke file sementara lalu rename supaya file setengah jadi tidak pernah terbaca
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, time.Time, error) {
+	if _, err := s.path(key); err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+	return s.baseURL + LocalFilesPath + "?" + query.Encode(), expiresAt, nil
+}
+
+// Verify memeriksa query dari signed URL sebelum file dilayani
+func (s *LocalStorage) Verify(key, expires, signature string, now time.Time) error {
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature")
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.mac(key, expires)) {
+		return fmt.Errorf("invalid signature")
+	}
+	if now.Unix() > expiresUnix {
+		return fmt.Errorf("url has expired")
+	}
+	return nil
+}
+
+func (s *LocalStorage) sign(key, expires string) string {
+	return hex.EncodeToString(s.mac(key, expires))
+}
+
+func (s *LocalStorage) mac(key, expires string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return mac.Sum(nil)
+}
+
+// path memetakan key ke path di bawah root dan menolak key yang keluar dari root
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == "." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) || clean == ".." {
+		return "", fmt.Errorf("invalid storage key")
+	}
+	return filepath.Join(s.root, clean), nil
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	utils "employee-attendance-system/internal/util"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Storage menyimpan file upload (mis. foto selfie clock in). Key memakai "/" sebagai pemisah
+// dan dibuat oleh server, bukan dari nama file client.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL mengembalikan URL sementara untuk mengunduh file tanpa JWT
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, time.Time, error)
+}
+
+var ErrNotFound = fmt.Errorf("file not found")
+
+// NewStorage memilih implementasi dari `storage.driver`; saat ini hanya "local"
+func NewStorage(config *viper.Viper, log *logrus.Logger) Storage {
+	switch driver := config.GetString("storage.driver"); driver {
+	case "", "local":
+		root := config.GetString("storage.localPath")
+		if root == "" {
+			root = "./storage"
+		}
+		// Tidak jatuh ke secret JWT: signed URL yang bocor tidak boleh membantu memalsukan token
+		secret := config.GetString("storage.signingSecret")
+		if !utils.IsUsableSecret(secret) {
+			log.Fatal("storage.signingSecret must be set to a random value")
+		}
+		return NewLocalStorage(root, config.GetString("storage.publicBaseURL"), secret)
+	default:
+		log.Fatalf("unsupported storage driver: %s", driver)
+		return nil
+	}
+}
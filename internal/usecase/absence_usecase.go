@@ -0,0 +1,134 @@
+// absence_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// absenceJobName adalah nama job di job_runs; sama dengan nama job di scheduler
+const absenceJobName = "absence-detection"
+
+// absenceDefaultBackfillDays membatasi berapa hari ke belakang job boleh mengejar hari yang terlewat
+const absenceDefaultBackfillDays = 7
+
+// AbsenceUseCase menandai karyawan yang tidak clock in sama sekali sebagai absent
+type AbsenceUseCase interface {
+	DetectAbsences(ctx context.Context, now time.Time) (int64, error)
+}
+
+type absenceUseCase struct {
+	repo         repository.AttendanceRepository
+	profileRepo  repository.UserRepository
+	jobRunRepo   repository.JobRunRepository
+	calendar     *workCalendar
+	periods      payPeriodGuard
+	backfillDays int
+	log          *logrus.Logger
+}
+
+func NewAbsenceUseCase(repo repository.AttendanceRepository, profileRepo repository.UserRepository, shiftRepo repository.ShiftRepository, holidayRepo repository.HolidayRepository, payrollRepo repository.PayrollRepository, jobRunRepo repository.JobRunRepository, config *viper.Viper, log *logrus.Logger) AbsenceUseCase {
+	backfillDays := config.GetInt("scheduler.absenceBackfillDays")
+	if backfillDays <= 0 {
+		backfillDays = absenceDefaultBackfillDays
+	}
+	return &absenceUseCase{repo: repo, profileRepo: profileRepo, jobRunRepo: jobRunRepo, calendar: newWorkCalendar(shiftRepo, holidayRepo),
+		periods: payPeriodGuard{repo: payrollRepo}, backfillDays: backfillDays, log: log}
+}
+
+// DetectAbsences memeriksa hari kerja sejak run sukses terakhir (paling jauh backfillDays ke
+// belakang) sampai hari ini untuk setiap karyawan aktif, sehingga hari yang terlewat saat server
+// mati tetap diperiksa. Hari kerja yang jam pulangnya (cutoff) sudah lewat dan belum punya
+// attendance sama sekali dibuatkan record "absent". Hari libur, hari non-kerja shift, dan cuti
+// (sudah punya record on_leave) dilewati, sehingga job aman dijalankan berulang kali. Hari di
+// pay period terkunci juga dilewati.
+func (u *absenceUseCase) DetectAbsences(ctx context.Context, now time.Time) (int64, error) {
+	profiles, err := u.profileRepo.FindActiveProfilesWithDepartment()
+	if err != nil {
+		return 0, err
+	}
+
+	today := dateOf(now)
+	start, err := u.backfillStart(today)
+	if err != nil {
+		return 0, err
+	}
+	var days []time.Time
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if err := u.periods.check(day); err != nil {
+			u.log.WithField("work_date", day.Format(dateLayout)).WithError(err).Debug("Skipping absence check")
+			continue
+		}
+		days = append(days, day)
+	}
+
+	// Libur dan shift semua karyawan untuk seluruh rentang dimuat sekali
+	calendar, err := u.calendar.Load(profiles, start, today)
+	if err != nil {
+		return 0, err
+	}
+
+	var absences []*domain.Attendance
+	for _, profile := range profiles {
+		joined := joinedDate(profile, now.Location())
+		for _, day := range days {
+			if day.Before(joined) || !calendar.IsWorkingDay(profile, day) {
+				continue
+			}
+
+			schedule, err := calendar.Schedule(profile, day)
+			if err != nil {
+				u.log.WithField("employee_code", profile.EmployeeCode).WithError(err).Warn("Skipping absence check")
+				continue
+			}
+			if !schedule.Scheduled || now.Before(schedule.TargetOut(day)) {
+				continue
+			}
+
+			workDate := day
+			absences = append(absences, &domain.Attendance{
+				EmployeeCode: profile.EmployeeCode,
+				AttendanceID: attendanceIDFor(profile.EmployeeCode, workDate),
+				WorkDate:     &workDate,
+				Status:       domain.AttendanceStatusAbsent,
+			})
+		}
+	}
+
+	created, err := u.repo.CreateMissingAttendances(absences)
+	if err != nil {
+		return 0, err
+	}
+	if created > 0 {
+		u.log.WithField("count", created).Info("Marked employees as absent")
+	}
+	if err := u.jobRunRepo.SaveSuccess(absenceJobName, now); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// backfillStart adalah hari pertama yang diperiksa: sehari sebelum run sukses terakhir, karena
+// cutoff shift malam hari itu bisa jatuh setelah run tersebut. Tanpa riwayat run hanya kemarin
+// yang diperiksa, dan tidak pernah lebih jauh dari backfillDays.
+func (u *absenceUseCase) backfillStart(today time.Time) (time.Time, error) {
+	start := today.AddDate(0, 0, -1)
+	last, err := u.jobRunRepo.FindLastSuccess(absenceJobName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if last != nil {
+		if since := dateOf(last.In(today.Location())).AddDate(0, 0, -1); since.Before(start) {
+			start = since
+		}
+	}
+	if earliest := today.AddDate(0, 0, -u.backfillDays); start.Before(earliest) {
+		start = earliest
+	}
+	return start, nil
+}
@@ -5,8 +5,13 @@ import (
 	"context"
 	"employee-attendance-system/internal/entity/domain"
 	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/event"
 	"employee-attendance-system/internal/repository"
+	"employee-attendance-system/internal/storage"
+	utils "employee-attendance-system/internal/util"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -16,28 +21,124 @@ import (
 )
 
 type AttendanceUseCase interface {
-	ClockIn(ctx context.Context, userID uuid.UUID) (*dto.AttendanceResponse, error)
-	ClockOut(ctx context.Context, userID uuid.UUID) (*dto.AttendanceResponse, error)
+	ClockIn(ctx context.Context, userID uuid.UUID, req dto.ClockInRequest) (*dto.AttendanceResponse, error)
+	ClockOut(ctx context.Context, userID uuid.UUID, req dto.ClockOutRequest) (*dto.AttendanceResponse, error)
+	BreakStart(ctx context.Context, userID uuid.UUID, req dto.BreakStartRequest) (*dto.AttendanceBreakResponse, error)
+	BreakEnd(ctx context.Context, userID uuid.UUID) (*dto.AttendanceBreakResponse, error)
 	GetAttendanceLogs(ctx context.Context, userID uuid.UUID, role string, req dto.GetAttendanceLogsRequest) ([]dto.AttendanceLogResponse, int64, error)
 	CheckCurrentStatus(ctx context.Context, userID uuid.UUID) (*dto.CurrentStatusResponse, error)
+	GetTimesheet(ctx context.Context, userID uuid.UUID, req dto.GetTimesheetRequest) (*dto.TimesheetResponse, error)
+	ExportAttendanceLogs(ctx context.Context, userID uuid.UUID, role string, req dto.GetAttendanceLogsRequest) (func(w utils.TableWriter) error, error)
+	GetAttendanceReport(ctx context.Context, userID uuid.UUID, role string, req dto.AttendanceReportRequest) (*dto.AttendanceReportResponse, error)
+	RenderAttendanceReportPDF(report *dto.AttendanceReportResponse) ([]byte, error)
 	GetAdminDashboard(ctx context.Context, req dto.AdminDashboardRequest) (*dto.AdminDashboardResponse, error)
 	GetAttendanceHistory(ctx context.Context, req dto.GetAttendanceHistoryRequest) ([]*dto.AttendanceHistoryResponse, int64, error)
+	SubscribeAttendanceFeed(ctx context.Context, userID uuid.UUID, role string, req dto.AttendanceFeedRequest) (*event.Subscription, error)
+	GetAttendancePhotoURL(ctx context.Context, historyID uuid.UUID) (*dto.AttendancePhotoURLResponse, error)
 }
 
 type attendanceUseCase struct {
-	repo        repository.AttendanceRepository
-	profileRepo repository.UserRepository // Untuk get employee code
-	deptRepo    repository.DepartmentRepository
-	log         *logrus.Logger
-	validate    *validator.Validate
+	repo         repository.AttendanceRepository
+	profileRepo  repository.UserRepository // Untuk get employee code
+	deptRepo     repository.DepartmentRepository
+	shiftRepo    repository.ShiftRepository
+	locationRepo repository.LocationRepository
+	calendar     *workCalendar
+	periods      payPeriodGuard
+	events       *event.Broker
+	webhooks     WebhookPublisher
+	files        storage.Storage // Foto selfie clock in/out
+	log          *logrus.Logger
+	validate     *validator.Validate
 }
 
-func NewAttendanceUseCase(repo repository.AttendanceRepository, profileRepo repository.UserRepository, deptRepo repository.DepartmentRepository, log *logrus.Logger, validate *validator.Validate) AttendanceUseCase {
+func NewAttendanceUseCase(repo repository.AttendanceRepository, profileRepo repository.UserRepository, deptRepo repository.DepartmentRepository, shiftRepo repository.ShiftRepository, holidayRepo repository.HolidayRepository, locationRepo repository.LocationRepository, payrollRepo repository.PayrollRepository, events *event.Broker, webhooks WebhookPublisher, files storage.Storage, log *logrus.Logger, validate *validator.Validate) AttendanceUseCase {
 	return &attendanceUseCase{repo: repo, profileRepo: profileRepo,
-		deptRepo: deptRepo, log: log, validate: validate}
+		deptRepo: deptRepo, shiftRepo: shiftRepo, locationRepo: locationRepo, calendar: newWorkCalendar(shiftRepo, holidayRepo), periods: payPeriodGuard{repo: payrollRepo}, events: events, webhooks: webhooks, files: files, log: log, validate: validate}
 
 }
 
+// resolveWorkDate menentukan hari kerja untuk clock in pada waktu now. Clock in
+// setelah tengah malam tapi sebelum jam pulang shift malam kemarin dianggap
+// bagian dari hari kerja kemarin.
+func (u *attendanceUseCase) resolveWorkDate(profile *domain.UserProfile, now time.Time) (time.Time, error) {
+	today := dateOf(now)
+	yesterday := today.AddDate(0, 0, -1)
+
+	schedule, err := u.calendar.Schedule(profile, yesterday)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if schedule.Scheduled && schedule.Overnight() && now.Before(schedule.TargetOut(yesterday)) {
+		return yesterday, nil
+	}
+	return today, nil
+}
+
+// checkAttendanceMode memastikan mode yang dipilih diizinkan departemen pada hari kerja
+// tersebut. Mode office selalu diizinkan; departemen tanpa rule hanya boleh office.
+func (u *attendanceUseCase) checkAttendanceMode(profile *domain.UserProfile, mode domain.AttendanceMode, workDate time.Time) error {
+	if mode == domain.AttendanceModeOffice || profile.DepartmentID == nil {
+		return nil
+	}
+	rules, err := u.deptRepo.FindModeRulesByDepartmentID(*profile.DepartmentID)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.Mode != mode {
+			continue
+		}
+		if rule.AppliesOn(workDate.Weekday()) {
+			return nil
+		}
+		return fmt.Errorf("attendance mode %s is not allowed on %s for your department", mode, workDate.Weekday())
+	}
+	return fmt.Errorf("attendance mode %s is not allowed for your department", mode)
+}
+
+// checkGeofence memastikan koordinat device berada di salah satu office location
+// departemen karyawan. Departemen tanpa office location tidak dibatasi lokasinya.
+func (u *attendanceUseCase) checkGeofence(profile *domain.UserProfile, latitude, longitude, accuracy *float64) (*domain.OfficeLocation, error) {
+	if profile.DepartmentID == nil {
+		return nil, nil
+	}
+	locations, err := u.locationRepo.FindLocationsByDepartmentID(*profile.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, nil
+	}
+	if latitude == nil || longitude == nil {
+		return nil, fmt.Errorf("location is required for your department")
+	}
+
+	var nearest *domain.OfficeLocation
+	nearestDistance := math.MaxFloat64
+	inaccurate := false
+	for _, location := range locations {
+		distance := utils.HaversineDistance(*latitude, *longitude, location.Latitude, location.Longitude)
+		if distance <= float64(location.RadiusMeters) {
+			// Titik di dalam radius tapi akurasi GPS lebih besar dari radius belum bisa dipercaya
+			if accuracy != nil && *accuracy > float64(location.RadiusMeters) {
+				inaccurate = true
+				continue
+			}
+			return location, nil
+		}
+		if distance < nearestDistance {
+			nearest, nearestDistance = location, distance
+		}
+	}
+
+	if inaccurate {
+		return nil, fmt.Errorf("location accuracy of %.0fm is too low, please retry with a better GPS signal", *accuracy)
+	}
+	return nil, fmt.Errorf("you are outside the allowed office area: %.0fm from %s (radius %dm)",
+		nearestDistance, nearest.Name, nearest.RadiusMeters)
+}
+
 func (u *attendanceUseCase) GetAdminDashboard(ctx context.Context, req dto.AdminDashboardRequest) (*dto.AdminDashboardResponse, error) {
 	// Set default date range if not provided
 	now := time.Now()
@@ -53,6 +154,9 @@ func (u *attendanceUseCase) GetAdminDashboard(ctx context.Context, req dto.Admin
 	if endDate.Before(startDate) {
 		return nil, fmt.Errorf("end_date cannot be before start_date")
 	}
+	if endDate.Sub(startDate) >= maxReportDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must not exceed %d days", maxReportDays)
+	}
 
 	// 1. Total Employees per Department
 	employeesPerDept, err := u.profileRepo.CountEmployeesPerDepartment()
@@ -72,11 +176,58 @@ func (u *attendanceUseCase) GetAdminDashboard(ctx context.Context, req dto.Admin
 		return nil, err
 	}
 
-	return &dto.AdminDashboardResponse{
+	dashboard := &dto.AdminDashboardResponse{
 		TotalEmployeesPerDept:   employeesPerDept,
 		TotalUpdatedDepts:       updatedDepts,
 		TotalTodayRegistrations: todayRegistrations,
-	}, nil
+		StartDate:               startDate.Format(dateLayout),
+		EndDate:                 endDate.Format(dateLayout),
+	}
+
+	// 4. KPI attendance, semuanya agregasi di database
+	stats, err := u.repo.GetAttendanceStats(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.Attendance = *stats
+	if dashboard.Departments, err = u.repo.GetDepartmentAttendanceStats(startDate, endDate); err != nil {
+		return nil, err
+	}
+	if dashboard.TopLateEmployees, err = u.repo.GetTopLateEmployees(startDate, endDate, dashboardTopLateLimit); err != nil {
+		return nil, err
+	}
+	trend, err := u.repo.GetDailyAttendanceTrend(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.DailyTrend = fillDailyTrend(trend, startDate, endDate)
+
+	// 5. Karyawan yang sedang clock in (termasuk shift malam dari kemarin)
+	if dashboard.ClockedInNow, err = u.repo.CountClockedIn(now.Add(-maxShiftDuration)); err != nil {
+		return nil, err
+	}
+	return dashboard, nil
+}
+
+// dashboardTopLateLimit adalah jumlah karyawan di daftar paling sering terlambat
+const dashboardTopLateLimit = 10
+
+// fillDailyTrend melengkapi tanggal tanpa attendance dengan nilai nol supaya series grafik kontinu
+func fillDailyTrend(trend []dto.DashboardTrendPoint, start, end time.Time) []dto.DashboardTrendPoint {
+	byDate := make(map[string]dto.DashboardTrendPoint, len(trend))
+	for _, point := range trend {
+		byDate[point.Date] = point
+	}
+	res := make([]dto.DashboardTrendPoint, 0, len(trend))
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format(dateLayout)
+		point, ok := byDate[key]
+		if !ok {
+			point = dto.DashboardTrendPoint{Date: key}
+		}
+		res = append(res, point)
+	}
+	return res
 }
 
 func (u *attendanceUseCase) GetAttendanceHistory(ctx context.Context, req dto.GetAttendanceHistoryRequest) ([]*dto.AttendanceHistoryResponse, int64, error) {
@@ -100,18 +251,27 @@ func (u *attendanceUseCase) GetAttendanceHistory(ctx context.Context, req dto.Ge
 
 func mapToAttendanceHistoryResponse(h *domain.AttendanceHistory) *dto.AttendanceHistoryResponse {
 	return &dto.AttendanceHistoryResponse{
-		ID:             h.ID,
-		EmployeeCode:   h.EmployeeCode,
-		AttendanceID:   h.AttendanceID,
-		DateAttendance: h.DateAttendance,
-		AttendanceType: string(h.AttendanceType),
-		Description:    h.Description,
-		CreatedAt:      h.CreatedAt,
-		UpdatedAt:      h.UpdatedAt,
+		ID:               h.ID,
+		EmployeeCode:     h.EmployeeCode,
+		AttendanceID:     h.AttendanceID,
+		DateAttendance:   h.DateAttendance,
+		AttendanceType:   string(h.AttendanceType),
+		Description:      h.Description,
+		Actor:            string(h.Actor),
+		ActorUserID:      h.ActorUserID,
+		Latitude:         h.Latitude,
+		Longitude:        h.Longitude,
+		LocationAccuracy: h.LocationAccuracy,
+		OfficeLocationID: h.OfficeLocationID,
+		KioskID:          h.KioskID,
+		ReaderID:         h.ReaderID,
+		HasPhoto:         h.PhotoKey != "",
+		CreatedAt:        h.CreatedAt,
+		UpdatedAt:        h.UpdatedAt,
 	}
 }
 
-func (u *attendanceUseCase) ClockIn(ctx context.Context, userID uuid.UUID) (*dto.AttendanceResponse, error) {
+func (u *attendanceUseCase) ClockIn(ctx context.Context, userID uuid.UUID, req dto.ClockInRequest) (*dto.AttendanceResponse, error) {
 	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
 	if err != nil || profile == nil {
 		return nil, fmt.Errorf("profile not found")
@@ -120,73 +280,350 @@ func (u *attendanceUseCase) ClockIn(ctx context.Context, userID uuid.UUID) (*dto
 		return nil, fmt.Errorf("no department assigned")
 	}
 
-	now := time.Now()
-	today := now.Format("2006-01-02")
-	attendanceID := fmt.Sprintf("%s-%s", profile.EmployeeCode, today)
+	now := req.Source.Time()
+	workDate, err := u.resolveWorkDate(profile, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.periods.check(workDate); err != nil {
+		return nil, err
+	}
+	attendanceID := attendanceIDFor(profile.EmployeeCode, workDate)
 
 	var attendance domain.Attendance
+	markedAbsent := false
 	if err := u.repo.FindAttendanceByID(attendanceID, &attendance); err == nil {
-		return nil, fmt.Errorf("already clocked in today")
+		switch attendance.Status {
+		case domain.AttendanceStatusOnLeave:
+			return nil, fmt.Errorf("you are on leave today")
+		case domain.AttendanceStatusAbsent:
+			// Sudah ditandai absent oleh job, clock in terlambat tetap dicatat
+			markedAbsent = true
+		default:
+			return nil, fmt.Errorf("already clocked in today")
+		}
 	} else if err != gorm.ErrRecordNotFound {
 		return nil, err
 	}
 
-	attendance = domain.Attendance{
-		EmployeeCode: profile.EmployeeCode,
-		AttendanceID: attendanceID,
-		ClockIn:      &now,
+	// Clock in di hari libur mengikuti kebijakan departemen: ditolak atau dihitung lembur
+	holiday, err := u.calendar.HolidayOn(profile, workDate)
+	if err != nil {
+		return nil, err
+	}
+	description := "Clock in"
+	if holiday != nil {
+		if profile.Department != nil && profile.Department.HolidayPolicy == domain.HolidayPolicyBlock {
+			return nil, fmt.Errorf("clock in is not allowed on holiday: %s", holiday.Name)
+		}
+		description = "Clock in (holiday overtime: " + holiday.Name + ")"
 	}
 
-	history := domain.AttendanceHistory{
-		EmployeeCode:   profile.EmployeeCode,
-		AttendanceID:   attendanceID,
-		DateAttendance: now,
-		AttendanceType: domain.AttendanceTypeIn,
-		Description:    "Clock in",
+	mode := domain.AttendanceModeOffice
+	if req.Mode != "" {
+		mode = domain.AttendanceMode(req.Mode)
+	}
+	if err := u.checkAttendanceMode(profile, mode, workDate); err != nil {
+		return nil, err
+	}
+
+	// Geofence hanya berlaku untuk kerja dari kantor tanpa kiosk / badge reader
+	var location *domain.OfficeLocation
+	if device := req.Source.Device(); device != "" {
+		if mode != domain.AttendanceModeOffice {
+			return nil, fmt.Errorf("%s clock in is only allowed for office mode", device)
+		}
+		description += " via " + device
+	} else if mode == domain.AttendanceModeOffice {
+		location, err = u.checkGeofence(profile, req.Latitude, req.Longitude, req.Accuracy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Source.OccurredAt != nil {
+		description += " (offline sync)"
+	}
+
+	photoKey, err := u.storeClockPhoto(ctx, profile, req.Photo, req.Source, domain.AttendanceTypeIn, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !markedAbsent {
+		attendance = domain.Attendance{
+			EmployeeCode: profile.EmployeeCode,
+			AttendanceID: attendanceID,
+			WorkDate:     &workDate,
+		}
 	}
+	attendance.ClockIn = &now
+	attendance.Status = domain.AttendanceStatusPresent
+	attendance.HolidayWork = holiday != nil
+	attendance.Mode = mode
 
-	err = u.repo.CreateAttendanceWithHistory(&attendance, &history)
+	history := domain.AttendanceHistory{
+		EmployeeCode:     profile.EmployeeCode,
+		AttendanceID:     attendanceID,
+		DateAttendance:   now,
+		AttendanceType:   domain.AttendanceTypeIn,
+		Actor:            domain.ActorEmployee,
+		Description:      description,
+		Latitude:         req.Latitude,
+		Longitude:        req.Longitude,
+		LocationAccuracy: req.Accuracy,
+		OfficeLocationID: req.Source.OfficeLocationID,
+		KioskID:          req.Source.KioskID,
+		ReaderID:         req.Source.ReaderID,
+		PhotoKey:         photoKey,
+	}
+	if location != nil {
+		history.OfficeLocationID = &location.ID
+	}
+
+	if markedAbsent {
+		err = u.repo.UpdateAttendanceWithHistory(&attendance, &history)
+	} else {
+		err = u.repo.CreateAttendanceWithHistory(&attendance, &history)
+	}
 	if err != nil {
+		u.discardClockPhoto(ctx, photoKey)
 		return nil, err
 	}
+	publishAttendanceEvent(u.events, u.webhooks, dto.AttendanceEventClockIn, profile, &attendance, domain.ActorEmployee)
 
 	return mapToAttendanceResponse(&attendance), nil
 }
 
-func (u *attendanceUseCase) ClockOut(ctx context.Context, userID uuid.UUID) (*dto.AttendanceResponse, error) {
+func (u *attendanceUseCase) ClockOut(ctx context.Context, userID uuid.UUID, req dto.ClockOutRequest) (*dto.AttendanceResponse, error) {
 	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
 	if err != nil || profile == nil {
 		return nil, fmt.Errorf("profile not found")
 	}
 
-	now := time.Now()
-	today := now.Format("2006-01-02")
-	attendanceID := fmt.Sprintf("%s-%s", profile.EmployeeCode, today)
+	now := req.Source.Time()
 
-	var attendance domain.Attendance
-	if err := u.repo.FindAttendanceByID(attendanceID, &attendance); err != nil {
+	// Attendance yang masih open bisa berasal dari hari kerja kemarin (shift malam)
+	attendance, err := u.repo.FindOpenAttendance(profile.EmployeeCode, now.Add(-maxShiftDuration))
+	if err != nil {
+		return nil, err
+	}
+	// Clock out offline bisa tiba setelah job menutup attendance dengan auto clock out
+	var replacedAutoClockOut *time.Time
+	if attendance == nil && req.Source.OccurredAt != nil {
+		attendance, err = u.autoClosedAttendanceFor(profile, now)
+		if err != nil {
+			return nil, err
+		}
+		if attendance != nil {
+			replacedAutoClockOut = attendance.ClockOut
+		}
+	}
+	if attendance == nil {
+		var today domain.Attendance
+		if err := u.repo.FindAttendanceByID(attendanceIDFor(profile.EmployeeCode, dateOf(now)), &today); err == nil && today.ClockOut != nil {
+			return nil, fmt.Errorf("already clocked out")
+		}
 		return nil, fmt.Errorf("no clock in today")
 	}
-	if attendance.ClockOut != nil {
-		return nil, fmt.Errorf("already clocked out")
+	// Clock out offline bisa tiba setelah clock in yang lebih baru
+	if !clockOutAfterClockIn(attendance, now) {
+		return nil, fmt.Errorf("clock out time is before the last clock in")
+	}
+	if err := u.periods.check(workDateOf(attendance)); err != nil {
+		return nil, err
+	}
+
+	var location *domain.OfficeLocation
+	description := "Clock out"
+	if device := req.Source.Device(); device != "" {
+		description += " via " + device
+	} else if attendance.Mode == domain.AttendanceModeOffice {
+		// Mode remote / field visit / business trip tidak dibatasi lokasi, sama seperti saat clock in
+		location, err = u.checkGeofence(profile, req.Latitude, req.Longitude, req.Accuracy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if replacedAutoClockOut != nil {
+		description += fmt.Sprintf(" (offline sync, replaces auto clock out at %s)", replacedAutoClockOut.Format("2006-01-02 15:04"))
+	} else if req.Source.OccurredAt != nil {
+		description += " (offline sync)"
+	}
+
+	photoKey, err := u.storeClockPhoto(ctx, profile, req.Photo, req.Source, domain.AttendanceTypeOut, now)
+	if err != nil {
+		return nil, err
 	}
 
 	attendance.ClockOut = &now
+	attendance.AutoClosed = false
+
+	history := domain.AttendanceHistory{
+		EmployeeCode:     profile.EmployeeCode,
+		AttendanceID:     attendance.AttendanceID,
+		DateAttendance:   now,
+		AttendanceType:   domain.AttendanceTypeOut,
+		Actor:            domain.ActorEmployee,
+		Description:      description,
+		Latitude:         req.Latitude,
+		Longitude:        req.Longitude,
+		LocationAccuracy: req.Accuracy,
+		OfficeLocationID: req.Source.OfficeLocationID,
+		KioskID:          req.Source.KioskID,
+		ReaderID:         req.Source.ReaderID,
+		PhotoKey:         photoKey,
+	}
+	if location != nil {
+		history.OfficeLocationID = &location.ID
+	}
+
+	err = u.repo.UpdateAttendanceWithHistory(attendance, &history)
+	if err != nil {
+		u.discardClockPhoto(ctx, photoKey)
+		return nil, err
+	}
+	publishAttendanceEvent(u.events, u.webhooks, dto.AttendanceEventClockOut, profile, attendance, domain.ActorEmployee)
+
+	return mapToAttendanceResponse(attendance), nil
+}
+
+// clockOutAfterClockIn membandingkan clock in dari database (jam dinding, terbaca sebagai UTC)
+// dengan now sebagai jam dinding, supaya benar di server dengan zona waktu selain UTC
+func clockOutAfterClockIn(attendance *domain.Attendance, now time.Time) bool {
+	return attendance.ClockIn != nil && attendance.ClockIn.Before(wallClock(now))
+}
+
+// ErrAutoClockOutConflict menandai clock out offline yang lebih lambat dari auto clock out
+// sistem pada attendance yang sama; auto clock out tidak ditimpa dan perlu koreksi admin.
+var ErrAutoClockOutConflict = errors.New("attendance was already auto clocked out by system")
+
+// autoClosedAttendanceFor mengembalikan attendance terakhir sebelum now yang ditutup oleh auto
+// clock out setelah waktu now, sehingga clock out offline pada now boleh menggantikannya.
+// Attendance auto clock out yang waktunya tidak setelah now dilaporkan sebagai ErrAutoClockOutConflict.
+func (u *attendanceUseCase) autoClosedAttendanceFor(profile *domain.UserProfile, now time.Time) (*domain.Attendance, error) {
+	attendance, err := u.repo.FindLatestAttendanceBefore(profile.EmployeeCode, now.Add(-maxShiftDuration), now)
+	if err != nil || attendance == nil {
+		return nil, err
+	}
+	if !attendance.AutoClosed || attendance.ClockOut == nil {
+		return nil, nil
+	}
+	// Auto clock out disimpan sebagai jam dinding, sama seperti saat job menutupnya
+	if !wallClock(now).Before(*attendance.ClockOut) {
+		return nil, fmt.Errorf("%w at %s, before this clock out", ErrAutoClockOutConflict, attendance.ClockOut.Format("2006-01-02 15:04"))
+	}
+	return attendance, nil
+}
+
+// BreakStart memulai istirahat; hanya bisa dilakukan saat sudah clock in dan belum clock out
+func (u *attendanceUseCase) BreakStart(ctx context.Context, userID uuid.UUID, req dto.BreakStartRequest) (*dto.AttendanceBreakResponse, error) {
+	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	now := time.Now()
+	attendance, err := u.repo.FindOpenAttendance(profile.EmployeeCode, now.Add(-maxShiftDuration))
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil {
+		return nil, fmt.Errorf("you must be clocked in to start a break")
+	}
+	if err := u.periods.check(workDateOf(attendance)); err != nil {
+		return nil, err
+	}
+
+	open, err := u.repo.FindOpenBreak(attendance.AttendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, fmt.Errorf("already on break")
+	}
 
+	brk := domain.AttendanceBreak{
+		EmployeeCode: profile.EmployeeCode,
+		AttendanceID: attendance.AttendanceID,
+		Type:         domain.BreakType(req.Type),
+		StartedAt:    now,
+	}
 	history := domain.AttendanceHistory{
 		EmployeeCode:   profile.EmployeeCode,
-		AttendanceID:   attendanceID,
+		AttendanceID:   attendance.AttendanceID,
 		DateAttendance: now,
-		AttendanceType: domain.AttendanceTypeOut,
-		Description:    "Clock out",
+		AttendanceType: domain.AttendanceTypeBreakStart,
+		Actor:          domain.ActorEmployee,
+		Description:    "Break start (" + req.Type + ")",
+	}
+	created, err := u.repo.CreateBreakWithHistory(&brk, &history)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		// Request break start lain menang di antara FindOpenBreak dan insert
+		return nil, fmt.Errorf("already on break")
 	}
 
-	err = u.repo.UpdateAttendanceWithHistory(&attendance, &history)
+	return mapToAttendanceBreakResponse(&brk), nil
+}
+
+func (u *attendanceUseCase) BreakEnd(ctx context.Context, userID uuid.UUID) (*dto.AttendanceBreakResponse, error) {
+	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	now := time.Now()
+	attendance, err := u.repo.FindOpenAttendance(profile.EmployeeCode, now.Add(-maxShiftDuration))
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil {
+		return nil, fmt.Errorf("you must be clocked in to end a break")
+	}
+	if err := u.periods.check(workDateOf(attendance)); err != nil {
+		return nil, err
+	}
+
+	brk, err := u.repo.FindOpenBreak(attendance.AttendanceID)
 	if err != nil {
 		return nil, err
 	}
+	if brk == nil {
+		return nil, fmt.Errorf("not on break")
+	}
+	brk.EndedAt = &now
 
-	return mapToAttendanceResponse(&attendance), nil
+	history := domain.AttendanceHistory{
+		EmployeeCode:   profile.EmployeeCode,
+		AttendanceID:   attendance.AttendanceID,
+		DateAttendance: now,
+		AttendanceType: domain.AttendanceTypeBreakEnd,
+		Actor:          domain.ActorEmployee,
+		Description:    "Break end (" + string(brk.Type) + ")",
+	}
+	if err := u.repo.UpdateBreakWithHistory(brk, &history); err != nil {
+		return nil, err
+	}
+
+	return mapToAttendanceBreakResponse(brk), nil
+}
+
+func mapToAttendanceBreakResponse(b *domain.AttendanceBreak) *dto.AttendanceBreakResponse {
+	res := &dto.AttendanceBreakResponse{
+		ID:           b.ID,
+		AttendanceID: b.AttendanceID,
+		Type:         string(b.Type),
+		StartedAt:    b.StartedAt,
+		EndedAt:      b.EndedAt,
+	}
+	if b.EndedAt != nil {
+		res.Minutes = int(b.EndedAt.Sub(b.StartedAt).Minutes())
+	}
+	return res
 }
 
 // func (u *attendanceUseCase) GetAttendanceLogs(ctx context.Context, userID uuid.UUID, role string, req dto.GetAttendanceLogsRequest) ([]dto.AttendanceLogResponse, int64, error) {
@@ -225,6 +662,14 @@ func (u *attendanceUseCase) ClockOut(ctx context.Context, userID uuid.UUID) (*dt
 // 	return logs, total, nil
 // }
 
+// logSortColumns memetakan sort_by ke kolom query, hanya nilai ini yang boleh masuk ke ORDER BY
+var logSortColumns = map[string]string{
+	"work_date":           "work_date",
+	"late_minutes":        "a.late_minutes",
+	"early_leave_minutes": "a.early_leave_minutes",
+	"worked_minutes":      "a.worked_minutes",
+}
+
 func (u *attendanceUseCase) GetAttendanceLogs(ctx context.Context, userID uuid.UUID, role string, req dto.GetAttendanceLogsRequest) ([]dto.AttendanceLogResponse, int64, error) {
 	u.log.WithFields(logrus.Fields{
 		"user_id":       userID,
@@ -237,26 +682,9 @@ func (u *attendanceUseCase) GetAttendanceLogs(ctx context.Context, userID uuid.U
 
 	offset := (req.Page - 1) * req.Limit
 
-	query := u.repo.GetAttendanceQuery()
-
-	if req.Date != "" {
-		u.log.WithField("filter_date", req.Date).Debug("Applying date filter")
-		query = query.Where("DATE(a.clock_in) = ?", req.Date)
-	}
-	if req.DepartmentID != nil {
-		u.log.WithField("filter_department_id", req.DepartmentID).Debug("Applying department filter")
-		query = query.Where("up.department_id = ?", *req.DepartmentID)
-	}
-
-	if role != "admin" {
-		profile, _ := u.profileRepo.FindUserProfileByUserID(userID)
-		u.log.WithField("user_profile", profile).Debug("Non-admin role, checking department restriction")
-		if profile != nil && profile.DepartmentID != nil {
-			query = query.Where("up.department_id = ?", *profile.DepartmentID)
-		} else {
-			u.log.Warn("No access: user has no department")
-			return nil, 0, fmt.Errorf("no access")
-		}
+	query, err := u.attendanceLogsQuery(userID, role, req)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	var total int64
@@ -267,6 +695,8 @@ func (u *attendanceUseCase) GetAttendanceLogs(ctx context.Context, userID uuid.U
 	u.log.WithField("total_records", total).Info("Total attendance logs found")
 
 	var rawLogs []dto.RawAttendanceLog
+	// Urutan diterapkan setelah count
+	query = orderAttendanceLogs(query, req)
 	if err := query.Offset(offset).Limit(req.Limit).Scan(&rawLogs).Error; err != nil {
 		u.log.WithError(err).Error("Failed to scan raw attendance logs")
 		return nil, 0, err
@@ -276,90 +706,139 @@ func (u *attendanceUseCase) GetAttendanceLogs(ctx context.Context, userID uuid.U
 	finalLogs := make([]dto.AttendanceLogResponse, 0, len(rawLogs))
 
 	for _, raw := range rawLogs {
-		inPunctuality := "N/A"
-		outPunctuality := "N/A"
-
-		u.log.WithFields(logrus.Fields{
-			"attendance_id":      raw.AttendanceID,
-			"employee_code":      raw.EmployeeCode,
-			"clock_in":           raw.ClockIn,
-			"max_clock_in_time":  raw.MaxClockInTime,
-			"clock_out":          raw.ClockOut,
-			"max_clock_out_time": raw.MaxClockOutTime,
-		}).Debug("Processing attendance record")
-
-		// Kalkulasi Punctuality Clock In
-		if raw.ClockIn != nil {
-			if raw.MaxClockInTime == nil {
-				u.log.WithField("department", raw.DepartmentName).Error("MaxClockInTime not configured")
-				return nil, 0, fmt.Errorf("konfigurasi 'MaxClockInTime' untuk departemen '%s' tidak ditemukan", raw.DepartmentName)
-			}
-
-			maxIn := *raw.MaxClockInTime
-			actualClockIn := *raw.ClockIn
-			targetInTime := time.Date(
-				actualClockIn.Year(), actualClockIn.Month(), actualClockIn.Day(),
-				maxIn.Hour(), maxIn.Minute(), maxIn.Second(), 0, actualClockIn.Location(),
-			)
-
-			u.log.WithFields(logrus.Fields{
-				"actual_clock_in": actualClockIn,
-				"target_in_time":  targetInTime,
-			}).Debug("Comparing clock in times")
-
-			if actualClockIn.After(targetInTime) {
-				inPunctuality = "Late"
-			} else {
-				inPunctuality = "On Time"
-			}
+		log, err := u.buildAttendanceLog(raw)
+		if err != nil {
+			return nil, 0, err
 		}
+		finalLogs = append(finalLogs, log)
+	}
 
-		// Kalkulasi Punctuality Clock Out
-		// Kalkulasi Punctuality Clock Out
-		if raw.ClockOut != nil {
-			if raw.MaxClockOutTime == nil {
-				return nil, 0, fmt.Errorf("konfigurasi 'MaxClockOutTime' untuk departemen '%s' tidak ditemukan", raw.DepartmentName)
-			}
-
-			actualClockOut := *raw.ClockOut
-			maxOut := *raw.MaxClockOutTime
+	return finalLogs, total, nil
+}
 
-			// Gunakan tanggal actualClockOut, jam dari MaxClockOutTime
-			targetOutTime := time.Date(
-				actualClockOut.Year(), actualClockOut.Month(), actualClockOut.Day(),
-				maxOut.Hour(), maxOut.Minute(), maxOut.Second(), 0, actualClockOut.Location(),
-			)
+// attendanceLogsQuery menerapkan filter logs dan batasan akses: non-admin hanya melihat departemennya
+func (u *attendanceUseCase) attendanceLogsQuery(userID uuid.UUID, role string, req dto.GetAttendanceLogsRequest) (*gorm.DB, error) {
+	query := u.repo.GetAttendanceQuery()
 
-			u.log.WithFields(logrus.Fields{
-				"actual_clock_out": actualClockOut,
-				"target_out_time":  targetOutTime,
-			}).Debug("Comparing clock out times")
+	if req.Date != "" {
+		u.log.WithField("filter_date", req.Date).Debug("Applying date filter")
+		query = query.Where("COALESCE(a.work_date, DATE(a.clock_in)) = ?", req.Date)
+	}
+	if req.StartDate != "" {
+		query = query.Where("COALESCE(a.work_date, DATE(a.clock_in)) >= ?", req.StartDate)
+	}
+	if req.EndDate != "" {
+		query = query.Where("COALESCE(a.work_date, DATE(a.clock_in)) <= ?", req.EndDate)
+	}
+	if req.DepartmentID != nil {
+		u.log.WithField("filter_department_id", req.DepartmentID).Debug("Applying department filter")
+		query = query.Where("up.department_id = ?", *req.DepartmentID)
+	}
+	if req.Status != "" {
+		u.log.WithField("filter_status", req.Status).Debug("Applying status filter")
+		query = query.Where("a.status = ?", req.Status)
+	}
+	if req.Mode != "" {
+		u.log.WithField("filter_mode", req.Mode).Debug("Applying mode filter")
+		query = query.Where("a.mode = ?", req.Mode)
+	}
+	if req.MinLateMinutes != nil {
+		query = query.Where("a.late_minutes >= ?", *req.MinLateMinutes)
+	}
+	if req.MinEarlyLeaveMinutes != nil {
+		query = query.Where("a.early_leave_minutes >= ?", *req.MinEarlyLeaveMinutes)
+	}
+	if req.MinWorkedMinutes != nil {
+		query = query.Where("a.worked_minutes >= ?", *req.MinWorkedMinutes)
+	}
+	if req.MaxWorkedMinutes != nil {
+		query = query.Where("a.worked_minutes <= ?", *req.MaxWorkedMinutes)
+	}
 
-			if actualClockOut.Before(targetOutTime) {
-				outPunctuality = "Early Leave"
-			} else {
-				outPunctuality = "On Time"
-			}
+	if role != "admin" {
+		profile, _ := u.profileRepo.FindUserProfileByUserID(userID)
+		u.log.WithField("user_profile", profile).Debug("Non-admin role, checking department restriction")
+		if profile != nil && profile.DepartmentID != nil {
+			query = query.Where("up.department_id = ?", *profile.DepartmentID)
+		} else {
+			u.log.Warn("No access: user has no department")
+			return nil, fmt.Errorf("no access")
 		}
+	}
 
-		u.log.WithFields(logrus.Fields{
-			"in_punctuality":  inPunctuality,
-			"out_punctuality": outPunctuality,
-		}).Info("Calculated punctuality result")
+	return query, nil
+}
 
-		finalLogs = append(finalLogs, dto.AttendanceLogResponse{
-			AttendanceID:   raw.AttendanceID,
-			EmployeeCode:   raw.EmployeeCode,
-			FullName:       raw.FullName,
-			DepartmentName: raw.DepartmentName,
-			ClockIn:        raw.ClockIn,
-			ClockOut:       raw.ClockOut,
-			InPunctuality:  inPunctuality,
-			OutPunctuality: outPunctuality,
-		})
+// orderAttendanceLogs mengurutkan logs sesuai sort_by/sort_order; employee_code sebagai tie-breaker supaya paging stabil
+func orderAttendanceLogs(query *gorm.DB, req dto.GetAttendanceLogsRequest) *gorm.DB {
+	sortColumn := "work_date"
+	if req.SortBy != "" {
+		sortColumn = logSortColumns[req.SortBy]
 	}
+	sortOrder := "DESC"
+	if req.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+	return query.Order(fmt.Sprintf("%s %s NULLS LAST, a.employee_code ASC", sortColumn, sortOrder))
+}
 
-	return finalLogs, total, nil
+// buildAttendanceLog menilai satu baris GetAttendanceQuery menjadi log: jadwal, punctuality, istirahat dan lembur
+func (u *attendanceUseCase) buildAttendanceLog(raw dto.RawAttendanceLog) (dto.AttendanceLogResponse, error) {
+	u.log.WithFields(logrus.Fields{
+		"attendance_id":      raw.AttendanceID,
+		"employee_code":      raw.EmployeeCode,
+		"clock_in":           raw.ClockIn,
+		"max_clock_in_time":  raw.MaxClockInTime,
+		"clock_out":          raw.ClockOut,
+		"max_clock_out_time": raw.MaxClockOutTime,
+		"shift_name":         raw.ShiftName,
+		"punctuality_rule":   raw.PunctualityRule,
+	}).Debug("Processing attendance record")
+
+	schedule, err := scheduleFromRawLog(raw)
+	if err != nil {
+		u.log.WithField("department", raw.DepartmentName).Error(err.Error())
+		return dto.AttendanceLogResponse{}, err
+	}
+
+	// Penilaian punctuality sesuai rule departemen (fixed window, flexitime, minimum duration)
+	result := evaluateRawLog(raw, schedule)
+	inPunctuality, outPunctuality := result.In, result.Out
+
+	breakMinutes := raw.BreakSeconds / 60
+
+	overtimeMinutes := overtimeFromRawLog(raw, schedule)
+	if overtimeMinutes > 0 && outPunctuality == punctualityOnTime {
+		outPunctuality = "Overtime"
+	}
+
+	u.log.WithFields(logrus.Fields{
+		"in_punctuality":  inPunctuality,
+		"out_punctuality": outPunctuality,
+	}).Debug("Calculated punctuality result")
+
+	return dto.AttendanceLogResponse{
+		AttendanceID:      raw.AttendanceID,
+		EmployeeCode:      raw.EmployeeCode,
+		FullName:          raw.FullName,
+		DepartmentName:    raw.DepartmentName,
+		ShiftName:         schedule.ShiftName,
+		WorkDate:          raw.WorkDate.Format("2006-01-02"),
+		ClockIn:           raw.ClockIn,
+		ClockOut:          raw.ClockOut,
+		Status:            raw.Status,
+		HolidayWork:       raw.HolidayWork,
+		AutoClosed:        raw.AutoClosed,
+		Mode:              raw.Mode,
+		LateMinutes:       result.LateMinutes,
+		EarlyLeaveMinutes: result.EarlyLeaveMinutes,
+		BreakMinutes:      breakMinutes,
+		WorkedMinutes:     result.WorkedMinutes,
+		BreakViolation:    raw.MaxBreakMinutes > 0 && breakMinutes > raw.MaxBreakMinutes,
+		OvertimeMinutes:   overtimeMinutes,
+		InPunctuality:     inPunctuality,
+		OutPunctuality:    outPunctuality,
+	}, nil
 }
 
 func mapToAttendanceResponse(a *domain.Attendance) *dto.AttendanceResponse {
@@ -367,26 +846,14 @@ func mapToAttendanceResponse(a *domain.Attendance) *dto.AttendanceResponse {
 		ID:           a.ID,
 		EmployeeCode: a.EmployeeCode,
 		AttendanceID: a.AttendanceID,
+		WorkDate:     a.WorkDate,
+		Mode:         string(a.Mode),
 		ClockIn:      a.ClockIn,
 		ClockOut:     a.ClockOut,
 		CreatedAt:    a.CreatedAt,
 		UpdatedAt:    a.UpdatedAt,
 	}
 }
-func parseDepartmentTime(base *time.Time, deptTime *time.Time, fallback string) time.Time {
-	var t time.Time
-
-	if deptTime != nil {
-		t = *deptTime
-	} else {
-		parsed, _ := time.Parse("15:04:05", fallback)
-		t = parsed
-	}
-
-	// gabungkan tanggal dari base (clock_in/clock_out) dengan jam aturan dept
-	year, month, day := base.Date()
-	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, base.Location())
-}
 
 func (u *attendanceUseCase) CheckCurrentStatus(ctx context.Context, userID uuid.UUID) (*dto.CurrentStatusResponse, error) {
 	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
@@ -406,8 +873,21 @@ func (u *attendanceUseCase) CheckCurrentStatus(ctx context.Context, userID uuid.
 		clockIn = attendance.ClockIn
 		clockOut = attendance.ClockOut
 
-		if attendance.ClockIn != nil && attendance.ClockOut == nil {
+		if attendance.Status == domain.AttendanceStatusOnLeave {
+			status = "On Leave"
+		} else if attendance.Status == domain.AttendanceStatusAbsent {
+			status = "Absent"
+		} else if attendance.ClockIn != nil && attendance.ClockOut == nil {
 			status = "Clocked In"
+			brk, err := u.repo.FindOpenBreak(attendance.AttendanceID)
+			if err != nil {
+				return nil, err
+			}
+			if brk != nil {
+				status = "On Break"
+			}
+		} else if attendance.ClockIn != nil && attendance.ClockOut != nil && attendance.AutoClosed {
+			status = "Auto Clocked Out"
 		} else if attendance.ClockIn != nil && attendance.ClockOut != nil {
 			status = "Clocked Out"
 		}
@@ -420,14 +900,48 @@ func (u *attendanceUseCase) CheckCurrentStatus(ctx context.Context, userID uuid.
 		deptName = profile.Department.Name
 	}
 
+	punctuality := punctualityResult{In: punctualityNA, Out: punctualityNA}
+	if attendance != nil {
+		punctuality, err = u.evaluateAttendance(profile, attendance)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &dto.CurrentStatusResponse{
-		UserID:       userID,
-		EmployeeCode: profile.EmployeeCode,
-		FullName:     profile.FullName,
-		Department:   deptName,
-		Status:       status,
-		ClockIn:      clockIn,
-		ClockOut:     clockOut,
-		UpdatedAt:    time.Now(),
+		UserID:            userID,
+		EmployeeCode:      profile.EmployeeCode,
+		FullName:          profile.FullName,
+		Department:        deptName,
+		Status:            status,
+		ClockIn:           clockIn,
+		ClockOut:          clockOut,
+		InPunctuality:     punctuality.In,
+		OutPunctuality:    punctuality.Out,
+		LateMinutes:       punctuality.LateMinutes,
+		EarlyLeaveMinutes: punctuality.EarlyLeaveMinutes,
+		UpdatedAt:         time.Now(),
 	}, nil
 }
+
+// evaluateAttendance menilai punctuality satu attendance dengan jadwal dan rule departemen karyawan
+func (u *attendanceUseCase) evaluateAttendance(profile *domain.UserProfile, attendance *domain.Attendance) (punctualityResult, error) {
+	workDate := workDateOf(attendance)
+	schedule, err := u.calendar.Schedule(profile, workDate)
+	if err != nil {
+		return punctualityResult{}, err
+	}
+	breakSeconds, err := u.repo.SumBreakSeconds(attendance.AttendanceID)
+	if err != nil {
+		return punctualityResult{}, err
+	}
+	rule := newPunctualityRule(punctualityFromDepartment(profile.Department))
+	return evaluatePunctuality(rule, attendance.Status, attendanceDay{
+		WorkDate:     workDate,
+		ClockIn:      attendance.ClockIn,
+		ClockOut:     attendance.ClockOut,
+		AutoClosed:   attendance.AutoClosed,
+		BreakSeconds: breakSeconds,
+		Schedule:     schedule,
+	}), nil
+}
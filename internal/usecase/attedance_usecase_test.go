@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"testing"
+	"time"
+)
+
+func TestClockOutAfterClockInOnNonUTCServer(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	local := time.Local
+	time.Local = jakarta
+	defer func() { time.Local = local }()
+
+	// Kolom timestamp dibaca kembali sebagai jam dinding dengan zona UTC
+	clockIn := time.Date(2025, time.September, 1, 8, 0, 0, 0, time.UTC)
+	attendance := &domain.Attendance{ClockIn: &clockIn}
+
+	cases := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"one hour after clock in", time.Date(2025, time.September, 1, 9, 0, 0, 0, time.Local), true},
+		{"same wall clock as clock in", time.Date(2025, time.September, 1, 8, 0, 0, 0, time.Local), false},
+		{"before clock in", time.Date(2025, time.September, 1, 7, 59, 0, 0, time.Local), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := clockOutAfterClockIn(attendance, tc.now); got != tc.want {
+				t.Errorf("clockOutAfterClockIn(%s) = %v, want %v", tc.now, got, tc.want)
+			}
+		})
+	}
+}
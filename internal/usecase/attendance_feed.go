@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/event"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscribeAttendanceFeed membuka langganan event attendance dengan aturan akses yang sama seperti
+// GetAttendanceLogs: admin bisa semua departemen, selain admin hanya departemennya sendiri.
+// Pemanggil wajib memanggil Close pada subscription.
+func (u *attendanceUseCase) SubscribeAttendanceFeed(ctx context.Context, userID uuid.UUID, role string, req dto.AttendanceFeedRequest) (*event.Subscription, error) {
+	departmentID := req.DepartmentID
+	if role != "admin" {
+		profile, _ := u.profileRepo.FindUserProfileByUserID(userID)
+		if profile == nil || profile.DepartmentID == nil {
+			return nil, fmt.Errorf("no access")
+		}
+		if departmentID != nil && *departmentID != *profile.DepartmentID {
+			return nil, fmt.Errorf("no access")
+		}
+		departmentID = profile.DepartmentID
+	}
+	return u.events.Subscribe(departmentID), nil
+}
+
+// newAttendanceEvent menyusun event dari attendance yang baru disimpan
+func newAttendanceEvent(eventType string, profile *domain.UserProfile, attendance *domain.Attendance, actor domain.AttendanceActor) dto.AttendanceEvent {
+	e := dto.AttendanceEvent{
+		ID:           uuid.New(),
+		Type:         eventType,
+		EmployeeCode: profile.EmployeeCode,
+		FullName:     profile.FullName,
+		DepartmentID: profile.DepartmentID,
+		AttendanceID: attendance.AttendanceID,
+		WorkDate:     workDateOf(attendance).Format(dateLayout),
+		Status:       string(attendance.Status),
+		Mode:         string(attendance.Mode),
+		ClockIn:      attendance.ClockIn,
+		ClockOut:     attendance.ClockOut,
+		Actor:        string(actor),
+		OccurredAt:   time.Now(),
+	}
+	if profile.Department != nil {
+		e.DepartmentName = profile.Department.Name
+	}
+	return e
+}
+
+// publishAttendanceEvent dipanggil setelah perubahan attendance tersimpan. Webhook di-enqueue
+// langsung sebagai delivery di database (tidak lewat broker yang boleh membuang event), sedangkan
+// feed SSE selalu per departemen sehingga karyawan tanpa departemen tidak dikirim ke broker.
+func publishAttendanceEvent(events *event.Broker, webhooks WebhookPublisher, eventType string, profile *domain.UserProfile, attendance *domain.Attendance, actor domain.AttendanceActor) {
+	if profile == nil {
+		return
+	}
+	e := newAttendanceEvent(eventType, profile, attendance, actor)
+	if webhooks != nil {
+		webhooks.Publish("attendance."+eventType, e)
+	}
+	if events != nil && e.DepartmentID != nil {
+		events.Publish(e)
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxClockPhotoBytes = 3 << 20 // 3 MB, masih di bawah body limit default Fiber (4 MB)
+	clockPhotoURLTTL   = 10 * time.Minute
+)
+
+// Ekstensi file per content type foto yang diterima
+var clockPhotoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// storeClockPhoto menyimpan foto selfie clock in/out dan mengembalikan key-nya. Clock lewat
+// kiosk / badge reader tidak membawa foto, jadi aturan wajib foto departemen hanya berlaku
+// untuk clock dari device karyawan sendiri.
+func (u *attendanceUseCase) storeClockPhoto(ctx context.Context, profile *domain.UserProfile, photo *dto.ClockPhoto, source dto.ClockSource, attendanceType domain.AttendanceType, now time.Time) (string, error) {
+	if photo == nil {
+		if source.Device() == "" && profile.Department != nil && profile.Department.RequireClockPhoto {
+			return "", fmt.Errorf("photo is required for your department")
+		}
+		return "", nil
+	}
+	if len(photo.Content) > maxClockPhotoBytes {
+		return "", fmt.Errorf("photo is too large (max %d MB)", maxClockPhotoBytes>>20)
+	}
+	// Content type ditentukan dari isi file, header dari client tidak dipercaya
+	contentType := http.DetectContentType(photo.Content)
+	ext, ok := clockPhotoExtensions[contentType]
+	if !ok {
+		return "", fmt.Errorf("photo must be a JPEG, PNG or WebP image")
+	}
+
+	key := fmt.Sprintf("attendance/%s/%s/%s-%s%s", profile.EmployeeCode, now.Format("2006-01-02"), attendanceType, uuid.New(), ext)
+	if err := u.files.Put(ctx, key, bytes.NewReader(photo.Content), contentType); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// discardClockPhoto menghapus foto yang sudah tersimpan jika attendance gagal disimpan
+func (u *attendanceUseCase) discardClockPhoto(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+	if err := u.files.Delete(ctx, key); err != nil {
+		u.log.WithError(err).WithField("key", key).Warn("Failed to delete orphaned clock photo")
+	}
+}
+
+// GetAttendancePhotoURL membuat signed URL foto selfie satu history; hanya untuk admin
+func (u *attendanceUseCase) GetAttendancePhotoURL(ctx context.Context, historyID uuid.UUID) (*dto.AttendancePhotoURLResponse, error) {
+	history, err := u.repo.FindAttendanceHistoryByID(historyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("attendance history not found")
+		}
+		return nil, err
+	}
+	if history.PhotoKey == "" {
+		return nil, fmt.Errorf("attendance history has no photo")
+	}
+	url, expiresAt, err := u.files.SignedURL(ctx, history.PhotoKey, clockPhotoURLTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AttendancePhotoURLResponse{HistoryID: history.ID, URL: url, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxReportDays membatasi rentang laporan supaya PDF tetap wajar ukurannya
+const maxReportDays = 366
+
+// GetAttendanceReport merekap logs (sumber data yang sama dengan GetAttendanceLogs) untuk satu
+// departemen atau satu karyawan. Non-admin hanya bisa melaporkan departemennya sendiri.
+func (u *attendanceUseCase) GetAttendanceReport(ctx context.Context, userID uuid.UUID, role string, req dto.AttendanceReportRequest) (*dto.AttendanceReportResponse, error) {
+	if req.DepartmentID != nil && req.UserID != nil {
+		return nil, fmt.Errorf("choose either department_id or user_id")
+	}
+	start, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_date")
+	}
+	end, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_date")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end_date must not be before start_date")
+	}
+	if end.Sub(start) >= maxReportDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must not exceed %d days", maxReportDays)
+	}
+
+	// Departemen peminta untuk pengecekan akses non-admin
+	var ownDepartmentID *uuid.UUID
+	if role != "admin" {
+		requester, _ := u.profileRepo.FindUserProfileByUserID(userID)
+		if requester == nil || requester.DepartmentID == nil {
+			return nil, fmt.Errorf("no access")
+		}
+		ownDepartmentID = requester.DepartmentID
+	}
+
+	report := &dto.AttendanceReportResponse{
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		GeneratedAt: time.Now(),
+	}
+	query := u.repo.GetAttendanceQuery().
+		Where("COALESCE(a.work_date, DATE(a.clock_in)) BETWEEN ? AND ?", req.StartDate, req.EndDate)
+
+	if req.UserID != nil {
+		profile, err := u.profileRepo.FindUserProfileByUserID(*req.UserID)
+		if err != nil || profile == nil {
+			return nil, fmt.Errorf("user not found")
+		}
+		if ownDepartmentID != nil && (profile.DepartmentID == nil || *profile.DepartmentID != *ownDepartmentID) {
+			return nil, fmt.Errorf("no access")
+		}
+		report.Scope = "employee"
+		report.Title = fmt.Sprintf("%s (%s)", profile.FullName, profile.EmployeeCode)
+		query = query.Where("a.employee_code = ?", profile.EmployeeCode)
+	} else {
+		dept, err := u.deptRepo.FindDepartmentByID(*req.DepartmentID)
+		if err != nil {
+			return nil, fmt.Errorf("department not found")
+		}
+		if ownDepartmentID != nil && *ownDepartmentID != dept.ID {
+			return nil, fmt.Errorf("no access")
+		}
+		report.Scope = "department"
+		report.Title = dept.Name
+		query = query.Where("up.department_id = ?", dept.ID)
+	}
+
+	rows, err := query.Order("work_date ASC, a.employee_code ASC").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := make(map[string]*dto.AttendanceReportEmployee)
+	days := make(map[string]*dto.AttendanceReportDay)
+	for rows.Next() {
+		var raw dto.RawAttendanceLog
+		if err := query.ScanRows(rows, &raw); err != nil {
+			return nil, err
+		}
+		log, err := u.buildAttendanceLog(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		employee, ok := employees[log.EmployeeCode]
+		if !ok {
+			employee = &dto.AttendanceReportEmployee{
+				EmployeeCode:   log.EmployeeCode,
+				FullName:       log.FullName,
+				DepartmentName: log.DepartmentName,
+			}
+			employees[log.EmployeeCode] = employee
+		}
+		day, ok := days[log.WorkDate]
+		if !ok {
+			day = &dto.AttendanceReportDay{Date: log.WorkDate}
+			days[log.WorkDate] = day
+		}
+		addToReport(report, employee, day, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report.Employees = make([]dto.AttendanceReportEmployee, 0, len(employees))
+	for _, employee := range employees {
+		report.Employees = append(report.Employees, *employee)
+	}
+	sort.Slice(report.Employees, func(i, j int) bool {
+		return report.Employees[i].FullName < report.Employees[j].FullName
+	})
+
+	// Setiap tanggal di rentang tetap muncul meskipun tidak ada record
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format(dateLayout)
+		if day, ok := days[key]; ok {
+			report.Days = append(report.Days, *day)
+		} else {
+			report.Days = append(report.Days, dto.AttendanceReportDay{Date: key})
+		}
+	}
+
+	lateness := &report.Lateness
+	if lateness.PresentDays > 0 {
+		lateness.LateRate = math.Round(float64(lateness.LateDays)/float64(lateness.PresentDays)*10000) / 100
+	}
+	if lateness.LateDays > 0 {
+		lateness.AverageLateMinutes = math.Round(float64(lateness.TotalLateMinutes)/float64(lateness.LateDays)*100) / 100
+	}
+	return report, nil
+}
+
+func addToReport(report *dto.AttendanceReportResponse, employee *dto.AttendanceReportEmployee, day *dto.AttendanceReportDay, log dto.AttendanceLogResponse) {
+	switch domain.AttendanceStatus(log.Status) {
+	case domain.AttendanceStatusOnLeave:
+		employee.LeaveDays++
+		day.OnLeave++
+		return
+	case domain.AttendanceStatusAbsent:
+		employee.AbsentDays++
+		day.Absent++
+		return
+	}
+
+	employee.PresentDays++
+	day.Present++
+	report.Lateness.PresentDays++
+	if log.LateMinutes > 0 {
+		employee.LateDays++
+		employee.LateMinutes += log.LateMinutes
+		day.Late++
+		report.Lateness.LateDays++
+		report.Lateness.TotalLateMinutes += log.LateMinutes
+		report.Lateness.MaxLateMinutes = max(report.Lateness.MaxLateMinutes, log.LateMinutes)
+	}
+	if log.EarlyLeaveMinutes > 0 {
+		employee.EarlyLeaveDays++
+		employee.EarlyLeaveMinutes += log.EarlyLeaveMinutes
+		day.EarlyLeave++
+		report.Lateness.EarlyLeaveDays++
+	}
+	if log.WorkedMinutes != nil {
+		employee.WorkedMinutes += *log.WorkedMinutes
+		day.WorkedMinutes += *log.WorkedMinutes
+	}
+	employee.OvertimeMinutes += log.OvertimeMinutes
+	day.OvertimeMinutes += log.OvertimeMinutes
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	utils "employee-attendance-system/internal/util"
+	"fmt"
+	"strconv"
+)
+
+// Tata letak laporan PDF (point)
+const (
+	reportMargin     = 40.0
+	reportLineHeight = 14.0
+	reportFontSize   = 9.0
+)
+
+type reportColumn struct {
+	Title string
+	Width float64
+	Right bool // rata kanan untuk angka
+}
+
+// reportPDF menulis laporan dari atas ke bawah dan membuat halaman baru saat ruang habis
+type reportPDF struct {
+	doc *utils.PDFDocument
+	y   float64
+}
+
+func newReportPDF() *reportPDF {
+	p := &reportPDF{doc: utils.NewPDFDocument()}
+	p.newPage()
+	return p
+}
+
+func (p *reportPDF) newPage() {
+	p.doc.AddPage()
+	p.y = utils.PDFPageHeight - reportMargin
+}
+
+// ensure memastikan masih ada ruang setinggi h, selain itu pindah ke halaman baru
+func (p *reportPDF) ensure(h float64) bool {
+	if p.y-h < reportMargin+reportLineHeight { // sisakan ruang untuk nomor halaman
+		p.newPage()
+		return true
+	}
+	return false
+}
+
+func (p *reportPDF) line(size float64, bold bool, text string) {
+	p.ensure(size + 4)
+	p.y -= size + 4
+	p.doc.Text(reportMargin, p.y, size, bold, text)
+}
+
+func (p *reportPDF) heading(text string) {
+	p.ensure(reportLineHeight * 4) // jangan biarkan judul sendirian di bawah halaman
+	p.y -= reportLineHeight / 2
+	p.line(11, true, text)
+	p.y -= 4
+}
+
+// table menulis tabel dengan header yang diulang di setiap halaman
+func (p *reportPDF) table(columns []reportColumn, rows [][]string) {
+	p.tableHeader(columns)
+	for _, row := range rows {
+		if p.ensure(reportLineHeight) {
+			p.tableHeader(columns)
+		}
+		p.y -= reportLineHeight
+		p.tableRow(columns, row, false)
+	}
+}
+
+func (p *reportPDF) tableHeader(columns []reportColumn) {
+	p.ensure(reportLineHeight * 2)
+	p.y -= reportLineHeight
+	titles := make([]string, len(columns))
+	for i, c := range columns {
+		titles[i] = c.Title
+	}
+	p.tableRow(columns, titles, true)
+	p.doc.Line(reportMargin, p.y-4, utils.PDFPageWidth-reportMargin, p.y-4)
+	p.y -= 2
+}
+
+func (p *reportPDF) tableRow(columns []reportColumn, values []string, bold bool) {
+	x := reportMargin
+	for i, c := range columns {
+		text := fitText(values[i], c.Width-4, reportFontSize)
+		tx := x
+		if c.Right {
+			tx = x + c.Width - 4 - textWidth(text, reportFontSize)
+		}
+		p.doc.Text(tx, p.y, reportFontSize, bold, text)
+		x += c.Width
+	}
+}
+
+// textWidth memperkirakan lebar teks Helvetica (rata-rata setengah ukuran font per karakter)
+func textWidth(text string, size float64) float64 {
+	return float64(len([]rune(text))) * size * 0.5
+}
+
+// fitText memotong teks yang lebih lebar dari kolom
+func fitText(text string, width, size float64) string {
+	runes := []rune(text)
+	maxChars := int(width / (size * 0.5))
+	if len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 3 {
+		return string(runes[:max(maxChars, 0)])
+	}
+	return string(runes[:maxChars-3]) + "..."
+}
+
+// formatMinutes menampilkan menit sebagai jam:menit, e.g. 125 -> "2:05"
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// RenderAttendanceReportPDF membuat PDF: ringkasan per karyawan, statistik keterlambatan,
+// rekap harian, lalu kolom tanda tangan untuk sign-off
+func (u *attendanceUseCase) RenderAttendanceReportPDF(report *dto.AttendanceReportResponse) ([]byte, error) {
+	p := newReportPDF()
+
+	p.line(16, true, "Attendance Report")
+	p.y -= 4
+	scope := "Department"
+	if report.Scope == "employee" {
+		scope = "Employee"
+	}
+	p.line(reportFontSize+1, false, fmt.Sprintf("%s: %s", scope, report.Title))
+	p.line(reportFontSize+1, false, fmt.Sprintf("Period: %s to %s", report.StartDate, report.EndDate))
+	p.line(reportFontSize+1, false, fmt.Sprintf("Generated: %s", report.GeneratedAt.Format(dateTimeLayout)))
+
+	p.heading("Summary")
+	summary := make([][]string, 0, len(report.Employees))
+	for _, e := range report.Employees {
+		summary = append(summary, []string{
+			e.EmployeeCode, e.FullName,
+			strconv.Itoa(e.PresentDays), strconv.Itoa(e.AbsentDays), strconv.Itoa(e.LeaveDays),
+			strconv.Itoa(e.LateDays), strconv.Itoa(e.LateMinutes), strconv.Itoa(e.EarlyLeaveDays),
+			formatMinutes(e.WorkedMinutes), formatMinutes(e.OvertimeMinutes),
+		})
+	}
+	if len(summary) == 0 {
+		p.line(reportFontSize, false, "No attendance records in this period.")
+	} else {
+		p.table([]reportColumn{
+			{Title: "Code", Width: 60},
+			{Title: "Name", Width: 125},
+			{Title: "Present", Width: 40, Right: true},
+			{Title: "Absent", Width: 38, Right: true},
+			{Title: "Leave", Width: 34, Right: true},
+			{Title: "Late", Width: 30, Right: true},
+			{Title: "Late min", Width: 44, Right: true},
+			{Title: "Early", Width: 34, Right: true},
+			{Title: "Worked", Width: 55, Right: true},
+			{Title: "Overtime", Width: 55, Right: true},
+		}, summary)
+	}
+
+	p.heading("Lateness")
+	l := report.Lateness
+	p.line(reportFontSize, false, fmt.Sprintf("Present days: %d", l.PresentDays))
+	p.line(reportFontSize, false, fmt.Sprintf("Late days: %d (%.2f%%)", l.LateDays, l.LateRate))
+	p.line(reportFontSize, false, fmt.Sprintf("Total late: %d minutes, average %.2f minutes, longest %d minutes", l.TotalLateMinutes, l.AverageLateMinutes, l.MaxLateMinutes))
+	p.line(reportFontSize, false, fmt.Sprintf("Early leave days: %d", l.EarlyLeaveDays))
+
+	p.heading("Daily Breakdown")
+	daily := make([][]string, 0, len(report.Days))
+	for _, d := range report.Days {
+		daily = append(daily, []string{
+			d.Date,
+			strconv.Itoa(d.Present), strconv.Itoa(d.Late), strconv.Itoa(d.EarlyLeave),
+			strconv.Itoa(d.Absent), strconv.Itoa(d.OnLeave),
+			formatMinutes(d.WorkedMinutes), formatMinutes(d.OvertimeMinutes),
+		})
+	}
+	p.table([]reportColumn{
+		{Title: "Date", Width: 75},
+		{Title: "Present", Width: 55, Right: true},
+		{Title: "Late", Width: 55, Right: true},
+		{Title: "Early Leave", Width: 65, Right: true},
+		{Title: "Absent", Width: 55, Right: true},
+		{Title: "On Leave", Width: 55, Right: true},
+		{Title: "Worked", Width: 75, Right: true},
+		{Title: "Overtime", Width: 75, Right: true},
+	}, daily)
+
+	// Kolom tanda tangan
+	p.ensure(reportLineHeight * 7)
+	p.y -= reportLineHeight * 2
+	p.doc.Text(reportMargin, p.y, reportFontSize, true, "Prepared by")
+	p.doc.Text(utils.PDFPageWidth/2, p.y, reportFontSize, true, "Approved by")
+	p.y -= reportLineHeight * 4
+	p.doc.Line(reportMargin, p.y, reportMargin+180, p.y)
+	p.doc.Line(utils.PDFPageWidth/2, p.y, utils.PDFPageWidth/2+180, p.y)
+	p.y -= reportLineHeight
+	p.doc.Text(reportMargin, p.y, reportFontSize, false, "Name / Date")
+	p.doc.Text(utils.PDFPageWidth/2, p.y, reportFontSize, false, "Name / Date")
+
+	// Nomor halaman ditulis terakhir karena total halaman baru diketahui
+	pages := p.doc.PageCount()
+	for i := range pages {
+		p.doc.SelectPage(i)
+		p.doc.Text(reportMargin, reportMargin/2, 8, false, fmt.Sprintf("Attendance Report - %s", report.Title))
+		footer := fmt.Sprintf("Page %d of %d", i+1, pages)
+		p.doc.Text(utils.PDFPageWidth-reportMargin-textWidth(footer, 8), reportMargin/2, 8, false, footer)
+	}
+	return p.doc.Bytes(), nil
+}
@@ -35,6 +35,7 @@ type authUseCase struct {
 	log      *logrus.Logger
 	config   *viper.Viper
 	jwtUtils *utils.JWTConfig
+	webhooks WebhookPublisher
 }
 
 func NewAuthUseCase(
@@ -43,9 +44,10 @@ func NewAuthUseCase(
 	validate *validator.Validate,
 	config *viper.Viper,
 	jwtUtils *utils.JWTConfig,
+	webhooks WebhookPublisher,
 ) AuthUseCase {
 	return &authUseCase{repo: repo, log: log, validate: validate, config: config,
-		jwtUtils: jwtUtils}
+		jwtUtils: jwtUtils, webhooks: webhooks}
 
 }
 func GenerateEmployeeCode() string {
@@ -76,6 +78,12 @@ func (u *authUseCase) Signup(ctx context.Context, email, password, fullName stri
 	if err := u.repo.CreateUser(user, profile, security, role); err != nil {
 		return nil, err
 	}
+	u.webhooks.Publish(domain.WebhookEventUserSignup, dto.UserSignupEvent{
+		UserID:       user.ID,
+		Email:        user.Email,
+		FullName:     profile.FullName,
+		EmployeeCode: profile.EmployeeCode,
+	})
 	return user, nil
 }
 
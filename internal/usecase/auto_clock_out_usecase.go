@@ -0,0 +1,127 @@
+// auto_clock_out_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/event"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AutoClockOutUseCase menutup attendance yang lupa clock out sesuai kebijakan departemen
+type AutoClockOutUseCase interface {
+	CloseForgottenClockOuts(ctx context.Context, now time.Time) (int, error)
+}
+
+type autoClockOutUseCase struct {
+	repo        repository.AttendanceRepository
+	profileRepo repository.UserRepository
+	periods     payPeriodGuard
+	events      *event.Broker
+	webhooks    WebhookPublisher
+	log         *logrus.Logger
+}
+
+func NewAutoClockOutUseCase(repo repository.AttendanceRepository, profileRepo repository.UserRepository, payrollRepo repository.PayrollRepository, events *event.Broker, webhooks WebhookPublisher, log *logrus.Logger) AutoClockOutUseCase {
+	return &autoClockOutUseCase{repo: repo, profileRepo: profileRepo, periods: payPeriodGuard{repo: payrollRepo}, events: events, webhooks: webhooks, log: log}
+}
+
+// CloseForgottenClockOuts mengisi ClockOut dengan waktu dari kebijakan (bukan waktu job berjalan),
+// menandai attendance sebagai AutoClosed dan mencatat history dengan actor "system".
+func (u *autoClockOutUseCase) CloseForgottenClockOuts(ctx context.Context, now time.Time) (int, error) {
+	attendances, err := u.repo.FindOpenAttendancesWithAutoClockOut()
+	if err != nil {
+		return 0, err
+	}
+
+	// Timestamp dari database adalah jam dinding tanpa zona, jadi bandingkan sebagai jam dinding
+	nowWall := wallClock(now)
+	profiles := make(map[string]*domain.UserProfile)
+	closed := 0
+	for _, attendance := range attendances {
+		profile, ok := profiles[attendance.EmployeeCode]
+		if !ok {
+			profile, err = u.profileRepo.FindUserProfileByEmployeeCode(attendance.EmployeeCode)
+			if err != nil {
+				return closed, err
+			}
+			profiles[attendance.EmployeeCode] = profile
+		}
+		if profile == nil || profile.Department == nil {
+			continue
+		}
+		dept := profile.Department
+
+		closeAt, ok := autoClockOutAt(dept, attendance)
+		if !ok || nowWall.Before(closeAt) {
+			continue
+		}
+		// Attendance di pay period terkunci dibiarkan open, harus dikoreksi setelah di-unlock
+		if err := u.periods.check(workDateOf(attendance)); err != nil {
+			u.log.WithField("attendance_id", attendance.AttendanceID).WithError(err).Warn("Skipping auto clock out")
+			continue
+		}
+
+		attendance.ClockOut = &closeAt
+		attendance.AutoClosed = true
+		history := &domain.AttendanceHistory{
+			EmployeeCode:   attendance.EmployeeCode,
+			AttendanceID:   attendance.AttendanceID,
+			DateAttendance: closeAt,
+			AttendanceType: domain.AttendanceTypeOut,
+			Description:    fmt.Sprintf("Auto clock out by system (%s policy)", dept.AutoClockOutMode),
+			Actor:          domain.ActorSystem,
+		}
+		ok, err = u.repo.AutoCloseAttendance(attendance, history)
+		if err != nil {
+			return closed, err
+		}
+		// Karyawan sudah clock out sendiri sejak attendance dimuat
+		if !ok {
+			continue
+		}
+		publishAttendanceEvent(u.events, u.webhooks, dto.AttendanceEventClockOut, profile, attendance, domain.ActorSystem)
+		closed++
+	}
+
+	if closed > 0 {
+		u.log.WithField("count", closed).Info("Auto clocked out forgotten attendances")
+	}
+	return closed, nil
+}
+
+// autoClockOutAt menghitung waktu auto clock out (jam dinding) untuk attendance yang masih open.
+// fixed_time memakai jam pada hari kerja, digeser ke hari berikutnya jika tidak setelah clock in.
+func autoClockOutAt(dept *domain.Department, attendance *domain.Attendance) (time.Time, bool) {
+	if attendance.ClockIn == nil {
+		return time.Time{}, false
+	}
+	clockIn := wallClock(*attendance.ClockIn)
+
+	switch dept.AutoClockOutMode {
+	case domain.AutoClockOutFixedTime:
+		if dept.AutoClockOutTime == nil {
+			return time.Time{}, false
+		}
+		workDate := dateOf(clockIn)
+		if attendance.WorkDate != nil {
+			workDate = dateIn(*attendance.WorkDate, time.UTC)
+		}
+		closeAt := on(workDate, *dept.AutoClockOutTime)
+		for !closeAt.After(clockIn) {
+			closeAt = closeAt.AddDate(0, 0, 1)
+		}
+		return closeAt, true
+	case domain.AutoClockOutAfterHours:
+		if dept.AutoClockOutHours <= 0 {
+			return time.Time{}, false
+		}
+		return clockIn.Add(time.Duration(dept.AutoClockOutHours) * time.Hour), true
+	}
+	return time.Time{}, false
+}
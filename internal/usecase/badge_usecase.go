@@ -0,0 +1,460 @@
+// badge_usecase.go
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type BadgeUseCase interface {
+	CreateBadge(ctx context.Context, adminID uuid.UUID, req dto.CreateBadgeRequest) (*dto.BadgeResponse, error)
+	GetBadges(ctx context.Context, req dto.ListBadgesRequest) ([]*dto.BadgeResponse, int64, error)
+	GetMyBadges(ctx context.Context, userID uuid.UUID) ([]*dto.BadgeResponse, error)
+	DeactivateBadge(ctx context.Context, adminID uuid.UUID, id uuid.UUID, req dto.DeactivateBadgeRequest) (*dto.BadgeResponse, error)
+	ReportBadgeLost(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID, req dto.DeactivateBadgeRequest) (*dto.BadgeResponse, error)
+	ReactivateBadge(ctx context.Context, id uuid.UUID) (*dto.BadgeResponse, error)
+	RotateBadgeSecret(ctx context.Context, id uuid.UUID) (*dto.BadgeResponse, error)
+
+	CreateReader(ctx context.Context, adminID uuid.UUID, req dto.CreateBadgeReaderRequest) (*dto.BadgeReaderResponse, error)
+	GetReaders(ctx context.Context, page, limit int) ([]*dto.BadgeReaderResponse, int64, error)
+	UpdateReader(ctx context.Context, id uuid.UUID, req dto.UpdateBadgeReaderRequest) (*dto.BadgeReaderResponse, error)
+	DeleteReader(ctx context.Context, id uuid.UUID) error
+	RotateReaderKey(ctx context.Context, id uuid.UUID) (*dto.BadgeReaderResponse, error)
+	AuthenticateReader(ctx context.Context, apiKey string) (*domain.BadgeReader, error)
+
+	Tap(ctx context.Context, readerID uuid.UUID, req dto.BadgeTapRequest) (*dto.BadgeTapResponse, error)
+}
+
+// Kartu yang ditempel lagi dalam rentang debounce diabaikan, supaya tap ganda di gerbang
+// tidak langsung clock out setelah clock in
+const badgeDefaultDebounce = time.Minute
+
+type badgeUseCase struct {
+	repo         repository.BadgeRepository
+	attRepo      repository.AttendanceRepository
+	profileRepo  repository.UserRepository
+	locationRepo repository.LocationRepository
+	attendance   AttendanceUseCase
+	keys         deviceKeys[*domain.BadgeReader]
+	debounce     time.Duration
+	log          *logrus.Logger
+	validate     *validator.Validate
+}
+
+func NewBadgeUseCase(repo repository.BadgeRepository, attRepo repository.AttendanceRepository, profileRepo repository.UserRepository, locationRepo repository.LocationRepository, attendance AttendanceUseCase, config *viper.Viper, log *logrus.Logger, validate *validator.Validate) BadgeUseCase {
+	debounce := config.GetDuration("badge.debounce")
+	if debounce <= 0 {
+		debounce = badgeDefaultDebounce
+	}
+	keys := deviceKeys[*domain.BadgeReader]{
+		name:       "badge reader",
+		prefix:     "reader_",
+		findByHash: repo.FindReaderByKeyHash,
+		touch: func(reader *domain.BadgeReader, seenAt time.Time) error {
+			return repo.TouchReader(reader.ID, seenAt)
+		},
+		log: log,
+	}
+	return &badgeUseCase{repo: repo, attRepo: attRepo, profileRepo: profileRepo, locationRepo: locationRepo,
+		attendance: attendance, keys: keys, debounce: debounce, log: log, validate: validate}
+}
+
+func (u *badgeUseCase) CreateBadge(ctx context.Context, adminID uuid.UUID, req dto.CreateBadgeRequest) (*dto.BadgeResponse, error) {
+	profile, err := u.profileRepo.FindUserProfileByEmployeeCode(req.EmployeeCode)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+	badge := &domain.BadgeCard{
+		Kind:         domain.BadgeKindRFID,
+		EmployeeCode: profile.EmployeeCode,
+		Status:       domain.BadgeStatusActive,
+		Label:        req.Label,
+		IssuedBy:     &adminID,
+	}
+
+	if req.Kind == string(domain.BadgeKindQR) {
+		if req.UID != "" {
+			return nil, fmt.Errorf("qr badges do not have a uid")
+		}
+		// Satu karyawan hanya punya satu badge QR aktif; ganti kode lewat rotate-secret
+		if _, err := u.repo.FindActiveBadge(profile.EmployeeCode, domain.BadgeKindQR); err == nil {
+			return nil, fmt.Errorf("employee already has an active qr badge")
+		} else if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		badge.Kind = domain.BadgeKindQR
+		if badge.Secret, err = generateBadgeSecret(); err != nil {
+			return nil, err
+		}
+	} else {
+		if req.UID == "" {
+			return nil, fmt.Errorf("badge uid is required")
+		}
+		uid, err := normalizeBadgeUID(req.UID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := u.repo.FindBadgeByUID(uid); err == nil {
+			return nil, fmt.Errorf("badge uid already registered")
+		} else if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		badge.UID = &uid
+	}
+
+	if err := u.repo.CreateBadge(badge); err != nil {
+		return nil, err
+	}
+	return mapToBadgeResponse(badge), nil
+}
+
+func (u *badgeUseCase) GetBadges(ctx context.Context, req dto.ListBadgesRequest) ([]*dto.BadgeResponse, int64, error) {
+	badges, total, err := u.repo.FindBadges(req.EmployeeCode, req.Status, (req.Page-1)*req.Limit, req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mapToBadgeResponses(badges), total, nil
+}
+
+func (u *badgeUseCase) GetMyBadges(ctx context.Context, userID uuid.UUID) ([]*dto.BadgeResponse, error) {
+	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+	badges, _, err := u.repo.FindBadges(profile.EmployeeCode, "", 0, 100)
+	if err != nil {
+		return nil, err
+	}
+	return mapToBadgeResponses(badges), nil
+}
+
+func (u *badgeUseCase) DeactivateBadge(ctx context.Context, adminID uuid.UUID, id uuid.UUID, req dto.DeactivateBadgeRequest) (*dto.BadgeResponse, error) {
+	badge, err := u.findBadge(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.deactivate(badge, domain.BadgeStatusDeactivated, adminID, req.Reason)
+}
+
+// ReportBadgeLost bisa dilakukan pemilik kartu sendiri supaya kartu yang hilang segera
+// tidak bisa dipakai orang lain, tanpa menunggu admin
+func (u *badgeUseCase) ReportBadgeLost(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID, req dto.DeactivateBadgeRequest) (*dto.BadgeResponse, error) {
+	badge, err := u.findBadge(id)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" {
+		profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+		if err != nil || profile == nil {
+			return nil, fmt.Errorf("profile not found")
+		}
+		if profile.EmployeeCode != badge.EmployeeCode {
+			return nil, fmt.Errorf("access denied")
+		}
+	}
+	return u.deactivate(badge, domain.BadgeStatusLost, userID, req.Reason)
+}
+
+func (u *badgeUseCase) ReactivateBadge(ctx context.Context, id uuid.UUID) (*dto.BadgeResponse, error) {
+	badge, err := u.findBadge(id)
+	if err != nil {
+		return nil, err
+	}
+	if badge.Status == domain.BadgeStatusActive {
+		return nil, fmt.Errorf("badge is already active")
+	}
+	// Kode QR lama mungkin sudah tersebar selama badge hilang, jadi badge QR selalu dapat secret baru
+	if badge.Kind == domain.BadgeKindQR {
+		if badge.Secret, err = generateBadgeSecret(); err != nil {
+			return nil, err
+		}
+	}
+	badge.Status = domain.BadgeStatusActive
+	badge.DeactivatedAt = nil
+	badge.DeactivatedBy = nil
+	badge.DeactivatedReason = ""
+	if err := u.repo.UpdateBadge(badge); err != nil {
+		return nil, err
+	}
+	return mapToBadgeResponse(badge), nil
+}
+
+// RotateBadgeSecret mengganti secret badge QR; kode yang sudah dicetak atau di-screenshot
+// langsung tidak berlaku dan karyawan mengambil kode baru dari GET /kiosk/badge
+func (u *badgeUseCase) RotateBadgeSecret(ctx context.Context, id uuid.UUID) (*dto.BadgeResponse, error) {
+	badge, err := u.findBadge(id)
+	if err != nil {
+		return nil, err
+	}
+	if badge.Kind != domain.BadgeKindQR {
+		return nil, fmt.Errorf("only qr badges have a secret")
+	}
+	if badge.Secret, err = generateBadgeSecret(); err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdateBadge(badge); err != nil {
+		return nil, err
+	}
+	return mapToBadgeResponse(badge), nil
+}
+
+func (u *badgeUseCase) deactivate(badge *domain.BadgeCard, status domain.BadgeStatus, actorID uuid.UUID, reason string) (*dto.BadgeResponse, error) {
+	if badge.Status != domain.BadgeStatusActive {
+		return nil, fmt.Errorf("badge is already deactivated")
+	}
+	now := time.Now()
+	badge.Status = status
+	badge.DeactivatedAt = &now
+	badge.DeactivatedBy = &actorID
+	badge.DeactivatedReason = reason
+	if err := u.repo.UpdateBadge(badge); err != nil {
+		return nil, err
+	}
+	return mapToBadgeResponse(badge), nil
+}
+
+func (u *badgeUseCase) CreateReader(ctx context.Context, adminID uuid.UUID, req dto.CreateBadgeReaderRequest) (*dto.BadgeReaderResponse, error) {
+	if err := u.checkLocation(req.OfficeLocationID); err != nil {
+		return nil, err
+	}
+	reader := &domain.BadgeReader{
+		Name:             req.Name,
+		OfficeLocationID: req.OfficeLocationID,
+		CreatedBy:        &adminID,
+		DeviceKey:        domain.DeviceKey{IsActive: true},
+	}
+	apiKey, err := u.keys.issue(reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.CreateReader(reader); err != nil {
+		return nil, err
+	}
+	res := mapToBadgeReaderResponse(reader)
+	res.APIKey = apiKey
+	return res, nil
+}
+
+func (u *badgeUseCase) GetReaders(ctx context.Context, page, limit int) ([]*dto.BadgeReaderResponse, int64, error) {
+	readers, total, err := u.repo.FindReaders((page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.BadgeReaderResponse, len(readers))
+	for i, r := range readers {
+		res[i] = mapToBadgeReaderResponse(r)
+	}
+	return res, total, nil
+}
+
+func (u *badgeUseCase) UpdateReader(ctx context.Context, id uuid.UUID, req dto.UpdateBadgeReaderRequest) (*dto.BadgeReaderResponse, error) {
+	reader, err := u.findReader(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkLocation(req.OfficeLocationID); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		reader.Name = req.Name
+	}
+	if req.OfficeLocationID != nil {
+		reader.OfficeLocationID = req.OfficeLocationID
+	}
+	setDeviceActive(reader, req.IsActive)
+	if err := u.repo.UpdateReader(reader); err != nil {
+		return nil, err
+	}
+	return mapToBadgeReaderResponse(reader), nil
+}
+
+func (u *badgeUseCase) DeleteReader(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.findReader(id); err != nil {
+		return err
+	}
+	return u.repo.DeleteReader(id)
+}
+
+// RotateReaderKey mengganti API key reader; key lama langsung tidak berlaku
+func (u *badgeUseCase) RotateReaderKey(ctx context.Context, id uuid.UUID) (*dto.BadgeReaderResponse, error) {
+	reader, err := u.findReader(id)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := u.keys.rotate(reader, u.repo.UpdateReader)
+	if err != nil {
+		return nil, err
+	}
+	res := mapToBadgeReaderResponse(reader)
+	res.APIKey = apiKey
+	return res, nil
+}
+
+func (u *badgeUseCase) AuthenticateReader(ctx context.Context, apiKey string) (*domain.BadgeReader, error) {
+	return u.keys.authenticate(apiKey, time.Now())
+}
+
+// Tap memproses kartu yang ditempel di reader: clock out jika karyawan sedang clock in,
+// selain itu clock in
+func (u *badgeUseCase) Tap(ctx context.Context, readerID uuid.UUID, req dto.BadgeTapRequest) (*dto.BadgeTapResponse, error) {
+	uid, err := normalizeBadgeUID(req.UID)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := u.findReader(readerID)
+	if err != nil {
+		return nil, err
+	}
+	badge, err := u.repo.FindBadgeByUID(uid)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			u.log.WithFields(logrus.Fields{"reader_id": reader.ID, "uid": uid}).Warn("Unknown badge tapped")
+			return nil, fmt.Errorf("badge not registered")
+		}
+		return nil, err
+	}
+	if badge.Status != domain.BadgeStatusActive {
+		u.log.WithFields(logrus.Fields{"reader_id": reader.ID, "badge_id": badge.ID, "status": badge.Status}).Warn("Deactivated badge tapped")
+		return nil, fmt.Errorf("badge is deactivated")
+	}
+	profile, err := u.profileRepo.FindUserProfileByEmployeeCode(badge.EmployeeCode)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+
+	now := time.Now()
+	claimed, err := u.repo.ClaimBadgeScan(badge.ID, now, u.debounce)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("badge was tapped too recently")
+	}
+
+	open, err := u.attRepo.FindOpenAttendance(profile.EmployeeCode, now.Add(-maxShiftDuration))
+	if err != nil {
+		return nil, err
+	}
+	source := dto.ClockSource{ReaderID: &reader.ID, OfficeLocationID: reader.OfficeLocationID}
+	action := "clock_in"
+	var attendance *dto.AttendanceResponse
+	if open != nil {
+		action = "clock_out"
+		attendance, err = u.attendance.ClockOut(ctx, profile.SourceUserID, dto.ClockOutRequest{Source: source})
+	} else {
+		attendance, err = u.attendance.ClockIn(ctx, profile.SourceUserID, dto.ClockInRequest{Source: source})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dto.BadgeTapResponse{
+		EmployeeCode: profile.EmployeeCode,
+		FullName:     profile.FullName,
+		Action:       action,
+		Attendance:   attendance,
+	}, nil
+}
+
+func (u *badgeUseCase) checkLocation(officeLocationID *uuid.UUID) error {
+	if officeLocationID == nil {
+		return nil
+	}
+	if _, err := u.locationRepo.FindLocationByID(*officeLocationID); err != nil {
+		return fmt.Errorf("office location not found")
+	}
+	return nil
+}
+
+func (u *badgeUseCase) findBadge(id uuid.UUID) (*domain.BadgeCard, error) {
+	badge, err := u.repo.FindBadgeByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("badge not found")
+		}
+		return nil, err
+	}
+	return badge, nil
+}
+
+func (u *badgeUseCase) findReader(id uuid.UUID) (*domain.BadgeReader, error) {
+	reader, err := u.repo.FindReaderByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("badge reader not found")
+		}
+		return nil, err
+	}
+	return reader, nil
+}
+
+// normalizeBadgeUID menyeragamkan UID dari reader yang berbeda format ("04:a2:3b", "04-A2-3B", "04a23b")
+func normalizeBadgeUID(raw string) (string, error) {
+	uid := strings.ToUpper(strings.NewReplacer(":", "", "-", "", " ", "").Replace(strings.TrimSpace(raw)))
+	if len(uid) < 4 || len(uid)%2 != 0 {
+		return "", fmt.Errorf("invalid badge uid")
+	}
+	if _, err := hex.DecodeString(uid); err != nil {
+		return "", fmt.Errorf("invalid badge uid")
+	}
+	return uid, nil
+}
+
+func generateBadgeSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func mapToBadgeResponses(badges []*domain.BadgeCard) []*dto.BadgeResponse {
+	res := make([]*dto.BadgeResponse, len(badges))
+	for i, b := range badges {
+		res[i] = mapToBadgeResponse(b)
+	}
+	return res
+}
+
+func mapToBadgeResponse(b *domain.BadgeCard) *dto.BadgeResponse {
+	res := &dto.BadgeResponse{
+		ID:                b.ID,
+		Kind:              string(b.Kind),
+		EmployeeCode:      b.EmployeeCode,
+		Status:            string(b.Status),
+		Label:             b.Label,
+		LastScanAt:        b.LastScanAt,
+		DeactivatedAt:     b.DeactivatedAt,
+		DeactivatedReason: b.DeactivatedReason,
+		CreatedAt:         b.CreatedAt,
+		UpdatedAt:         b.UpdatedAt,
+	}
+	if b.UID != nil {
+		res.UID = *b.UID
+	}
+	return res
+}
+
+func mapToBadgeReaderResponse(r *domain.BadgeReader) *dto.BadgeReaderResponse {
+	return &dto.BadgeReaderResponse{
+		ID:               r.ID,
+		Name:             r.Name,
+		OfficeLocationID: r.OfficeLocationID,
+		IsActive:         r.IsActive,
+		LastSeenAt:       r.LastSeenAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
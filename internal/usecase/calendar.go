@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+	"time"
+)
+
+// workCalendar menentukan apakah suatu tanggal adalah hari kerja seorang karyawan.
+// Karyawan dengan shift mengikuti weekdays shift, selain itu Senin - Jumat.
+// Hari libur (nasional / departemen karyawan) tidak pernah dihitung hari kerja.
+type workCalendar struct {
+	shiftRepo   repository.ShiftRepository
+	holidayRepo repository.HolidayRepository
+}
+
+func newWorkCalendar(shiftRepo repository.ShiftRepository, holidayRepo repository.HolidayRepository) *workCalendar {
+	return &workCalendar{shiftRepo: shiftRepo, holidayRepo: holidayRepo}
+}
+
+// Schedule mengambil jadwal kerja karyawan pada hari kerja day: shift aktif, atau jam departemen
+func (c *workCalendar) Schedule(profile *domain.UserProfile, day time.Time) (*workSchedule, error) {
+	assignment, err := c.shiftRepo.FindActiveAssignment(profile.EmployeeCode, dateOf(day))
+	if err != nil {
+		return nil, err
+	}
+	return scheduleOn(profile, assignment, day)
+}
+
+// HolidayOn mengembalikan hari libur yang berlaku untuk karyawan pada tanggal day, nil jika bukan hari libur
+func (c *workCalendar) HolidayOn(profile *domain.UserProfile, day time.Time) (*domain.Holiday, error) {
+	return c.holidayRepo.FindHolidayOn(dateOf(day), profile.DepartmentID)
+}
+
+func (c *workCalendar) IsWorkingDay(profile *domain.UserProfile, day time.Time) (bool, error) {
+	holiday, err := c.HolidayOn(profile, day)
+	if err != nil {
+		return false, err
+	}
+	if holiday != nil {
+		return false, nil
+	}
+
+	assignment, err := c.shiftRepo.FindActiveAssignment(profile.EmployeeCode, dateOf(day))
+	if err != nil {
+		return false, err
+	}
+	return workingWeekday(assignment, day), nil
+}
+
+// WorkingDays mengembalikan semua hari kerja di rentang [start, end]
+func (c *workCalendar) WorkingDays(profile *domain.UserProfile, start, end time.Time) ([]time.Time, error) {
+	var days []time.Time
+	for day := dateOf(start); !day.After(end); day = day.AddDate(0, 0, 1) {
+		ok, err := c.IsWorkingDay(profile, day)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// Load memuat hari libur dan assignment shift semua profile untuk rentang [start, end] dengan
+// dua query, untuk perulangan per hari (timesheet, absence job) yang tidak boleh query per tanggal
+func (c *workCalendar) Load(profiles []*domain.UserProfile, start, end time.Time) (*loadedCalendar, error) {
+	holidays, err := c.holidayRepo.FindHolidaysBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		codes = append(codes, profile.EmployeeCode)
+	}
+	assignments, err := c.shiftRepo.FindAssignmentsBetween(codes, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := &loadedCalendar{
+		holidays:    make(map[string][]*domain.Holiday),
+		assignments: make(map[string][]*domain.ShiftAssignment),
+	}
+	for _, holiday := range holidays {
+		key := holiday.Date.Format(dateLayout)
+		loaded.holidays[key] = append(loaded.holidays[key], holiday)
+	}
+	for _, assignment := range assignments {
+		loaded.assignments[assignment.EmployeeCode] = append(loaded.assignments[assignment.EmployeeCode], assignment)
+	}
+	return loaded, nil
+}
+
+// loadedCalendar adalah workCalendar yang datanya sudah dimuat oleh Load. Aturannya sama,
+// hanya dibaca dari memory; tanggal di luar rentang Load dianggap tanpa libur dan shift.
+type loadedCalendar struct {
+	holidays    map[string][]*domain.Holiday         // per tanggal, libur departemen lebih dulu
+	assignments map[string][]*domain.ShiftAssignment // per employee code, effective_from terbaru dulu
+}
+
+func (c *loadedCalendar) HolidayOn(profile *domain.UserProfile, day time.Time) *domain.Holiday {
+	var national *domain.Holiday
+	for _, holiday := range c.holidays[day.Format(dateLayout)] {
+		if holiday.DepartmentID == nil {
+			if national == nil {
+				national = holiday
+			}
+			continue
+		}
+		if profile.DepartmentID != nil && *holiday.DepartmentID == *profile.DepartmentID {
+			return holiday
+		}
+	}
+	return national
+}
+
+func (c *loadedCalendar) activeAssignment(profile *domain.UserProfile, day time.Time) *domain.ShiftAssignment {
+	date := day.Format(dateLayout)
+	for _, assignment := range c.assignments[profile.EmployeeCode] {
+		if assignment.EffectiveFrom.Format(dateLayout) <= date &&
+			(assignment.EffectiveTo == nil || assignment.EffectiveTo.Format(dateLayout) >= date) {
+			return assignment
+		}
+	}
+	return nil
+}
+
+func (c *loadedCalendar) IsWorkingDay(profile *domain.UserProfile, day time.Time) bool {
+	if c.HolidayOn(profile, day) != nil {
+		return false
+	}
+	return workingWeekday(c.activeAssignment(profile, day), day)
+}
+
+func (c *loadedCalendar) Schedule(profile *domain.UserProfile, day time.Time) (*workSchedule, error) {
+	return scheduleOn(profile, c.activeAssignment(profile, day), day)
+}
+
+// scheduleOn memilih jadwal dari shift aktif, atau jam departemen jika tidak ada shift
+func scheduleOn(profile *domain.UserProfile, assignment *domain.ShiftAssignment, day time.Time) (*workSchedule, error) {
+	if assignment != nil && assignment.Shift != nil {
+		return scheduleFromShift(assignment.Shift, day), nil
+	}
+	if profile.Department == nil {
+		return nil, fmt.Errorf("no department assigned")
+	}
+	return scheduleFromDepartment(profile.Department), nil
+}
+
+// workingWeekday: weekdays shift aktif, atau Senin - Jumat jika tidak ada shift
+func workingWeekday(assignment *domain.ShiftAssignment, day time.Time) bool {
+	if assignment != nil && assignment.Shift != nil {
+		return assignment.Shift.AppliesOn(day.Weekday())
+	}
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+// joinedDate adalah tanggal pertama karyawan bisa dihitung hadir/absent, dalam zona loc.
+// Belum ada kolom tanggal masuk kerja, jadi dipakai tanggal (jam dinding server) profile dibuat.
+func joinedDate(profile *domain.UserProfile, loc *time.Location) time.Time {
+	return dateIn(profile.CreatedAt.Local(), loc)
+}
@@ -0,0 +1,402 @@
+// correction_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/event"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CorrectionUseCase interface {
+	SubmitCorrection(ctx context.Context, userID uuid.UUID, req dto.CreateCorrectionRequest) (*dto.CorrectionResponse, error)
+	GetCorrection(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.CorrectionResponse, error)
+	ListCorrections(ctx context.Context, userID uuid.UUID, role string, req dto.ListCorrectionsRequest) ([]*dto.CorrectionResponse, int64, error)
+	ApproveCorrection(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewCorrectionRequest) (*dto.CorrectionResponse, error)
+	RejectCorrection(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewCorrectionRequest) (*dto.CorrectionResponse, error)
+	CancelCorrection(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.CorrectionResponse, error)
+}
+
+type correctionUseCase struct {
+	repo     repository.CorrectionRepository
+	attRepo  repository.AttendanceRepository
+	userRepo repository.UserRepository
+	periods  payPeriodGuard
+	events   *event.Broker
+	webhooks WebhookPublisher
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewCorrectionUseCase(repo repository.CorrectionRepository, attRepo repository.AttendanceRepository, userRepo repository.UserRepository, payrollRepo repository.PayrollRepository, events *event.Broker, webhooks WebhookPublisher, log *logrus.Logger, validate *validator.Validate) CorrectionUseCase {
+	return &correctionUseCase{repo: repo, attRepo: attRepo, userRepo: userRepo, periods: payPeriodGuard{repo: payrollRepo}, events: events, webhooks: webhooks, log: log, validate: validate}
+}
+
+const dateTimeLayout = "2006-01-02 15:04:05"
+
+func (u *correctionUseCase) SubmitCorrection(ctx context.Context, userID uuid.UUID, req dto.CreateCorrectionRequest) (*dto.CorrectionResponse, error) {
+	profile, err := u.userRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	workDate, err := time.ParseInLocation(dateLayout, req.WorkDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid work_date: %w", err)
+	}
+	if workDate.After(dateOf(time.Now())) {
+		return nil, fmt.Errorf("cannot correct a future work date")
+	}
+	if err := u.periods.check(workDate); err != nil {
+		return nil, err
+	}
+
+	var clockIn, clockOut *time.Time
+	if req.ClockIn != "" {
+		t, err := time.ParseInLocation(dateTimeLayout, req.ClockIn, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clock_in: %w", err)
+		}
+		clockIn = &t
+	}
+	if req.ClockOut != "" {
+		t, err := time.ParseInLocation(dateTimeLayout, req.ClockOut, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clock_out: %w", err)
+		}
+		clockOut = &t
+	}
+
+	attendanceID := attendanceIDFor(profile.EmployeeCode, workDate)
+	attendance, err := u.findAttendance(attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	correctionType := domain.CorrectionType(req.Type)
+	if err := checkCorrection(correctionType, workDate, attendance, clockIn, clockOut); err != nil {
+		return nil, err
+	}
+
+	pending, err := u.repo.HasPendingCorrection(attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, fmt.Errorf("a pending correction already exists for this work date")
+	}
+
+	correction := &domain.AttendanceCorrection{
+		EmployeeCode: profile.EmployeeCode,
+		AttendanceID: attendanceID,
+		WorkDate:     workDate,
+		Type:         correctionType,
+		ClockIn:      clockIn,
+		ClockOut:     clockOut,
+		Reason:       req.Reason,
+		Status:       domain.ApprovalPending,
+	}
+	if attendance != nil {
+		correction.OriginalClockIn = attendance.ClockIn
+		correction.OriginalClockOut = attendance.ClockOut
+	}
+	if err := u.repo.CreateCorrection(correction); err != nil {
+		return nil, err
+	}
+	return mapToCorrectionResponse(correction), nil
+}
+
+func (u *correctionUseCase) GetCorrection(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.CorrectionResponse, error) {
+	correction, err := u.findAccessibleCorrection(userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToCorrectionResponse(correction), nil
+}
+
+func (u *correctionUseCase) ListCorrections(ctx context.Context, userID uuid.UUID, role string, req dto.ListCorrectionsRequest) ([]*dto.CorrectionResponse, int64, error) {
+	employeeCode := ""
+	if role != string(domain.Admin) || req.UserID != nil {
+		target := userID
+		if role == string(domain.Admin) {
+			target = *req.UserID
+		}
+		profile, err := u.userRepo.FindUserProfileByUserID(target)
+		if err != nil || profile == nil {
+			return nil, 0, fmt.Errorf("user not found")
+		}
+		employeeCode = profile.EmployeeCode
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	corrections, total, err := u.repo.FindCorrections(employeeCode, req.Status, offset, req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]*dto.CorrectionResponse, len(corrections))
+	for i, c := range corrections {
+		res[i] = mapToCorrectionResponse(c)
+	}
+	return res, total, nil
+}
+
+// ApproveCorrection menerapkan koreksi ke Attendance dan mencatat history "adjustment"
+// beserta admin yang menyetujui. Validasi diulang karena attendance bisa berubah sejak pengajuan.
+func (u *correctionUseCase) ApproveCorrection(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewCorrectionRequest) (*dto.CorrectionResponse, error) {
+	correction, err := u.findPendingCorrection(id)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err := u.findAttendance(correction.AttendanceID)
+	if err != nil {
+		return nil, err
+	}
+	workDate := dateIn(correction.WorkDate, time.Local)
+	if err := u.periods.check(workDate); err != nil {
+		return nil, err
+	}
+	if err := checkCorrection(correction.Type, workDate, attendance, correction.ClockIn, correction.ClockOut); err != nil {
+		return nil, err
+	}
+
+	if attendance == nil {
+		attendance = &domain.Attendance{
+			EmployeeCode: correction.EmployeeCode,
+			AttendanceID: correction.AttendanceID,
+			WorkDate:     &workDate,
+			Status:       domain.AttendanceStatusPresent,
+		}
+	}
+	if attendance.Status == domain.AttendanceStatusAbsent {
+		attendance.Status = domain.AttendanceStatusPresent
+	}
+	var changes []string
+	if correction.ClockIn != nil {
+		changes = append(changes, fmt.Sprintf("clock in %s -> %s", formatCorrectionTime(attendance.ClockIn), formatCorrectionTime(correction.ClockIn)))
+		attendance.ClockIn = correction.ClockIn
+	}
+	if correction.ClockOut != nil {
+		changes = append(changes, fmt.Sprintf("clock out %s -> %s", formatCorrectionTime(attendance.ClockOut), formatCorrectionTime(correction.ClockOut)))
+		attendance.ClockOut = correction.ClockOut
+		attendance.AutoClosed = false
+	}
+
+	now := time.Now()
+	correction.Status = domain.ApprovalApproved
+	correction.ReviewedBy = &reviewerID
+	correction.ReviewedAt = &now
+	correction.ReviewNote = req.Note
+
+	history := &domain.AttendanceHistory{
+		EmployeeCode:   correction.EmployeeCode,
+		AttendanceID:   correction.AttendanceID,
+		DateAttendance: now,
+		AttendanceType: domain.AttendanceTypeAdjustment,
+		Description:    fmt.Sprintf("Correction (%s): %s. Reason: %s", correction.Type, strings.Join(changes, ", "), correction.Reason),
+		Actor:          domain.ActorAdmin,
+		ActorUserID:    &reviewerID,
+	}
+
+	if err := u.repo.ApplyCorrection(correction, attendance, history); err != nil {
+		return nil, err
+	}
+	profile, err := u.userRepo.FindUserProfileByEmployeeCode(correction.EmployeeCode)
+	if err != nil {
+		u.log.WithError(err).Warn("Failed to load profile for correction event")
+	}
+	publishAttendanceEvent(u.events, u.webhooks, dto.AttendanceEventCorrectionApproved, profile, attendance, domain.ActorAdmin)
+	return mapToCorrectionResponse(correction), nil
+}
+
+func (u *correctionUseCase) RejectCorrection(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewCorrectionRequest) (*dto.CorrectionResponse, error) {
+	correction, err := u.findPendingCorrection(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	correction.Status = domain.ApprovalRejected
+	correction.ReviewedBy = &reviewerID
+	correction.ReviewedAt = &now
+	correction.ReviewNote = req.Note
+
+	if err := u.repo.UpdateCorrection(correction); err != nil {
+		return nil, err
+	}
+	return mapToCorrectionResponse(correction), nil
+}
+
+func (u *correctionUseCase) CancelCorrection(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.CorrectionResponse, error) {
+	correction, err := u.findAccessibleCorrection(userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	if correction.Status != domain.ApprovalPending {
+		return nil, fmt.Errorf("correction already reviewed")
+	}
+
+	correction.Status = domain.ApprovalCancelled
+	if err := u.repo.UpdateCorrection(correction); err != nil {
+		return nil, err
+	}
+	return mapToCorrectionResponse(correction), nil
+}
+
+// checkCorrection memastikan jenis koreksi cocok dengan kondisi attendance saat ini
+// dan jam hasil koreksi masuk akal. attendance nil berarti belum ada record untuk hari itu.
+func checkCorrection(correctionType domain.CorrectionType, workDate time.Time, attendance *domain.Attendance, clockIn, clockOut *time.Time) error {
+	if attendance != nil && attendance.Status == domain.AttendanceStatusOnLeave {
+		return fmt.Errorf("cannot correct attendance on a leave day")
+	}
+
+	switch correctionType {
+	case domain.CorrectionMissingClockIn:
+		if clockIn == nil {
+			return fmt.Errorf("clock_in is required")
+		}
+		if attendance != nil && attendance.ClockIn != nil {
+			return fmt.Errorf("attendance already has a clock in")
+		}
+	case domain.CorrectionMissingClockOut:
+		if clockOut == nil {
+			return fmt.Errorf("clock_out is required")
+		}
+		if clockIn != nil {
+			return fmt.Errorf("clock_in is not allowed for missing_clock_out")
+		}
+		if attendance == nil || attendance.ClockIn == nil {
+			return fmt.Errorf("no clock in to correct")
+		}
+		if attendance.ClockOut != nil {
+			return fmt.Errorf("attendance already has a clock out")
+		}
+	case domain.CorrectionWrongTime:
+		if clockIn == nil && clockOut == nil {
+			return fmt.Errorf("clock_in or clock_out is required")
+		}
+		if attendance == nil || attendance.ClockIn == nil {
+			return fmt.Errorf("no clock in to correct")
+		}
+	default:
+		return fmt.Errorf("invalid correction type")
+	}
+
+	// Gabungkan jam yang diajukan dengan nilai attendance yang ada, lalu bandingkan sebagai jam dinding
+	in, out := clockIn, clockOut
+	if attendance != nil {
+		if in == nil {
+			in = attendance.ClockIn
+		}
+		if out == nil {
+			out = attendance.ClockOut
+		}
+	}
+	now := wallClock(time.Now())
+	day := wallClock(workDate)
+	if in != nil {
+		inAt := wallClock(*in)
+		if inAt.Before(day) || !inAt.Before(day.AddDate(0, 0, 2)) {
+			return fmt.Errorf("clock_in does not match the work date")
+		}
+		if inAt.After(now) {
+			return fmt.Errorf("clock_in cannot be in the future")
+		}
+	}
+	if out != nil {
+		outAt := wallClock(*out)
+		if outAt.After(now) {
+			return fmt.Errorf("clock_out cannot be in the future")
+		}
+		if in != nil {
+			inAt := wallClock(*in)
+			if !outAt.After(inAt) {
+				return fmt.Errorf("clock_out must be after clock_in")
+			}
+			if outAt.Sub(inAt) > maxShiftDuration {
+				return fmt.Errorf("clock_out cannot be more than %v after clock_in", maxShiftDuration)
+			}
+		}
+	}
+	return nil
+}
+
+func (u *correctionUseCase) findAttendance(attendanceID string) (*domain.Attendance, error) {
+	var attendance domain.Attendance
+	if err := u.attRepo.FindAttendanceByID(attendanceID, &attendance); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+func (u *correctionUseCase) findPendingCorrection(id uuid.UUID) (*domain.AttendanceCorrection, error) {
+	correction, err := u.repo.FindCorrectionByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("correction not found")
+		}
+		return nil, err
+	}
+	if correction.Status != domain.ApprovalPending {
+		return nil, fmt.Errorf("correction already reviewed")
+	}
+	return correction, nil
+}
+
+// findAccessibleCorrection memastikan non-admin hanya bisa mengakses koreksi miliknya sendiri
+func (u *correctionUseCase) findAccessibleCorrection(userID uuid.UUID, role string, id uuid.UUID) (*domain.AttendanceCorrection, error) {
+	correction, err := u.repo.FindCorrectionByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("correction not found")
+		}
+		return nil, err
+	}
+	if role != string(domain.Admin) {
+		profile, err := u.userRepo.FindUserProfileByUserID(userID)
+		if err != nil || profile == nil || profile.EmployeeCode != correction.EmployeeCode {
+			return nil, fmt.Errorf("access denied")
+		}
+	}
+	return correction, nil
+}
+
+func formatCorrectionTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(dateTimeLayout)
+}
+
+func mapToCorrectionResponse(c *domain.AttendanceCorrection) *dto.CorrectionResponse {
+	return &dto.CorrectionResponse{
+		ID:               c.ID,
+		EmployeeCode:     c.EmployeeCode,
+		AttendanceID:     c.AttendanceID,
+		WorkDate:         c.WorkDate.Format(dateLayout),
+		Type:             string(c.Type),
+		ClockIn:          c.ClockIn,
+		ClockOut:         c.ClockOut,
+		OriginalClockIn:  c.OriginalClockIn,
+		OriginalClockOut: c.OriginalClockOut,
+		Reason:           c.Reason,
+		Status:           string(c.Status),
+		ReviewedBy:       c.ReviewedBy,
+		ReviewedAt:       c.ReviewedAt,
+		ReviewNote:       c.ReviewNote,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
@@ -22,6 +22,8 @@ type DepartmentUseCase interface {
 	DeleteDepartment(ctx context.Context, id uuid.UUID) error
 	GetDepartments(ctx context.Context, page, limit int) ([]*dto.DepartmentResponse, int64, error)
 	AssignmentDepartement(ctx context.Context, req dto.AssignmentDepartementRequest) error
+	GetAttendanceModeRules(ctx context.Context, id uuid.UUID) ([]*dto.DepartmentModeRuleResponse, error)
+	SetAttendanceModeRules(ctx context.Context, id uuid.UUID, req dto.SetDepartmentModeRulesRequest) ([]*dto.DepartmentModeRuleResponse, error)
 }
 
 type departmentUseCase struct {
@@ -29,10 +31,11 @@ type departmentUseCase struct {
 	userRepo repository.UserRepository
 	log      *logrus.Logger
 	validate *validator.Validate
+	webhooks WebhookPublisher
 }
 
-func NewDepartmentUseCase(repo repository.DepartmentRepository, log *logrus.Logger, validate *validator.Validate, userRepo repository.UserRepository) DepartmentUseCase {
-	return &departmentUseCase{repo: repo, log: log, validate: validate, userRepo: userRepo}
+func NewDepartmentUseCase(repo repository.DepartmentRepository, log *logrus.Logger, validate *validator.Validate, userRepo repository.UserRepository, webhooks WebhookPublisher) DepartmentUseCase {
+	return &departmentUseCase{repo: repo, log: log, validate: validate, userRepo: userRepo, webhooks: webhooks}
 }
 
 func (u *departmentUseCase) AssignmentDepartement(ctx context.Context, req dto.AssignmentDepartementRequest) error {
@@ -55,10 +58,35 @@ func (u *departmentUseCase) AssignmentDepartement(ctx context.Context, req dto.A
 		return err
 	}
 
+	previous, err := u.userRepo.FindUserProfileByUserID(req.UserID)
+	if err != nil {
+		return err
+	}
+
 	if err := u.repo.AssignmentDepartement(req.UserID, req.DepartmentID); err != nil {
 		return err
 	}
 
+	// Profil dibaca ulang supaya nama department di payload webhook sudah yang baru
+	profile, err := u.userRepo.FindUserProfileByUserID(req.UserID)
+	if err != nil || profile == nil {
+		u.log.WithError(err).WithField("user_id", req.UserID).Warn("Failed to load profile for department.assigned webhook")
+		return nil
+	}
+	payload := dto.DepartmentAssignedEvent{
+		UserID:       req.UserID,
+		EmployeeCode: profile.EmployeeCode,
+		FullName:     profile.FullName,
+		DepartmentID: req.DepartmentID,
+	}
+	if profile.Department != nil {
+		payload.DepartmentName = profile.Department.Name
+	}
+	if previous != nil {
+		payload.PreviousDepartmentID = previous.DepartmentID
+	}
+	u.webhooks.Publish(domain.WebhookEventDepartmentAssigned, payload)
+
 	return nil
 }
 func (u *departmentUseCase) CreateDepartment(ctx context.Context, req dto.CreateDepartmentRequest) (*dto.DepartmentResponse, error) {
@@ -74,9 +102,37 @@ func (u *departmentUseCase) CreateDepartment(ctx context.Context, req dto.Create
 	}
 
 	dept := &domain.Department{
-		Name:            req.Name,
-		MaxClockInTime:  clockIn,
-		MaxClockOutTime: clockOut,
+		Name:              req.Name,
+		MaxClockInTime:    clockIn,
+		MaxClockOutTime:   clockOut,
+		HolidayPolicy:     domain.HolidayPolicyOvertime,
+		MaxBreakMinutes:   req.MaxBreakMinutes,
+		RequireClockPhoto: req.RequireClockPhoto,
+		GraceMinutes:      req.GraceMinutes,
+		MinWorkMinutes:    480,
+		// Default kebijakan lembur
+		OvertimeMinMinutes:      30,
+		OvertimeRoundingMinutes: 15,
+	}
+	if req.OvertimeMinMinutes != nil {
+		dept.OvertimeMinMinutes = *req.OvertimeMinMinutes
+	}
+	if req.OvertimeRoundingMinutes != nil {
+		dept.OvertimeRoundingMinutes = *req.OvertimeRoundingMinutes
+	}
+	if req.HolidayPolicy != "" {
+		dept.HolidayPolicy = domain.HolidayPolicy(req.HolidayPolicy)
+	}
+	if req.MinWorkMinutes > 0 {
+		dept.MinWorkMinutes = req.MinWorkMinutes
+	}
+	dept.WeekdayGraceMinutes = req.WeekdayGraceMinutes
+	if err := applyPunctualityRule(dept, req.PunctualityRule, req.CoreStartTime, req.CoreEndTime); err != nil {
+		return nil, err
+	}
+	hours := req.AutoClockOutHours
+	if err := applyAutoClockOutPolicy(dept, req.AutoClockOutMode, req.AutoClockOutTime, &hours); err != nil {
+		return nil, err
 	}
 	if err := u.repo.CreateDepartment(dept); err != nil {
 		return nil, err
@@ -126,6 +182,40 @@ func (u *departmentUseCase) UpdateDepartment(ctx context.Context, id uuid.UUID,
 		)
 	}
 
+	if req.HolidayPolicy != "" {
+		dept.HolidayPolicy = domain.HolidayPolicy(req.HolidayPolicy)
+	}
+
+	if err := applyAutoClockOutPolicy(dept, req.AutoClockOutMode, req.AutoClockOutTime, req.AutoClockOutHours); err != nil {
+		return nil, err
+	}
+
+	if req.MaxBreakMinutes != nil {
+		dept.MaxBreakMinutes = *req.MaxBreakMinutes
+	}
+	if req.RequireClockPhoto != nil {
+		dept.RequireClockPhoto = *req.RequireClockPhoto
+	}
+	if req.GraceMinutes != nil {
+		dept.GraceMinutes = *req.GraceMinutes
+	}
+	if req.MinWorkMinutes != nil {
+		dept.MinWorkMinutes = *req.MinWorkMinutes
+	}
+	// Map kosong menghapus override grace per weekday
+	if req.WeekdayGraceMinutes != nil {
+		dept.WeekdayGraceMinutes = req.WeekdayGraceMinutes
+	}
+	if err := applyPunctualityRule(dept, req.PunctualityRule, req.CoreStartTime, req.CoreEndTime); err != nil {
+		return nil, err
+	}
+	if req.OvertimeMinMinutes != nil {
+		dept.OvertimeMinMinutes = *req.OvertimeMinMinutes
+	}
+	if req.OvertimeRoundingMinutes != nil {
+		dept.OvertimeRoundingMinutes = *req.OvertimeRoundingMinutes
+	}
+
 	if err := u.repo.UpdateDepartment(dept); err != nil {
 		return nil, err
 	}
@@ -150,16 +240,154 @@ func (u *departmentUseCase) GetDepartments(ctx context.Context, page, limit int)
 	return res, total, nil
 }
 
+func (u *departmentUseCase) GetAttendanceModeRules(ctx context.Context, id uuid.UUID) ([]*dto.DepartmentModeRuleResponse, error) {
+	if exist, err := u.repo.IsDepartmentExist(id); !exist || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("department not found")
+	}
+	rules, err := u.repo.FindModeRulesByDepartmentID(id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToModeRuleResponses(rules), nil
+}
+
+// SetAttendanceModeRules mengganti rule attendance mode departemen.
+// Rule kosong berarti departemen hanya boleh mode office.
+func (u *departmentUseCase) SetAttendanceModeRules(ctx context.Context, id uuid.UUID, req dto.SetDepartmentModeRulesRequest) ([]*dto.DepartmentModeRuleResponse, error) {
+	if exist, err := u.repo.IsDepartmentExist(id); !exist || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("department not found")
+	}
+
+	seen := make(map[string]bool)
+	rules := make([]*domain.DepartmentModeRule, 0, len(req.Rules))
+	for _, r := range req.Rules {
+		if seen[r.Mode] {
+			return nil, fmt.Errorf("duplicate mode: %s", r.Mode)
+		}
+		seen[r.Mode] = true
+		rules = append(rules, &domain.DepartmentModeRule{
+			DepartmentID: id,
+			Mode:         domain.AttendanceMode(r.Mode),
+			Weekdays:     r.Weekdays,
+		})
+	}
+
+	if err := u.repo.ReplaceModeRules(id, rules); err != nil {
+		return nil, err
+	}
+	return mapToModeRuleResponses(rules), nil
+}
+
+func mapToModeRuleResponses(rules []*domain.DepartmentModeRule) []*dto.DepartmentModeRuleResponse {
+	res := make([]*dto.DepartmentModeRuleResponse, len(rules))
+	for i, r := range rules {
+		res[i] = &dto.DepartmentModeRuleResponse{Mode: string(r.Mode), Weekdays: r.Weekdays}
+	}
+	return res
+}
+
+// applyAutoClockOutPolicy mengisi kebijakan auto clock out departemen dan memastikan
+// parameter yang dibutuhkan mode tersebut sudah ada. Nilai kosong berarti tidak diubah.
+func applyAutoClockOutPolicy(dept *domain.Department, mode, clockOutTime string, hours *int) error {
+	if mode != "" {
+		dept.AutoClockOutMode = domain.AutoClockOutMode(mode)
+	}
+	if dept.AutoClockOutMode == "" {
+		dept.AutoClockOutMode = domain.AutoClockOutNone
+	}
+	if clockOutTime != "" {
+		t, err := time.Parse(timeOfDayLayout, clockOutTime)
+		if err != nil {
+			return fmt.Errorf("invalid auto_clock_out_time: %w", err)
+		}
+		dept.AutoClockOutTime = &t
+	}
+	if hours != nil && *hours > 0 {
+		dept.AutoClockOutHours = *hours
+	}
+
+	switch dept.AutoClockOutMode {
+	case domain.AutoClockOutFixedTime:
+		if dept.AutoClockOutTime == nil {
+			return fmt.Errorf("auto_clock_out_time is required for fixed_time mode")
+		}
+	case domain.AutoClockOutAfterHours:
+		if dept.AutoClockOutHours <= 0 {
+			return fmt.Errorf("auto_clock_out_hours is required for after_hours mode")
+		}
+	}
+	return nil
+}
+
+// applyPunctualityRule mengisi rule punctuality departemen; flexitime wajib punya core hours.
+// Nilai kosong berarti tidak diubah.
+func applyPunctualityRule(dept *domain.Department, rule, coreStart, coreEnd string) error {
+	if rule != "" {
+		dept.PunctualityRule = domain.PunctualityRule(rule)
+	}
+	if dept.PunctualityRule == "" {
+		dept.PunctualityRule = domain.PunctualityFixedWindow
+	}
+	if coreStart != "" {
+		t, err := time.Parse(timeOfDayLayout, coreStart)
+		if err != nil {
+			return fmt.Errorf("invalid core_start_time: %w", err)
+		}
+		dept.CoreStartTime = &t
+	}
+	if coreEnd != "" {
+		t, err := time.Parse(timeOfDayLayout, coreEnd)
+		if err != nil {
+			return fmt.Errorf("invalid core_end_time: %w", err)
+		}
+		dept.CoreEndTime = &t
+	}
+
+	if dept.PunctualityRule == domain.PunctualityFlexitime && (dept.CoreStartTime == nil || dept.CoreEndTime == nil) {
+		return fmt.Errorf("core_start_time and core_end_time are required for flexitime rule")
+	}
+	return nil
+}
+
+// formatTimeOfDay memformat kolom time opsional menjadi "15:04:05"
+func formatTimeOfDay(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(timeOfDayLayout)
+	return &s
+}
+
 func mapToDepartmentResponse(d *domain.Department) *dto.DepartmentResponse {
 	if d == nil {
 		return nil
 	}
 	return &dto.DepartmentResponse{
-		ID:              d.ID,
-		Name:            d.Name,
-		MaxClockInTime:  d.MaxClockInTime,
-		MaxClockOutTime: d.MaxClockOutTime,
-		CreatedAt:       d.CreatedAt,
-		UpdatedAt:       d.UpdatedAt,
+		ID:                      d.ID,
+		Name:                    d.Name,
+		MaxClockInTime:          d.MaxClockInTime,
+		MaxClockOutTime:         d.MaxClockOutTime,
+		HolidayPolicy:           string(d.HolidayPolicy),
+		AutoClockOutMode:        string(d.AutoClockOutMode),
+		AutoClockOutTime:        formatTimeOfDay(d.AutoClockOutTime),
+		AutoClockOutHours:       d.AutoClockOutHours,
+		MaxBreakMinutes:         d.MaxBreakMinutes,
+		RequireClockPhoto:       d.RequireClockPhoto,
+		GraceMinutes:            d.GraceMinutes,
+		PunctualityRule:         string(d.PunctualityRule),
+		CoreStartTime:           formatTimeOfDay(d.CoreStartTime),
+		CoreEndTime:             formatTimeOfDay(d.CoreEndTime),
+		MinWorkMinutes:          d.MinWorkMinutes,
+		WeekdayGraceMinutes:     d.WeekdayGraceMinutes,
+		OvertimeMinMinutes:      d.OvertimeMinMinutes,
+		OvertimeRoundingMinutes: d.OvertimeRoundingMinutes,
+		CreatedAt:               d.CreatedAt,
+		UpdatedAt:               d.UpdatedAt,
 	}
 }
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"employee-attendance-system/internal/entity/domain"
+	utils "employee-attendance-system/internal/util"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// deviceLastSeenInterval: last_seen_at perangkat cukup diperbarui per menit, bukan di setiap request
+const deviceLastSeenInterval = time.Minute
+
+// apiKeyDevice adalah perangkat yang meng-embed domain.DeviceKey
+type apiKeyDevice interface {
+	Key() *domain.DeviceKey
+}
+
+// deviceKeys menyatukan pembuatan, rotasi dan autentikasi API key kiosk dan reader RFID
+type deviceKeys[D apiKeyDevice] struct {
+	name       string // nama perangkat di pesan error, mis. "kiosk"
+	prefix     string // awalan key supaya jenis key terlihat, mis. "kiosk_"
+	findByHash func(keyHash string) (D, error)
+	touch      func(device D, seenAt time.Time) error
+	log        *logrus.Logger
+}
+
+// issue mengganti key perangkat dengan key baru dan mengembalikan key asli, yang hanya
+// ditampilkan sekali ke admin. Key lama langsung tidak berlaku setelah perangkat disimpan.
+func (k deviceKeys[D]) issue(device D) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	apiKey := k.prefix + hex.EncodeToString(b)
+	device.Key().KeyHash = utils.HashToken(apiKey)
+	return apiKey, nil
+}
+
+// authenticate mencari perangkat aktif pemilik apiKey dan memperbarui last_seen_at
+func (k deviceKeys[D]) authenticate(apiKey string, now time.Time) (D, error) {
+	var none D
+	device, err := k.findByHash(utils.HashToken(apiKey))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return none, fmt.Errorf("invalid %s key", k.name)
+		}
+		return none, err
+	}
+	key := device.Key()
+	if !key.IsActive {
+		return none, fmt.Errorf("%s is inactive", k.name)
+	}
+	if key.LastSeenAt == nil || now.Sub(*key.LastSeenAt) >= deviceLastSeenInterval {
+		if err := k.touch(device, now); err != nil {
+			k.log.WithError(err).WithField("device", k.name).Warn("Failed to update device last seen")
+		}
+	}
+	return device, nil
+}
+
+// rotate memberi perangkat key baru lalu menyimpannya dengan save
+func (k deviceKeys[D]) rotate(device D, save func(D) error) (string, error) {
+	apiKey, err := k.issue(device)
+	if err != nil {
+		return "", err
+	}
+	if err := save(device); err != nil {
+		return "", err
+	}
+	return apiKey, nil
+}
+
+// setDeviceActive dipakai endpoint update perangkat; nil berarti tidak diubah
+func setDeviceActive(device apiKeyDevice, isActive *bool) {
+	if isActive != nil {
+		device.Key().IsActive = *isActive
+	}
+}
@@ -0,0 +1,217 @@
+// holiday_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	utils "employee-attendance-system/internal/util"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type HolidayUseCase interface {
+	CreateHoliday(ctx context.Context, req dto.CreateHolidayRequest) (*dto.HolidayResponse, error)
+	GetHoliday(ctx context.Context, id uuid.UUID) (*dto.HolidayResponse, error)
+	UpdateHoliday(ctx context.Context, id uuid.UUID, req dto.UpdateHolidayRequest) (*dto.HolidayResponse, error)
+	DeleteHoliday(ctx context.Context, id uuid.UUID) error
+	GetHolidays(ctx context.Context, req dto.ListHolidaysRequest) ([]*dto.HolidayResponse, int64, error)
+	ImportICalendar(ctx context.Context, req dto.ImportHolidaysRequest, r io.Reader) (*dto.ImportHolidaysResponse, error)
+}
+
+type holidayUseCase struct {
+	repo     repository.HolidayRepository
+	deptRepo repository.DepartmentRepository
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewHolidayUseCase(repo repository.HolidayRepository, deptRepo repository.DepartmentRepository, log *logrus.Logger, validate *validator.Validate) HolidayUseCase {
+	return &holidayUseCase{repo: repo, deptRepo: deptRepo, log: log, validate: validate}
+}
+
+// maxImportedHolidayDays membatasi panjang satu event .ics agar file rusak tidak membuat ribuan baris
+const maxImportedHolidayDays = 31
+
+func (u *holidayUseCase) CreateHoliday(ctx context.Context, req dto.CreateHolidayRequest) (*dto.HolidayResponse, error) {
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date: %w", err)
+	}
+	if err := u.ensureDepartment(req.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	holiday := &domain.Holiday{
+		Date:         date,
+		Name:         req.Name,
+		Type:         holidayTypeOrDefault(req.Type),
+		DepartmentID: req.DepartmentID,
+	}
+	if err := u.repo.CreateHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return mapToHolidayResponse(holiday), nil
+}
+
+func (u *holidayUseCase) GetHoliday(ctx context.Context, id uuid.UUID) (*dto.HolidayResponse, error) {
+	holiday, err := u.findHoliday(id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToHolidayResponse(holiday), nil
+}
+
+func (u *holidayUseCase) UpdateHoliday(ctx context.Context, id uuid.UUID, req dto.UpdateHolidayRequest) (*dto.HolidayResponse, error) {
+	holiday, err := u.findHoliday(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Date != "" {
+		date, err := time.Parse(dateLayout, req.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %w", err)
+		}
+		holiday.Date = date
+	}
+	if req.Name != "" {
+		holiday.Name = req.Name
+	}
+	if req.Type != "" {
+		holiday.Type = domain.HolidayType(req.Type)
+	}
+	if req.DepartmentID != nil {
+		if err := u.ensureDepartment(req.DepartmentID); err != nil {
+			return nil, err
+		}
+		holiday.DepartmentID = req.DepartmentID
+	}
+
+	if err := u.repo.UpdateHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return mapToHolidayResponse(holiday), nil
+}
+
+func (u *holidayUseCase) DeleteHoliday(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.findHoliday(id); err != nil {
+		return err
+	}
+	return u.repo.DeleteHoliday(id)
+}
+
+func (u *holidayUseCase) GetHolidays(ctx context.Context, req dto.ListHolidaysRequest) ([]*dto.HolidayResponse, int64, error) {
+	holidays, total, err := u.repo.FindAllHolidays(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.HolidayResponse, len(holidays))
+	for i, h := range holidays {
+		res[i] = mapToHolidayResponse(h)
+	}
+	return res, total, nil
+}
+
+// ImportICalendar membuat satu Holiday per tanggal untuk setiap VEVENT.
+// Event yang UID + tanggalnya sudah pernah diimport dilewati sehingga import bisa diulang.
+func (u *holidayUseCase) ImportICalendar(ctx context.Context, req dto.ImportHolidaysRequest, r io.Reader) (*dto.ImportHolidaysResponse, error) {
+	if err := u.ensureDepartment(req.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	events, err := utils.ParseICalendar(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calendar file: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("calendar file contains no events")
+	}
+
+	res := &dto.ImportHolidaysResponse{}
+	var holidays []*domain.Holiday
+	for _, ev := range events {
+		start, end := dateOf(ev.Start), dateOf(ev.End)
+		if end.Sub(start) >= maxImportedHolidayDays*24*time.Hour {
+			u.log.Warnf("Skipping calendar event %q: longer than %d days", ev.UID, maxImportedHolidayDays)
+			res.Skipped++
+			continue
+		}
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			if ev.UID != "" {
+				exists, err := u.repo.IsHolidayImported(ev.UID, day, req.DepartmentID)
+				if err != nil {
+					return nil, err
+				}
+				if exists {
+					res.Skipped++
+					continue
+				}
+			}
+			name := ev.Summary
+			if name == "" {
+				name = "Holiday"
+			}
+			holidays = append(holidays, &domain.Holiday{
+				Date:         day,
+				Name:         name,
+				Type:         holidayTypeOrDefault(req.Type),
+				DepartmentID: req.DepartmentID,
+				ExternalUID:  ev.UID,
+			})
+		}
+	}
+
+	if err := u.repo.CreateHolidays(holidays); err != nil {
+		return nil, err
+	}
+	res.Imported = len(holidays)
+	return res, nil
+}
+
+func (u *holidayUseCase) findHoliday(id uuid.UUID) (*domain.Holiday, error) {
+	holiday, err := u.repo.FindHolidayByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("holiday not found")
+		}
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (u *holidayUseCase) ensureDepartment(id *uuid.UUID) error {
+	if id == nil {
+		return nil
+	}
+	if _, err := u.deptRepo.FindDepartmentByID(*id); err != nil {
+		return fmt.Errorf("department not found")
+	}
+	return nil
+}
+
+func holidayTypeOrDefault(t string) domain.HolidayType {
+	if t == "" {
+		return domain.HolidayNational
+	}
+	return domain.HolidayType(t)
+}
+
+func mapToHolidayResponse(h *domain.Holiday) *dto.HolidayResponse {
+	return &dto.HolidayResponse{
+		ID:           h.ID,
+		Date:         h.Date.Format(dateLayout),
+		Name:         h.Name,
+		Type:         string(h.Type),
+		DepartmentID: h.DepartmentID,
+		CreatedAt:    h.CreatedAt,
+		UpdatedAt:    h.UpdatedAt,
+	}
+}
@@ -0,0 +1,127 @@
+// idempotency_usecase.go
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/repository"
+	utils "employee-attendance-system/internal/util"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type IdempotencyUseCase interface {
+	HashRequest(fingerprint []byte) string
+	Begin(ctx context.Context, scope, key, requestHash string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *domain.IdempotencyRecord, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, record *domain.IdempotencyRecord) error
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+const (
+	idempotencyDefaultTTL  = 24 * time.Hour
+	idempotencyLockTimeout = time.Minute // Request pertama yang belum selesai setelah ini dianggap terhenti
+)
+
+type idempotencyUseCase struct {
+	repo   repository.IdempotencyRepository
+	secret []byte
+	ttl    time.Duration
+	log    *logrus.Logger
+}
+
+func NewIdempotencyUseCase(repo repository.IdempotencyRepository, config *viper.Viper, log *logrus.Logger) IdempotencyUseCase {
+	// Body request (mis. password saat signup) ikut di-hash, jadi hash-nya harus memakai secret server
+	secret := config.GetString("idempotency.hashSecret")
+	if !utils.IsUsableSecret(secret) {
+		log.Fatal("idempotency.hashSecret must be set to a random value")
+	}
+	ttl := config.GetDuration("idempotency.ttl")
+	if ttl <= 0 {
+		ttl = idempotencyDefaultTTL
+	}
+	return &idempotencyUseCase{repo: repo, secret: []byte(secret), ttl: ttl, log: log}
+}
+
+// HashRequest membuat HMAC dari isi request supaya hash yang tersimpan tidak bisa dipakai
+// untuk menebak isi body tanpa mengetahui secret server
+func (u *idempotencyUseCase) HashRequest(fingerprint []byte) string {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write(fingerprint)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Begin mengunci key untuk request ini. Record dengan StatusCode 0 berarti kunci milik
+// request ini dan request harus diproses; selain itu record berisi response pertama yang
+// harus diputar ulang untuk retry.
+func (u *idempotencyUseCase) Begin(ctx context.Context, scope, key, requestHash string) (*domain.IdempotencyRecord, error) {
+	// Dibulatkan ke presisi timestamp Postgres supaya locked_at bisa dicocokkan lagi saat complete/release
+	now := time.Now().Truncate(time.Microsecond)
+	record := &domain.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		LockedAt:    now,
+		ExpiresAt:   now.Add(u.ttl),
+	}
+	created, err := u.repo.CreateRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		return record, nil
+	}
+	reclaimed, err := u.repo.ReclaimRecord(record, now.Add(-idempotencyLockTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if reclaimed {
+		return record, nil
+	}
+
+	existing, err := u.repo.FindRecord(scope, key)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			// Baru saja dilepas oleh request pertama yang gagal; client cukup mengulang
+			return nil, fmt.Errorf("request with this idempotency key is still being processed")
+		}
+		return nil, err
+	}
+	if existing.RequestHash != requestHash {
+		return nil, fmt.Errorf("idempotency key was already used for a different request")
+	}
+	if existing.StatusCode == 0 {
+		return nil, fmt.Errorf("request with this idempotency key is still being processed")
+	}
+	return existing, nil
+}
+
+// Complete menyimpan response pertama untuk diputar ulang sampai key kedaluwarsa. Tidak
+// menimpa apa pun jika kunci sudah diambil alih request lain setelah lock timeout.
+func (u *idempotencyUseCase) Complete(ctx context.Context, record *domain.IdempotencyRecord, statusCode int, contentType string, body []byte) error {
+	return u.repo.SaveResponse(record.Scope, record.Key, record.LockedAt, statusCode, contentType, body)
+}
+
+// Release melepas key tanpa menyimpan response (mis. error server) supaya retry diproses ulang.
+// Hanya kunci milik request ini yang dihapus, bukan kunci request lain yang sudah mengambil alih.
+func (u *idempotencyUseCase) Release(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return u.repo.DeleteRecord(record.Scope, record.Key, record.LockedAt)
+}
+
+func (u *idempotencyUseCase) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	purged, err := u.repo.DeleteExpiredRecords(now)
+	if err != nil {
+		return 0, err
+	}
+	if purged > 0 {
+		u.log.WithField("count", purged).Info("Purged expired idempotency keys")
+	}
+	return purged, nil
+}
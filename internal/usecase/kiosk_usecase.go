@@ -0,0 +1,404 @@
+// kiosk_usecase.go
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	utils "employee-attendance-system/internal/util"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type KioskUseCase interface {
+	CreateKiosk(ctx context.Context, adminID uuid.UUID, req dto.CreateKioskRequest) (*dto.KioskResponse, error)
+	GetKiosks(ctx context.Context, page, limit int) ([]*dto.KioskResponse, int64, error)
+	UpdateKiosk(ctx context.Context, id uuid.UUID, req dto.UpdateKioskRequest) (*dto.KioskResponse, error)
+	DeleteKiosk(ctx context.Context, id uuid.UUID) error
+	RotateKioskKey(ctx context.Context, id uuid.UUID) (*dto.KioskResponse, error)
+	AuthenticateKiosk(ctx context.Context, apiKey string) (*domain.KioskDevice, error)
+	IssueQRCode(ctx context.Context, kioskID uuid.UUID) (*dto.KioskQRResponse, error)
+	ScanKioskQR(ctx context.Context, userID uuid.UUID, req dto.KioskScanRequest) (*dto.KioskScanResponse, error)
+	ScanBadge(ctx context.Context, kioskID uuid.UUID, req dto.KioskBadgeScanRequest) (*dto.KioskScanResponse, error)
+	GetBadge(ctx context.Context, userID uuid.UUID) (*dto.KioskBadgeResponse, error)
+}
+
+// Format kode QR. Bagian terakhir adalah HMAC-SHA256 dari bagian sebelumnya; signature
+// badge juga mencakup secret badge yang tersimpan di badge_cards sehingga bisa di-rotate.
+//
+//	kiosk: kqr1.<kiosk_id>.<nonce>.<expires_unix>.<signature>
+//	badge: kbg2.<badge_id>.<signature>
+const (
+	kioskQRPrefix       = "kqr1"
+	kioskBadgePrefix    = "kbg2"
+	kioskDefaultQRTTL   = 30 * time.Second
+	kioskClockSkew      = 5 * time.Second // Toleransi jam HP / kiosk yang sedikit berbeda
+	kioskActionClockOut = "clock_out"
+)
+
+type kioskUseCase struct {
+	repo         repository.KioskRepository
+	badgeRepo    repository.BadgeRepository
+	profileRepo  repository.UserRepository
+	deptRepo     repository.DepartmentRepository
+	locationRepo repository.LocationRepository
+	attendance   AttendanceUseCase
+	keys         deviceKeys[*domain.KioskDevice]
+	secret       []byte
+	qrTTL        time.Duration
+	log          *logrus.Logger
+	validate     *validator.Validate
+}
+
+func NewKioskUseCase(repo repository.KioskRepository, badgeRepo repository.BadgeRepository, profileRepo repository.UserRepository, deptRepo repository.DepartmentRepository, locationRepo repository.LocationRepository, attendance AttendanceUseCase, config *viper.Viper, log *logrus.Logger, validate *validator.Validate) KioskUseCase {
+	// Secret QR sengaja tidak jatuh ke secret JWT: bocornya satu tidak boleh ikut membuka yang lain
+	secret := config.GetString("kiosk.qrSecret")
+	if !utils.IsUsableSecret(secret) {
+		log.Fatal("kiosk.qrSecret must be set to a random value")
+	}
+	qrTTL := config.GetDuration("kiosk.qrTTL")
+	if qrTTL <= 0 {
+		qrTTL = kioskDefaultQRTTL
+	}
+	keys := deviceKeys[*domain.KioskDevice]{
+		name:       "kiosk",
+		prefix:     "kiosk_",
+		findByHash: repo.FindKioskByKeyHash,
+		touch: func(kiosk *domain.KioskDevice, seenAt time.Time) error {
+			return repo.TouchKiosk(kiosk.ID, seenAt)
+		},
+		log: log,
+	}
+	return &kioskUseCase{repo: repo, badgeRepo: badgeRepo, profileRepo: profileRepo, deptRepo: deptRepo, locationRepo: locationRepo,
+		attendance: attendance, keys: keys, secret: []byte(secret), qrTTL: qrTTL, log: log, validate: validate}
+}
+
+func (u *kioskUseCase) CreateKiosk(ctx context.Context, adminID uuid.UUID, req dto.CreateKioskRequest) (*dto.KioskResponse, error) {
+	if err := u.checkReferences(req.DepartmentID, req.OfficeLocationID); err != nil {
+		return nil, err
+	}
+	kiosk := &domain.KioskDevice{
+		Name:             req.Name,
+		DepartmentID:     req.DepartmentID,
+		OfficeLocationID: req.OfficeLocationID,
+		CreatedBy:        &adminID,
+		DeviceKey:        domain.DeviceKey{IsActive: true},
+	}
+	apiKey, err := u.keys.issue(kiosk)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.CreateKiosk(kiosk); err != nil {
+		return nil, err
+	}
+	res := mapToKioskResponse(kiosk)
+	res.APIKey = apiKey
+	return res, nil
+}
+
+func (u *kioskUseCase) GetKiosks(ctx context.Context, page, limit int) ([]*dto.KioskResponse, int64, error) {
+	kiosks, total, err := u.repo.FindKiosks((page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.KioskResponse, len(kiosks))
+	for i, k := range kiosks {
+		res[i] = mapToKioskResponse(k)
+	}
+	return res, total, nil
+}
+
+func (u *kioskUseCase) UpdateKiosk(ctx context.Context, id uuid.UUID, req dto.UpdateKioskRequest) (*dto.KioskResponse, error) {
+	kiosk, err := u.findKiosk(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkReferences(req.DepartmentID, req.OfficeLocationID); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		kiosk.Name = req.Name
+	}
+	if req.DepartmentID != nil {
+		kiosk.DepartmentID = req.DepartmentID
+	}
+	if req.OfficeLocationID != nil {
+		kiosk.OfficeLocationID = req.OfficeLocationID
+	}
+	setDeviceActive(kiosk, req.IsActive)
+	if err := u.repo.UpdateKiosk(kiosk); err != nil {
+		return nil, err
+	}
+	return mapToKioskResponse(kiosk), nil
+}
+
+func (u *kioskUseCase) DeleteKiosk(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.findKiosk(id); err != nil {
+		return err
+	}
+	return u.repo.DeleteKiosk(id)
+}
+
+// RotateKioskKey mengganti API key kiosk; key lama langsung tidak berlaku
+func (u *kioskUseCase) RotateKioskKey(ctx context.Context, id uuid.UUID) (*dto.KioskResponse, error) {
+	kiosk, err := u.findKiosk(id)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := u.keys.rotate(kiosk, u.repo.UpdateKiosk)
+	if err != nil {
+		return nil, err
+	}
+	res := mapToKioskResponse(kiosk)
+	res.APIKey = apiKey
+	return res, nil
+}
+
+func (u *kioskUseCase) AuthenticateKiosk(ctx context.Context, apiKey string) (*domain.KioskDevice, error) {
+	return u.keys.authenticate(apiKey, time.Now())
+}
+
+// IssueQRCode membuat QR baru untuk ditampilkan kiosk. Setiap QR punya nonce sendiri dan
+// berlaku sebentar, sehingga foto QR yang dikirim ke orang lain cepat kedaluwarsa.
+func (u *kioskUseCase) IssueQRCode(ctx context.Context, kioskID uuid.UUID) (*dto.KioskQRResponse, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(u.qrTTL).Truncate(time.Second)
+	payload := strings.Join([]string{kioskQRPrefix, kioskID.String(), hex.EncodeToString(nonce), strconv.FormatInt(expiresAt.Unix(), 10)}, ".")
+	return &dto.KioskQRResponse{Code: payload + "." + u.sign(payload), ExpiresAt: expiresAt}, nil
+}
+
+// ScanKioskQR dipanggil dari HP karyawan setelah scan QR di kiosk
+func (u *kioskUseCase) ScanKioskQR(ctx context.Context, userID uuid.UUID, req dto.KioskScanRequest) (*dto.KioskScanResponse, error) {
+	kioskID, nonce, err := u.verifyQRCode(req.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	kiosk, err := u.findUsableKiosk(kioskID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+	scan := &domain.KioskScan{
+		KioskID:      kiosk.ID,
+		Method:       domain.KioskScanEmployee,
+		Nonce:        &nonce,
+		EmployeeCode: profile.EmployeeCode,
+		ScannedAt:    time.Now(),
+	}
+	return u.clockViaKiosk(ctx, kiosk, profile, scan, req.Action)
+}
+
+// ScanBadge dipanggil kiosk setelah scan QR badge karyawan. Kiosk sudah terautentikasi
+// dengan API key; badge dibuktikan dengan signature dan harus masih aktif di registry.
+func (u *kioskUseCase) ScanBadge(ctx context.Context, kioskID uuid.UUID, req dto.KioskBadgeScanRequest) (*dto.KioskScanResponse, error) {
+	badge, err := u.verifyBadgeCode(req.BadgeCode)
+	if err != nil {
+		return nil, err
+	}
+	if badge.Status != domain.BadgeStatusActive {
+		u.log.WithFields(logrus.Fields{"kiosk_id": kioskID, "badge_id": badge.ID, "status": badge.Status}).Warn("Deactivated badge scanned")
+		return nil, fmt.Errorf("badge is deactivated")
+	}
+	kiosk, err := u.findUsableKiosk(kioskID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := u.profileRepo.FindUserProfileByEmployeeCode(badge.EmployeeCode)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+	scan := &domain.KioskScan{
+		KioskID:      kiosk.ID,
+		Method:       domain.KioskScanBadge,
+		EmployeeCode: profile.EmployeeCode,
+		ScannedAt:    time.Now(),
+	}
+	return u.clockViaKiosk(ctx, kiosk, profile, scan, req.Action)
+}
+
+// GetBadge mengembalikan kode badge QR aktif milik karyawan untuk dicetak. Badge QR
+// diterbitkan admin lewat /badges; kode berubah setiap kali secret-nya di-rotate.
+func (u *kioskUseCase) GetBadge(ctx context.Context, userID uuid.UUID) (*dto.KioskBadgeResponse, error) {
+	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+	badge, err := u.badgeRepo.FindActiveBadge(profile.EmployeeCode, domain.BadgeKindQR)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("badge not found")
+		}
+		return nil, err
+	}
+	return &dto.KioskBadgeResponse{BadgeID: badge.ID, EmployeeCode: profile.EmployeeCode, BadgeCode: u.badgeCode(badge)}, nil
+}
+
+// clockViaKiosk mencatat scan lalu menjalankan clock in/out atas nama karyawan.
+// Scan dicatat lebih dulu supaya QR yang sama tidak bisa diproses dua kali bersamaan,
+// dan dihapus lagi jika clock in/out ditolak supaya nonce-nya tidak hangus.
+func (u *kioskUseCase) clockViaKiosk(ctx context.Context, kiosk *domain.KioskDevice, profile *domain.UserProfile, scan *domain.KioskScan, action string) (*dto.KioskScanResponse, error) {
+	if kiosk.DepartmentID != nil && (profile.DepartmentID == nil || *profile.DepartmentID != *kiosk.DepartmentID) {
+		return nil, fmt.Errorf("this kiosk is not assigned to your department")
+	}
+	created, err := u.repo.CreateScan(scan)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, fmt.Errorf("kiosk code has already been used")
+	}
+
+	source := dto.ClockSource{KioskID: &kiosk.ID, OfficeLocationID: kiosk.OfficeLocationID}
+	var attendance *dto.AttendanceResponse
+	if action == kioskActionClockOut {
+		attendance, err = u.attendance.ClockOut(ctx, profile.SourceUserID, dto.ClockOutRequest{Source: source})
+	} else {
+		attendance, err = u.attendance.ClockIn(ctx, profile.SourceUserID, dto.ClockInRequest{Source: source})
+	}
+	if err != nil {
+		if delErr := u.repo.DeleteScan(scan.ID); delErr != nil {
+			u.log.WithError(delErr).WithField("scan_id", scan.ID).Warn("Failed to release kiosk scan")
+		}
+		return nil, err
+	}
+	return &dto.KioskScanResponse{
+		EmployeeCode: profile.EmployeeCode,
+		FullName:     profile.FullName,
+		Action:       action,
+		Attendance:   attendance,
+	}, nil
+}
+
+func (u *kioskUseCase) verifyQRCode(code string, now time.Time) (uuid.UUID, string, error) {
+	parts := strings.Split(code, ".")
+	if len(parts) != 5 || parts[0] != kioskQRPrefix {
+		return uuid.Nil, "", fmt.Errorf("invalid kiosk code")
+	}
+	if !u.verify(strings.Join(parts[:4], "."), parts[4]) {
+		return uuid.Nil, "", fmt.Errorf("invalid kiosk code")
+	}
+	kioskID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("invalid kiosk code")
+	}
+	expiresUnix, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("invalid kiosk code")
+	}
+	if now.After(time.Unix(expiresUnix, 0).Add(kioskClockSkew)) {
+		return uuid.Nil, "", fmt.Errorf("kiosk code has expired")
+	}
+	return kioskID, parts[2], nil
+}
+
+func (u *kioskUseCase) badgeCode(badge *domain.BadgeCard) string {
+	payload := kioskBadgePrefix + "." + badge.ID.String()
+	return payload + "." + u.sign(payload+"."+badge.Secret)
+}
+
+func (u *kioskUseCase) verifyBadgeCode(code string) (*domain.BadgeCard, error) {
+	parts := strings.Split(code, ".")
+	if len(parts) != 3 || parts[0] != kioskBadgePrefix {
+		return nil, fmt.Errorf("invalid badge code")
+	}
+	badgeID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid badge code")
+	}
+	badge, err := u.badgeRepo.FindBadgeByID(badgeID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("invalid badge code")
+		}
+		return nil, err
+	}
+	// Kode yang dibuat sebelum secret di-rotate tidak lagi cocok
+	if badge.Kind != domain.BadgeKindQR || !u.verify(parts[0]+"."+parts[1]+"."+badge.Secret, parts[2]) {
+		return nil, fmt.Errorf("invalid badge code")
+	}
+	return badge, nil
+}
+
+func (u *kioskUseCase) sign(payload string) string {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (u *kioskUseCase) verify(payload, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(payload))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func (u *kioskUseCase) checkReferences(departmentID, officeLocationID *uuid.UUID) error {
+	if departmentID != nil {
+		if _, err := u.deptRepo.FindDepartmentByID(*departmentID); err != nil {
+			return fmt.Errorf("department not found")
+		}
+	}
+	if officeLocationID != nil {
+		if _, err := u.locationRepo.FindLocationByID(*officeLocationID); err != nil {
+			return fmt.Errorf("office location not found")
+		}
+	}
+	return nil
+}
+
+func (u *kioskUseCase) findUsableKiosk(id uuid.UUID) (*domain.KioskDevice, error) {
+	kiosk, err := u.findKiosk(id)
+	if err != nil {
+		return nil, err
+	}
+	if !kiosk.IsActive {
+		return nil, fmt.Errorf("kiosk is inactive")
+	}
+	return kiosk, nil
+}
+
+func (u *kioskUseCase) findKiosk(id uuid.UUID) (*domain.KioskDevice, error) {
+	kiosk, err := u.repo.FindKioskByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("kiosk not found")
+		}
+		return nil, err
+	}
+	return kiosk, nil
+}
+
+func mapToKioskResponse(k *domain.KioskDevice) *dto.KioskResponse {
+	return &dto.KioskResponse{
+		ID:               k.ID,
+		Name:             k.Name,
+		DepartmentID:     k.DepartmentID,
+		OfficeLocationID: k.OfficeLocationID,
+		IsActive:         k.IsActive,
+		LastSeenAt:       k.LastSeenAt,
+		CreatedAt:        k.CreatedAt,
+		UpdatedAt:        k.UpdatedAt,
+	}
+}
@@ -0,0 +1,469 @@
+// leave_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LeaveUseCase interface {
+	CreateLeaveType(ctx context.Context, req dto.CreateLeaveTypeRequest) (*dto.LeaveTypeResponse, error)
+	UpdateLeaveType(ctx context.Context, id uuid.UUID, req dto.UpdateLeaveTypeRequest) (*dto.LeaveTypeResponse, error)
+	DeleteLeaveType(ctx context.Context, id uuid.UUID) error
+	GetLeaveTypes(ctx context.Context) ([]*dto.LeaveTypeResponse, error)
+
+	GetLeaveBalances(ctx context.Context, req dto.GetLeaveBalancesRequest) ([]*dto.LeaveBalanceResponse, error)
+	SetLeaveBalance(ctx context.Context, req dto.SetLeaveBalanceRequest) (*dto.LeaveBalanceResponse, error)
+
+	SubmitLeaveRequest(ctx context.Context, userID uuid.UUID, req dto.CreateLeaveRequest) (*dto.LeaveRequestResponse, error)
+	GetLeaveRequest(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.LeaveRequestResponse, error)
+	ListLeaveRequests(ctx context.Context, userID uuid.UUID, role string, req dto.ListLeaveRequestsRequest) ([]*dto.LeaveRequestResponse, int64, error)
+	ApproveLeaveRequest(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error)
+	RejectLeaveRequest(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error)
+	CancelLeaveRequest(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.LeaveRequestResponse, error)
+}
+
+type leaveUseCase struct {
+	repo     repository.LeaveRepository
+	userRepo repository.UserRepository
+	calendar *workCalendar
+	periods  payPeriodGuard
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewLeaveUseCase(repo repository.LeaveRepository, userRepo repository.UserRepository, shiftRepo repository.ShiftRepository, holidayRepo repository.HolidayRepository, payrollRepo repository.PayrollRepository, log *logrus.Logger, validate *validator.Validate) LeaveUseCase {
+	return &leaveUseCase{repo: repo, userRepo: userRepo, calendar: newWorkCalendar(shiftRepo, holidayRepo), periods: payPeriodGuard{repo: payrollRepo}, log: log, validate: validate}
+}
+
+func (u *leaveUseCase) CreateLeaveType(ctx context.Context, req dto.CreateLeaveTypeRequest) (*dto.LeaveTypeResponse, error) {
+	leaveType := &domain.LeaveType{
+		Code:        strings.ToUpper(req.Code),
+		Name:        req.Name,
+		IsPaid:      true,
+		AnnualQuota: req.AnnualQuota,
+	}
+	if req.IsPaid != nil {
+		leaveType.IsPaid = *req.IsPaid
+	}
+	if err := u.repo.CreateLeaveType(leaveType); err != nil {
+		return nil, err
+	}
+	return mapToLeaveTypeResponse(leaveType), nil
+}
+
+func (u *leaveUseCase) UpdateLeaveType(ctx context.Context, id uuid.UUID, req dto.UpdateLeaveTypeRequest) (*dto.LeaveTypeResponse, error) {
+	leaveType, err := u.repo.FindLeaveTypeByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("leave type not found")
+		}
+		return nil, err
+	}
+
+	if req.Name != "" {
+		leaveType.Name = req.Name
+	}
+	if req.IsPaid != nil {
+		leaveType.IsPaid = *req.IsPaid
+	}
+	if req.AnnualQuota != nil {
+		leaveType.AnnualQuota = *req.AnnualQuota
+	}
+
+	if err := u.repo.UpdateLeaveType(leaveType); err != nil {
+		return nil, err
+	}
+	return mapToLeaveTypeResponse(leaveType), nil
+}
+
+func (u *leaveUseCase) DeleteLeaveType(ctx context.Context, id uuid.UUID) error {
+	return u.repo.DeleteLeaveType(id)
+}
+
+func (u *leaveUseCase) GetLeaveTypes(ctx context.Context) ([]*dto.LeaveTypeResponse, error) {
+	leaveTypes, err := u.repo.FindAllLeaveTypes()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*dto.LeaveTypeResponse, len(leaveTypes))
+	for i, t := range leaveTypes {
+		res[i] = mapToLeaveTypeResponse(t)
+	}
+	return res, nil
+}
+
+func (u *leaveUseCase) GetLeaveBalances(ctx context.Context, req dto.GetLeaveBalancesRequest) ([]*dto.LeaveBalanceResponse, error) {
+	profile, err := u.userRepo.FindUserProfileByUserID(req.UserID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	// Tampilkan semua jenis cuti, termasuk yang belum punya record saldo
+	leaveTypes, err := u.repo.FindAllLeaveTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.LeaveBalanceResponse, 0, len(leaveTypes))
+	for _, t := range leaveTypes {
+		balance, err := u.repo.FindOrCreateBalance(profile.EmployeeCode, t, req.Year)
+		if err != nil {
+			return nil, err
+		}
+		pending, err := u.repo.SumPendingDays(profile.EmployeeCode, t.ID, req.Year)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, mapToLeaveBalanceResponse(balance, pending))
+	}
+	return res, nil
+}
+
+func (u *leaveUseCase) SetLeaveBalance(ctx context.Context, req dto.SetLeaveBalanceRequest) (*dto.LeaveBalanceResponse, error) {
+	profile, err := u.userRepo.FindUserProfileByUserID(req.UserID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	leaveType, err := u.repo.FindLeaveTypeByID(req.LeaveTypeID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("leave type not found")
+		}
+		return nil, err
+	}
+
+	balance, err := u.repo.FindOrCreateBalance(profile.EmployeeCode, leaveType, req.Year)
+	if err != nil {
+		return nil, err
+	}
+	balance.Entitled = req.Entitled
+	if err := u.repo.UpdateBalance(balance); err != nil {
+		return nil, err
+	}
+
+	pending, err := u.repo.SumPendingDays(profile.EmployeeCode, leaveType.ID, req.Year)
+	if err != nil {
+		return nil, err
+	}
+	return mapToLeaveBalanceResponse(balance, pending), nil
+}
+
+func (u *leaveUseCase) SubmitLeaveRequest(ctx context.Context, userID uuid.UUID, req dto.CreateLeaveRequest) (*dto.LeaveRequestResponse, error) {
+	profile, err := u.userRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	leaveType, err := u.repo.FindLeaveTypeByID(req.LeaveTypeID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("leave type not found")
+		}
+		return nil, err
+	}
+
+	start, err := time.ParseInLocation(dateLayout, req.StartDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_date: %w", err)
+	}
+	end, err := time.ParseInLocation(dateLayout, req.EndDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_date: %w", err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end_date cannot be before start_date")
+	}
+	if start.Year() != end.Year() {
+		return nil, fmt.Errorf("leave request cannot span multiple years")
+	}
+
+	days, err := u.calendar.WorkingDays(profile, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("leave range has no working days")
+	}
+
+	overlap, err := u.repo.HasOverlappingLeave(profile.EmployeeCode, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if overlap {
+		return nil, fmt.Errorf("leave request overlaps an existing request")
+	}
+
+	if leaveType.AnnualQuota > 0 {
+		balance, err := u.repo.FindOrCreateBalance(profile.EmployeeCode, leaveType, start.Year())
+		if err != nil {
+			return nil, err
+		}
+		pending, err := u.repo.SumPendingDays(profile.EmployeeCode, leaveType.ID, start.Year())
+		if err != nil {
+			return nil, err
+		}
+		if balance.Entitled-balance.Used-pending < len(days) {
+			return nil, fmt.Errorf("insufficient leave balance")
+		}
+	}
+
+	leave := &domain.LeaveRequest{
+		EmployeeCode: profile.EmployeeCode,
+		LeaveTypeID:  leaveType.ID,
+		StartDate:    start,
+		EndDate:      end,
+		Days:         len(days),
+		Reason:       req.Reason,
+		Status:       domain.ApprovalPending,
+	}
+	if err := u.repo.CreateLeaveRequest(leave); err != nil {
+		return nil, err
+	}
+	leave.LeaveType = leaveType
+	return mapToLeaveRequestResponse(leave), nil
+}
+
+func (u *leaveUseCase) GetLeaveRequest(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.findAccessibleLeaveRequest(userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToLeaveRequestResponse(leave), nil
+}
+
+func (u *leaveUseCase) ListLeaveRequests(ctx context.Context, userID uuid.UUID, role string, req dto.ListLeaveRequestsRequest) ([]*dto.LeaveRequestResponse, int64, error) {
+	employeeCode := ""
+	if role != string(domain.Admin) || req.UserID != nil {
+		target := userID
+		if role == string(domain.Admin) {
+			target = *req.UserID
+		}
+		profile, err := u.userRepo.FindUserProfileByUserID(target)
+		if err != nil || profile == nil {
+			return nil, 0, fmt.Errorf("user not found")
+		}
+		employeeCode = profile.EmployeeCode
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	requests, total, err := u.repo.FindLeaveRequests(employeeCode, req.Status, offset, req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]*dto.LeaveRequestResponse, len(requests))
+	for i, r := range requests {
+		res[i] = mapToLeaveRequestResponse(r)
+	}
+	return res, total, nil
+}
+
+func (u *leaveUseCase) ApproveLeaveRequest(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.findPendingLeaveRequest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := u.userRepo.FindUserProfileByEmployeeCode(leave.EmployeeCode)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	if err := u.periods.checkRange(leave.StartDate, leave.EndDate); err != nil {
+		return nil, err
+	}
+	if leave.LeaveType == nil {
+		return nil, fmt.Errorf("leave type not found")
+	}
+
+	// Tandai setiap hari kerja sebagai "on_leave" supaya muncul di logs dan current status.
+	// Hari kerja dihitung ulang karena hari libur bisa ditambahkan setelah pengajuan.
+	days, err := u.calendar.WorkingDays(profile, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := u.repo.FindOrCreateBalance(leave.EmployeeCode, leave.LeaveType, leave.StartDate.Year())
+	if err != nil {
+		return nil, err
+	}
+	if leave.LeaveType.AnnualQuota > 0 && balance.Entitled-balance.Used < len(days) {
+		return nil, fmt.Errorf("insufficient leave balance")
+	}
+	// Saldo dipotong oleh repository sesuai hari yang benar-benar ditulis (Days), supaya
+	// refund saat cancel sama persis; len(days) adalah batas atasnya
+	attendances := make([]*domain.Attendance, 0, len(days))
+	for _, day := range days {
+		workDate := day
+		attendances = append(attendances, &domain.Attendance{
+			EmployeeCode:   leave.EmployeeCode,
+			AttendanceID:   attendanceIDFor(leave.EmployeeCode, workDate),
+			WorkDate:       &workDate,
+			Status:         domain.AttendanceStatusOnLeave,
+			LeaveRequestID: &leave.ID,
+		})
+	}
+
+	now := time.Now()
+	leave.Status = domain.ApprovalApproved
+	leave.ReviewedBy = &reviewerID
+	leave.ReviewedAt = &now
+	leave.ReviewNote = req.Note
+
+	if err := u.repo.ApproveLeaveRequest(leave, balance, attendances); err != nil {
+		return nil, err
+	}
+	return mapToLeaveRequestResponse(leave), nil
+}
+
+func (u *leaveUseCase) RejectLeaveRequest(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.findPendingLeaveRequest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	leave.Status = domain.ApprovalRejected
+	leave.ReviewedBy = &reviewerID
+	leave.ReviewedAt = &now
+	leave.ReviewNote = req.Note
+
+	if err := u.repo.RejectLeaveRequest(leave); err != nil {
+		return nil, err
+	}
+	return mapToLeaveRequestResponse(leave), nil
+}
+
+func (u *leaveUseCase) CancelLeaveRequest(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.findAccessibleLeaveRequest(userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance *domain.LeaveBalance
+	switch leave.Status {
+	case domain.ApprovalPending:
+	case domain.ApprovalApproved:
+		// Cuti yang sudah berjalan tidak bisa dibatalkan
+		if !leave.StartDate.After(dateOf(time.Now())) {
+			return nil, fmt.Errorf("leave already started")
+		}
+		if err := u.periods.checkRange(leave.StartDate, leave.EndDate); err != nil {
+			return nil, err
+		}
+		if leave.LeaveType == nil {
+			return nil, fmt.Errorf("leave type not found")
+		}
+		// Days sudah berisi jumlah hari kerja yang dipotong saat approval
+		balance, err = u.repo.FindOrCreateBalance(leave.EmployeeCode, leave.LeaveType, leave.StartDate.Year())
+		if err != nil {
+			return nil, err
+		}
+		balance.Used -= leave.Days
+		if balance.Used < 0 {
+			balance.Used = 0
+		}
+	default:
+		return nil, fmt.Errorf("leave request cannot be cancelled")
+	}
+
+	leave.Status = domain.ApprovalCancelled
+	if err := u.repo.CancelLeaveRequest(leave, balance, dateOf(time.Now())); err != nil {
+		return nil, err
+	}
+	return mapToLeaveRequestResponse(leave), nil
+}
+
+func (u *leaveUseCase) findPendingLeaveRequest(id uuid.UUID) (*domain.LeaveRequest, error) {
+	leave, err := u.repo.FindLeaveRequestByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("leave request not found")
+		}
+		return nil, err
+	}
+	if leave.Status != domain.ApprovalPending {
+		return nil, fmt.Errorf("leave request already reviewed")
+	}
+	return leave, nil
+}
+
+// findAccessibleLeaveRequest memastikan non-admin hanya bisa mengakses cuti miliknya sendiri
+func (u *leaveUseCase) findAccessibleLeaveRequest(userID uuid.UUID, role string, id uuid.UUID) (*domain.LeaveRequest, error) {
+	leave, err := u.repo.FindLeaveRequestByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("leave request not found")
+		}
+		return nil, err
+	}
+	if role != string(domain.Admin) {
+		profile, err := u.userRepo.FindUserProfileByUserID(userID)
+		if err != nil || profile == nil || profile.EmployeeCode != leave.EmployeeCode {
+			return nil, fmt.Errorf("access denied")
+		}
+	}
+	return leave, nil
+}
+
+func mapToLeaveTypeResponse(t *domain.LeaveType) *dto.LeaveTypeResponse {
+	if t == nil {
+		return nil
+	}
+	return &dto.LeaveTypeResponse{
+		ID:          t.ID,
+		Code:        t.Code,
+		Name:        t.Name,
+		IsPaid:      t.IsPaid,
+		AnnualQuota: t.AnnualQuota,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func mapToLeaveBalanceResponse(b *domain.LeaveBalance, pending int) *dto.LeaveBalanceResponse {
+	res := &dto.LeaveBalanceResponse{
+		LeaveTypeID: b.LeaveTypeID,
+		Year:        b.Year,
+		Entitled:    b.Entitled,
+		Used:        b.Used,
+		Pending:     pending,
+	}
+	if b.LeaveType != nil {
+		res.LeaveTypeCode = b.LeaveType.Code
+		res.LeaveTypeName = b.LeaveType.Name
+		if b.LeaveType.AnnualQuota > 0 {
+			remaining := b.Entitled - b.Used - pending
+			res.Remaining = &remaining
+		}
+	}
+	return res
+}
+
+func mapToLeaveRequestResponse(r *domain.LeaveRequest) *dto.LeaveRequestResponse {
+	return &dto.LeaveRequestResponse{
+		ID:           r.ID,
+		EmployeeCode: r.EmployeeCode,
+		LeaveType:    mapToLeaveTypeResponse(r.LeaveType),
+		StartDate:    r.StartDate.Format(dateLayout),
+		EndDate:      r.EndDate.Format(dateLayout),
+		Days:         r.Days,
+		Reason:       r.Reason,
+		Status:       string(r.Status),
+		ReviewedBy:   r.ReviewedBy,
+		ReviewedAt:   r.ReviewedAt,
+		ReviewNote:   r.ReviewNote,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
@@ -0,0 +1,158 @@
+// location_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LocationUseCase interface {
+	CreateLocation(ctx context.Context, req dto.CreateOfficeLocationRequest) (*dto.OfficeLocationResponse, error)
+	GetLocation(ctx context.Context, id uuid.UUID) (*dto.OfficeLocationResponse, error)
+	UpdateLocation(ctx context.Context, id uuid.UUID, req dto.UpdateOfficeLocationRequest) (*dto.OfficeLocationResponse, error)
+	DeleteLocation(ctx context.Context, id uuid.UUID) error
+	GetLocations(ctx context.Context, page, limit int) ([]*dto.OfficeLocationResponse, int64, error)
+}
+
+type locationUseCase struct {
+	repo     repository.LocationRepository
+	deptRepo repository.DepartmentRepository
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewLocationUseCase(repo repository.LocationRepository, deptRepo repository.DepartmentRepository, log *logrus.Logger, validate *validator.Validate) LocationUseCase {
+	return &locationUseCase{repo: repo, deptRepo: deptRepo, log: log, validate: validate}
+}
+
+func (u *locationUseCase) CreateLocation(ctx context.Context, req dto.CreateOfficeLocationRequest) (*dto.OfficeLocationResponse, error) {
+	departments, err := u.findDepartments(req.DepartmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	location := &domain.OfficeLocation{
+		Name:         req.Name,
+		Address:      req.Address,
+		Latitude:     *req.Latitude,
+		Longitude:    *req.Longitude,
+		RadiusMeters: req.RadiusMeters,
+		Departments:  departments,
+	}
+	if err := u.repo.CreateLocation(location); err != nil {
+		return nil, err
+	}
+	return mapToOfficeLocationResponse(location), nil
+}
+
+func (u *locationUseCase) GetLocation(ctx context.Context, id uuid.UUID) (*dto.OfficeLocationResponse, error) {
+	location, err := u.findLocation(id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToOfficeLocationResponse(location), nil
+}
+
+func (u *locationUseCase) UpdateLocation(ctx context.Context, id uuid.UUID, req dto.UpdateOfficeLocationRequest) (*dto.OfficeLocationResponse, error) {
+	location, err := u.findLocation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		location.Name = req.Name
+	}
+	if req.Address != nil {
+		location.Address = *req.Address
+	}
+	if req.Latitude != nil {
+		location.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		location.Longitude = *req.Longitude
+	}
+	if req.RadiusMeters != 0 {
+		location.RadiusMeters = req.RadiusMeters
+	}
+	if req.DepartmentIDs != nil {
+		departments, err := u.findDepartments(*req.DepartmentIDs)
+		if err != nil {
+			return nil, err
+		}
+		location.Departments = departments
+	}
+
+	if err := u.repo.UpdateLocation(location, req.DepartmentIDs != nil); err != nil {
+		return nil, err
+	}
+	return mapToOfficeLocationResponse(location), nil
+}
+
+func (u *locationUseCase) DeleteLocation(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.findLocation(id); err != nil {
+		return err
+	}
+	return u.repo.DeleteLocation(id)
+}
+
+func (u *locationUseCase) GetLocations(ctx context.Context, page, limit int) ([]*dto.OfficeLocationResponse, int64, error) {
+	offset := (page - 1) * limit
+	locations, total, err := u.repo.FindAllLocations(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.OfficeLocationResponse, len(locations))
+	for i, l := range locations {
+		res[i] = mapToOfficeLocationResponse(l)
+	}
+	return res, total, nil
+}
+
+func (u *locationUseCase) findLocation(id uuid.UUID) (*domain.OfficeLocation, error) {
+	location, err := u.repo.FindLocationByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("office location not found")
+		}
+		return nil, err
+	}
+	return location, nil
+}
+
+func (u *locationUseCase) findDepartments(ids []uuid.UUID) ([]*domain.Department, error) {
+	departments := make([]*domain.Department, 0, len(ids))
+	for _, id := range ids {
+		dept, err := u.deptRepo.FindDepartmentByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("department not found")
+		}
+		departments = append(departments, dept)
+	}
+	return departments, nil
+}
+
+func mapToOfficeLocationResponse(l *domain.OfficeLocation) *dto.OfficeLocationResponse {
+	deptIDs := make([]uuid.UUID, len(l.Departments))
+	for i, d := range l.Departments {
+		deptIDs[i] = d.ID
+	}
+	return &dto.OfficeLocationResponse{
+		ID:            l.ID,
+		Name:          l.Name,
+		Address:       l.Address,
+		Latitude:      l.Latitude,
+		Longitude:     l.Longitude,
+		RadiusMeters:  l.RadiusMeters,
+		DepartmentIDs: deptIDs,
+		CreatedAt:     l.CreatedAt,
+		UpdatedAt:     l.UpdatedAt,
+	}
+}
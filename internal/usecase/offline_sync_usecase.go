@@ -0,0 +1,216 @@
+// offline_sync_usecase.go
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type OfflineSyncUseCase interface {
+	SyncClockEvents(ctx context.Context, userID uuid.UUID, req dto.OfflineSyncRequest) (*dto.OfflineSyncResponse, error)
+}
+
+const (
+	offlineDefaultMaxBackdate = 72 * time.Hour
+	offlineClockSkew          = 2 * time.Minute // Toleransi jam HP yang sedikit lebih cepat dari server
+	offlineClaimTimeout       = 2 * time.Minute // Event pending lebih lama dari ini dianggap prosesnya terhenti
+	offlineActionClockOut     = "clock_out"
+	offlineConflictAutoClose  = "auto_clock_out"
+	offlineMessageMaxLength   = 255
+)
+
+type offlineSyncUseCase struct {
+	repo        repository.OfflineSyncRepository
+	profileRepo repository.UserRepository
+	attendance  AttendanceUseCase
+	maxBackdate time.Duration
+	log         *logrus.Logger
+	validate    *validator.Validate
+}
+
+func NewOfflineSyncUseCase(repo repository.OfflineSyncRepository, profileRepo repository.UserRepository, attendance AttendanceUseCase, config *viper.Viper, log *logrus.Logger, validate *validator.Validate) OfflineSyncUseCase {
+	maxBackdate := config.GetDuration("attendance.offlineMaxBackdate")
+	if maxBackdate <= 0 {
+		maxBackdate = offlineDefaultMaxBackdate
+	}
+	return &offlineSyncUseCase{repo: repo, profileRepo: profileRepo, attendance: attendance,
+		maxBackdate: maxBackdate, log: log, validate: validate}
+}
+
+// SyncClockEvents menerapkan event offline satu per satu sesuai urutan client_time, jadi
+// clock in offline diproses sebelum clock out pasangannya. Aturan clock in/out biasa
+// (geofence, mode, hari libur, periode payroll terkunci) tetap berlaku dengan waktu client.
+// Clock out offline yang lebih awal dari auto clock out sistem menggantikan auto clock out
+// tersebut; yang lebih lambat ditolak dengan conflict auto_clock_out.
+// Error infrastruktur menghentikan batch; client cukup mengirim ulang batch yang sama
+// karena event yang sudah diproses tidak akan diproses lagi.
+func (u *offlineSyncUseCase) SyncClockEvents(ctx context.Context, userID uuid.UUID, req dto.OfflineSyncRequest) (*dto.OfflineSyncResponse, error) {
+	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	events := make([]dto.OfflineClockEventRequest, len(req.Events))
+	copy(events, req.Events)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].ClientTime.Before(events[j].ClientTime)
+	})
+
+	response := &dto.OfflineSyncResponse{Results: make([]dto.OfflineClockEventResult, 0, len(events))}
+	for _, ev := range events {
+		result, err := u.syncEvent(ctx, userID, profile, ev)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case result.Duplicate:
+			response.Duplicates++
+		case result.Status == string(domain.OfflineEventApplied):
+			response.Applied++
+		case result.Status == string(domain.OfflineEventRejected):
+			response.Rejected++
+		}
+		response.Results = append(response.Results, *result)
+	}
+	return response, nil
+}
+
+func (u *offlineSyncUseCase) syncEvent(ctx context.Context, userID uuid.UUID, profile *domain.UserProfile, ev dto.OfflineClockEventRequest) (*dto.OfflineClockEventResult, error) {
+	// Presisi Postgres mikrodetik; claimed_at harus sama persis saat claim dilepas
+	now := time.Now().Truncate(time.Microsecond)
+	record := &domain.OfflineClockEvent{
+		EventID:      ev.EventID,
+		EmployeeCode: profile.EmployeeCode,
+		Action:       ev.Action,
+		ClientTime:   ev.ClientTime,
+		Status:       domain.OfflineEventPending,
+		ClaimedAt:    now,
+	}
+	claimed, err := u.repo.ClaimEvent(record, now.Add(-offlineClaimTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		existing, err := u.repo.FindEvent(profile.EmployeeCode, ev.EventID)
+		if err != nil {
+			return nil, err
+		}
+		result := mapToOfflineClockEventResult(existing)
+		result.Duplicate = true
+		return result, nil
+	}
+
+	attendance, err := u.applyEvent(ctx, userID, ev, now)
+	if err != nil && !isOfflineRejection(err) {
+		// Error database / storage bukan penolakan: claim dilepas supaya batch bisa dikirim ulang
+		if releaseErr := u.repo.ReleaseEvent(record); releaseErr != nil {
+			u.log.WithError(releaseErr).WithField("event_id", ev.EventID).Error("Failed to release offline clock event claim")
+		}
+		return nil, err
+	}
+	processedAt := time.Now()
+	record.ProcessedAt = &processedAt
+	if err != nil {
+		record.Status = domain.OfflineEventRejected
+		record.Message = truncateOfflineMessage(err.Error())
+		if errors.Is(err, ErrAutoClockOutConflict) {
+			record.Conflict = offlineConflictAutoClose
+		}
+	} else {
+		record.Status = domain.OfflineEventApplied
+		record.AttendanceID = attendance.AttendanceID
+	}
+	if err := u.repo.CompleteEvent(record); err != nil {
+		// Attendance sudah tersimpan; event tetap pending dan akan dianggap terhenti setelah timeout
+		u.log.WithError(err).WithField("event_id", ev.EventID).Error("Failed to record offline clock event result")
+	}
+
+	result := mapToOfflineClockEventResult(record)
+	result.Attendance = attendance
+	return result, nil
+}
+
+// applyEvent memvalidasi jendela waktu event lalu menjalankan clock in/out dengan waktu client
+func (u *offlineSyncUseCase) applyEvent(ctx context.Context, userID uuid.UUID, ev dto.OfflineClockEventRequest, now time.Time) (*dto.AttendanceResponse, error) {
+	if ev.ClientTime.After(now.Add(offlineClockSkew)) {
+		return nil, fmt.Errorf("event time is in the future")
+	}
+	if now.Sub(ev.ClientTime) > u.maxBackdate {
+		return nil, fmt.Errorf("event is older than the allowed backdating window of %s", u.maxBackdate)
+	}
+
+	// Semua perhitungan attendance memakai jam dinding server, jadi offset dari client dinormalisasi dulu
+	occurredAt := ev.ClientTime.In(time.Local)
+	source := dto.ClockSource{OccurredAt: &occurredAt}
+	if ev.Action == offlineActionClockOut {
+		return u.attendance.ClockOut(ctx, userID, dto.ClockOutRequest{
+			Latitude:  ev.Latitude,
+			Longitude: ev.Longitude,
+			Accuracy:  ev.Accuracy,
+			Source:    source,
+		})
+	}
+	return u.attendance.ClockIn(ctx, userID, dto.ClockInRequest{
+		Mode:      ev.Mode,
+		Latitude:  ev.Latitude,
+		Longitude: ev.Longitude,
+		Accuracy:  ev.Accuracy,
+		Source:    source,
+	})
+}
+
+// offlineInfrastructureErrors adalah sentinel database dan context yang berbentuk error biasa
+var offlineInfrastructureErrors = []error{
+	context.Canceled, context.DeadlineExceeded,
+	gorm.ErrRecordNotFound, gorm.ErrInvalidTransaction, gorm.ErrInvalidDB,
+	sql.ErrConnDone, sql.ErrTxDone, driver.ErrBadConn,
+}
+
+// isOfflineRejection membedakan penolakan aturan bisnis (disimpan sebagai rejected) dari error
+// infrastruktur. Penolakan dibuat usecase dengan fmt.Errorf tanpa membungkus error lain, kecuali
+// ErrAutoClockOutConflict; error database dan storage punya tipe sendiri atau berupa sentinel di atas.
+func isOfflineRejection(err error) bool {
+	if errors.Is(err, ErrAutoClockOutConflict) {
+		return true
+	}
+	for _, infra := range offlineInfrastructureErrors {
+		if errors.Is(err, infra) {
+			return false
+		}
+	}
+	return errors.Unwrap(err) == nil && reflect.TypeOf(err) == reflect.TypeOf(errors.New(""))
+}
+
+func truncateOfflineMessage(message string) string {
+	if len(message) > offlineMessageMaxLength {
+		return message[:offlineMessageMaxLength]
+	}
+	return message
+}
+
+func mapToOfflineClockEventResult(e *domain.OfflineClockEvent) *dto.OfflineClockEventResult {
+	return &dto.OfflineClockEventResult{
+		EventID:      e.EventID,
+		Action:       e.Action,
+		ClientTime:   e.ClientTime,
+		Status:       string(e.Status),
+		Error:        e.Message,
+		Conflict:     e.Conflict,
+		AttendanceID: e.AttendanceID,
+	}
+}
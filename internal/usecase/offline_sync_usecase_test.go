@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestIsOfflineRejection(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"business rule", fmt.Errorf("already clocked in today"), true},
+		{"pay period locked", fmt.Errorf("pay period is locked"), true},
+		{"auto clock out conflict", fmt.Errorf("%w at 2025-09-01 23:00, before this clock out", ErrAutoClockOutConflict), true},
+		{"record not found", gorm.ErrRecordNotFound, false},
+		{"bad connection", driver.ErrBadConn, false},
+		{"context cancelled", context.Canceled, false},
+		{"wrapped database error", fmt.Errorf("insert attendance: %w", errors.New("connection reset")), false},
+		{"storage failure", &os.PathError{Op: "open", Path: "attendance/x.jpg", Err: os.ErrPermission}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isOfflineRejection(tc.err); got != tc.want {
+				t.Errorf("isOfflineRejection(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
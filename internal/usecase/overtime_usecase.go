@@ -0,0 +1,405 @@
+// overtime_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// maxOvertimePeriodDays membatasi rentang summary lembur (satu tahun)
+const maxOvertimePeriodDays = 366
+
+type OvertimeUseCase interface {
+	SubmitOvertime(ctx context.Context, userID uuid.UUID, req dto.CreateOvertimeRequest) (*dto.OvertimeResponse, error)
+	GetOvertime(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.OvertimeResponse, error)
+	ListOvertime(ctx context.Context, userID uuid.UUID, role string, req dto.ListOvertimeRequest) ([]*dto.OvertimeResponse, int64, error)
+	ApproveOvertime(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewOvertimeRequest) (*dto.OvertimeResponse, error)
+	RejectOvertime(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewOvertimeRequest) (*dto.OvertimeResponse, error)
+	CancelOvertime(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.OvertimeResponse, error)
+	GetOvertimeSummary(ctx context.Context, userID uuid.UUID, role string, req dto.OvertimeSummaryRequest) ([]*dto.OvertimeSummaryResponse, error)
+}
+
+type overtimeUseCase struct {
+	repo     repository.OvertimeRepository
+	attRepo  repository.AttendanceRepository
+	userRepo repository.UserRepository
+	periods  payPeriodGuard
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewOvertimeUseCase(repo repository.OvertimeRepository, attRepo repository.AttendanceRepository, userRepo repository.UserRepository, payrollRepo repository.PayrollRepository, log *logrus.Logger, validate *validator.Validate) OvertimeUseCase {
+	return &overtimeUseCase{repo: repo, attRepo: attRepo, userRepo: userRepo, periods: payPeriodGuard{repo: payrollRepo}, log: log, validate: validate}
+}
+
+func (u *overtimeUseCase) SubmitOvertime(ctx context.Context, userID uuid.UUID, req dto.CreateOvertimeRequest) (*dto.OvertimeResponse, error) {
+	profile, err := u.userRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	workDate, err := time.ParseInLocation(dateLayout, req.WorkDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid work_date: %w", err)
+	}
+	today := dateOf(time.Now())
+
+	minutes := req.Minutes
+	switch domain.OvertimeKind(req.Kind) {
+	case domain.OvertimePreApproval:
+		if workDate.Before(today) {
+			return nil, fmt.Errorf("pre approval must be submitted before the work date")
+		}
+		if minutes == 0 {
+			return nil, fmt.Errorf("minutes is required for pre approval")
+		}
+	case domain.OvertimePostApproval:
+		if workDate.After(today) {
+			return nil, fmt.Errorf("cannot submit post approval for a future work date")
+		}
+		actual, err := u.actualOvertime(u.attRepo.GetAttendanceQuery().
+			Where("a.attendance_id = ?", attendanceIDFor(profile.EmployeeCode, workDate)))
+		if err != nil {
+			return nil, err
+		}
+		actualMinutes := actual[profile.EmployeeCode][workDate.Format(dateLayout)]
+		if actualMinutes == 0 {
+			return nil, fmt.Errorf("no overtime recorded for this work date")
+		}
+		if minutes == 0 {
+			minutes = actualMinutes
+		}
+		if minutes > actualMinutes {
+			return nil, fmt.Errorf("requested minutes exceed actual overtime of %d minutes", actualMinutes)
+		}
+	default:
+		return nil, fmt.Errorf("invalid overtime kind")
+	}
+
+	active, err := u.repo.HasActiveOvertimeRequest(profile.EmployeeCode, workDate)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, fmt.Errorf("an overtime request already exists for this work date")
+	}
+
+	request := &domain.OvertimeRequest{
+		EmployeeCode: profile.EmployeeCode,
+		WorkDate:     workDate,
+		Kind:         domain.OvertimeKind(req.Kind),
+		Minutes:      minutes,
+		Reason:       req.Reason,
+		Status:       domain.ApprovalPending,
+	}
+	if err := u.repo.CreateOvertimeRequest(request); err != nil {
+		return nil, err
+	}
+	return mapToOvertimeResponse(request), nil
+}
+
+func (u *overtimeUseCase) GetOvertime(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.OvertimeResponse, error) {
+	request, err := u.findAccessibleOvertime(userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToOvertimeResponse(request), nil
+}
+
+func (u *overtimeUseCase) ListOvertime(ctx context.Context, userID uuid.UUID, role string, req dto.ListOvertimeRequest) ([]*dto.OvertimeResponse, int64, error) {
+	employeeCode, err := u.targetEmployeeCode(userID, role, req.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	requests, total, err := u.repo.FindOvertimeRequests(employeeCode, req.Status, offset, req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]*dto.OvertimeResponse, len(requests))
+	for i, r := range requests {
+		res[i] = mapToOvertimeResponse(r)
+	}
+	return res, total, nil
+}
+
+func (u *overtimeUseCase) ApproveOvertime(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewOvertimeRequest) (*dto.OvertimeResponse, error) {
+	request, err := u.findPendingOvertime(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.periods.check(request.WorkDate); err != nil {
+		return nil, err
+	}
+
+	approved := request.Minutes
+	if req.ApprovedMinutes > 0 {
+		if req.ApprovedMinutes > request.Minutes {
+			return nil, fmt.Errorf("approved minutes cannot exceed requested minutes")
+		}
+		approved = req.ApprovedMinutes
+	}
+
+	now := time.Now()
+	request.Status = domain.ApprovalApproved
+	request.ApprovedMinutes = approved
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+
+	if err := u.repo.UpdateOvertimeRequest(request); err != nil {
+		return nil, err
+	}
+	return mapToOvertimeResponse(request), nil
+}
+
+func (u *overtimeUseCase) RejectOvertime(ctx context.Context, reviewerID uuid.UUID, id uuid.UUID, req dto.ReviewOvertimeRequest) (*dto.OvertimeResponse, error) {
+	request, err := u.findPendingOvertime(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request.Status = domain.ApprovalRejected
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+
+	if err := u.repo.UpdateOvertimeRequest(request); err != nil {
+		return nil, err
+	}
+	return mapToOvertimeResponse(request), nil
+}
+
+func (u *overtimeUseCase) CancelOvertime(ctx context.Context, userID uuid.UUID, role string, id uuid.UUID) (*dto.OvertimeResponse, error) {
+	request, err := u.findAccessibleOvertime(userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.ApprovalPending {
+		return nil, fmt.Errorf("overtime request already reviewed")
+	}
+
+	request.Status = domain.ApprovalCancelled
+	if err := u.repo.UpdateOvertimeRequest(request); err != nil {
+		return nil, err
+	}
+	return mapToOvertimeResponse(request), nil
+}
+
+// GetOvertimeSummary menjumlahkan lembur per karyawan dalam satu periode. Menit yang disetujui
+// dibatasi lembur aktual pada hari tersebut, jadi pre approval yang tidak dikerjakan tidak dibayar.
+func (u *overtimeUseCase) GetOvertimeSummary(ctx context.Context, userID uuid.UUID, role string, req dto.OvertimeSummaryRequest) ([]*dto.OvertimeSummaryResponse, error) {
+	start, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_date: %w", err)
+	}
+	end, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_date: %w", err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end_date must not be before start_date")
+	}
+	if end.Sub(start) > maxOvertimePeriodDays*24*time.Hour {
+		return nil, fmt.Errorf("period cannot be longer than %d days", maxOvertimePeriodDays)
+	}
+
+	employeeCode, err := u.targetEmployeeCode(userID, role, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.attRepo.GetAttendanceQuery().
+		Where("COALESCE(a.work_date, DATE(a.clock_in)) BETWEEN ? AND ?", req.StartDate, req.EndDate)
+	if employeeCode != "" {
+		query = query.Where("a.employee_code = ?", employeeCode)
+	}
+	if req.DepartmentID != nil {
+		query = query.Where("up.department_id = ?", *req.DepartmentID)
+	}
+
+	var rawLogs []dto.RawAttendanceLog
+	if err := query.Scan(&rawLogs).Error; err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*dto.OvertimeSummaryResponse)
+	actual := make(map[string]map[string]int)
+	for _, raw := range rawLogs {
+		summary, ok := summaries[raw.EmployeeCode]
+		if !ok {
+			summary = &dto.OvertimeSummaryResponse{
+				EmployeeCode:   raw.EmployeeCode,
+				FullName:       raw.FullName,
+				DepartmentName: raw.DepartmentName,
+			}
+			summaries[raw.EmployeeCode] = summary
+			actual[raw.EmployeeCode] = make(map[string]int)
+		}
+
+		schedule, err := scheduleFromRawLog(raw)
+		if err != nil {
+			return nil, err
+		}
+		minutes := overtimeFromRawLog(raw, schedule)
+		if minutes > 0 {
+			actual[raw.EmployeeCode][raw.WorkDate.Format(dateLayout)] = minutes
+			summary.OvertimeDays++
+			summary.ActualMinutes += minutes
+		}
+	}
+
+	requests, err := u.repo.FindOvertimeRequestsBetween(employeeCode, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range requests {
+		summary, ok := summaries[r.EmployeeCode]
+		if !ok {
+			continue
+		}
+		switch r.Status {
+		case domain.ApprovalApproved:
+			summary.ApprovedMinutes += min(r.ApprovedMinutes, actual[r.EmployeeCode][r.WorkDate.Format(dateLayout)])
+		case domain.ApprovalPending:
+			summary.PendingMinutes += r.Minutes
+		}
+	}
+
+	res := make([]*dto.OvertimeSummaryResponse, 0, len(summaries))
+	for _, s := range summaries {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].EmployeeCode < res[j].EmployeeCode })
+	return res, nil
+}
+
+// actualOvertime menghitung lembur aktual dari hasil GetAttendanceQuery, per employee code lalu per work date
+func (u *overtimeUseCase) actualOvertime(query *gorm.DB) (map[string]map[string]int, error) {
+	var rawLogs []dto.RawAttendanceLog
+	if err := query.Scan(&rawLogs).Error; err != nil {
+		return nil, err
+	}
+
+	actual := make(map[string]map[string]int)
+	for _, raw := range rawLogs {
+		schedule, err := scheduleFromRawLog(raw)
+		if err != nil {
+			return nil, err
+		}
+		if actual[raw.EmployeeCode] == nil {
+			actual[raw.EmployeeCode] = make(map[string]int)
+		}
+		actual[raw.EmployeeCode][raw.WorkDate.Format(dateLayout)] = overtimeFromRawLog(raw, schedule)
+	}
+	return actual, nil
+}
+
+// targetEmployeeCode menentukan karyawan yang datanya diambil: non-admin selalu dirinya sendiri,
+// admin bisa memilih user_id atau kosong untuk semua karyawan
+func (u *overtimeUseCase) targetEmployeeCode(userID uuid.UUID, role string, requested *uuid.UUID) (string, error) {
+	if role == string(domain.Admin) && requested == nil {
+		return "", nil
+	}
+	target := userID
+	if role == string(domain.Admin) {
+		target = *requested
+	}
+	profile, err := u.userRepo.FindUserProfileByUserID(target)
+	if err != nil || profile == nil {
+		return "", fmt.Errorf("user not found")
+	}
+	return profile.EmployeeCode, nil
+}
+
+func (u *overtimeUseCase) findPendingOvertime(id uuid.UUID) (*domain.OvertimeRequest, error) {
+	request, err := u.repo.FindOvertimeRequestByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("overtime request not found")
+		}
+		return nil, err
+	}
+	if request.Status != domain.ApprovalPending {
+		return nil, fmt.Errorf("overtime request already reviewed")
+	}
+	return request, nil
+}
+
+// findAccessibleOvertime memastikan non-admin hanya bisa mengakses request miliknya sendiri
+func (u *overtimeUseCase) findAccessibleOvertime(userID uuid.UUID, role string, id uuid.UUID) (*domain.OvertimeRequest, error) {
+	request, err := u.repo.FindOvertimeRequestByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("overtime request not found")
+		}
+		return nil, err
+	}
+	if role != string(domain.Admin) {
+		profile, err := u.userRepo.FindUserProfileByUserID(userID)
+		if err != nil || profile == nil || profile.EmployeeCode != request.EmployeeCode {
+			return nil, fmt.Errorf("access denied")
+		}
+	}
+	return request, nil
+}
+
+// overtimeFromRawLog menghitung lembur aktual satu attendance: waktu setelah jam pulang,
+// atau seluruh waktu kerja bersih jika masuk di hari libur / hari di luar jadwal shift.
+// Clock out otomatis oleh sistem tidak pernah dihitung lembur.
+func overtimeFromRawLog(raw dto.RawAttendanceLog, schedule *workSchedule) int {
+	if raw.ClockIn == nil || raw.ClockOut == nil || raw.AutoClosed {
+		return 0
+	}
+
+	var minutes int
+	if raw.HolidayWork || !schedule.Scheduled {
+		minutes = int(raw.ClockOut.Sub(*raw.ClockIn).Minutes()) - raw.BreakSeconds/60
+	} else {
+		targetOut := schedule.TargetOut(dateIn(raw.WorkDate, raw.ClockOut.Location()))
+		minutes = int(raw.ClockOut.Sub(targetOut).Minutes())
+	}
+	return applyOvertimePolicy(minutes, raw.OvertimeMinMinutes, raw.OvertimeRoundingMinutes)
+}
+
+// applyOvertimePolicy membuang lembur di bawah threshold lalu membulatkan ke bawah per roundingMinutes
+func applyOvertimePolicy(minutes, minMinutes, roundingMinutes int) int {
+	if minutes <= 0 || minutes < minMinutes {
+		return 0
+	}
+	if roundingMinutes > 1 {
+		minutes -= minutes % roundingMinutes
+	}
+	return minutes
+}
+
+func mapToOvertimeResponse(r *domain.OvertimeRequest) *dto.OvertimeResponse {
+	return &dto.OvertimeResponse{
+		ID:              r.ID,
+		EmployeeCode:    r.EmployeeCode,
+		WorkDate:        r.WorkDate.Format(dateLayout),
+		Kind:            string(r.Kind),
+		Minutes:         r.Minutes,
+		ApprovedMinutes: r.ApprovedMinutes,
+		Reason:          r.Reason,
+		Status:          string(r.Status),
+		ReviewedBy:      r.ReviewedBy,
+		ReviewedAt:      r.ReviewedAt,
+		ReviewNote:      r.ReviewNote,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
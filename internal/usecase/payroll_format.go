@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"employee-attendance-system/internal/entity/dto"
+	utils "employee-attendance-system/internal/util"
+	"encoding/json"
+	"io"
+)
+
+// PayrollFormatter menulis rekap payroll ke format file tertentu. Format baru cukup
+// mengimplementasikan interface ini lalu didaftarkan di payrollFormatters.
+type PayrollFormatter interface {
+	ContentType() string
+	Extension() string
+	Format(w io.Writer, summary *dto.PayrollSummary) error
+}
+
+var payrollFormatters = map[string]PayrollFormatter{
+	"csv":  csvPayrollFormatter{},
+	"json": jsonPayrollFormatter{},
+}
+
+var payrollExportHeader = []any{
+	"employee_code", "full_name", "department", "period_start", "period_end",
+	"worked_days", "worked_minutes", "late_count", "late_minutes", "absent_days",
+	"paid_leave_days", "unpaid_leave_days", "overtime_minutes", "actual_overtime_minutes",
+}
+
+type csvPayrollFormatter struct{}
+
+func (csvPayrollFormatter) ContentType() string { return "text/csv" }
+func (csvPayrollFormatter) Extension() string   { return "csv" }
+
+func (csvPayrollFormatter) Format(w io.Writer, summary *dto.PayrollSummary) error {
+	table := utils.NewCSVTableWriter(w)
+	if err := table.WriteRow(payrollExportHeader); err != nil {
+		return err
+	}
+	for _, e := range summary.Employees {
+		if err := table.WriteRow([]any{
+			e.EmployeeCode, e.FullName, e.DepartmentName, summary.StartDate, summary.EndDate,
+			e.WorkedDays, e.WorkedMinutes, e.LateCount, e.LateMinutes, e.AbsentDays,
+			e.PaidLeaveDays, e.UnpaidLeaveDays, e.OvertimeMinutes, e.ActualOvertimeMinutes,
+		}); err != nil {
+			return err
+		}
+	}
+	return table.Close()
+}
+
+type jsonPayrollFormatter struct{}
+
+func (jsonPayrollFormatter) ContentType() string { return "application/json" }
+func (jsonPayrollFormatter) Extension() string   { return "json" }
+
+func (jsonPayrollFormatter) Format(w io.Writer, summary *dto.PayrollSummary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(summary)
+}
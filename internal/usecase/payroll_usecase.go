@@ -0,0 +1,428 @@
+// payroll_usecase.go
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PayrollUseCase interface {
+	ExportPayroll(ctx context.Context, adminID uuid.UUID, req dto.PayrollExportRequest) (*dto.PayrollExportFile, error)
+	LockAndExportPayroll(ctx context.Context, adminID uuid.UUID, req dto.LockPayrollExportRequest) (*dto.PayrollExportFile, error)
+	LockPayPeriod(ctx context.Context, adminID uuid.UUID, req dto.LockPayPeriodRequest) (*dto.PayPeriodResponse, error)
+	RelockPayPeriod(ctx context.Context, adminID uuid.UUID, id uuid.UUID) (*dto.PayPeriodResponse, error)
+	UnlockPayPeriod(ctx context.Context, adminID uuid.UUID, id uuid.UUID) (*dto.PayPeriodResponse, error)
+	ListPayPeriods(ctx context.Context, req dto.ListPayPeriodsRequest) ([]*dto.PayPeriodResponse, int64, error)
+}
+
+type payrollUseCase struct {
+	repo         repository.PayrollRepository
+	attRepo      repository.AttendanceRepository
+	overtimeRepo repository.OvertimeRepository
+	log          *logrus.Logger
+	validate     *validator.Validate
+}
+
+func NewPayrollUseCase(repo repository.PayrollRepository, attRepo repository.AttendanceRepository, overtimeRepo repository.OvertimeRepository, log *logrus.Logger, validate *validator.Validate) PayrollUseCase {
+	return &payrollUseCase{repo: repo, attRepo: attRepo, overtimeRepo: overtimeRepo, log: log, validate: validate}
+}
+
+// maxPayPeriodDays membatasi panjang satu pay period
+const maxPayPeriodDays = 62
+
+// ExportPayroll merekap attendance per karyawan untuk satu pay period lalu memformatnya sesuai
+// format yang diminta. Tidak mengubah status pay period; gunakan LockAndExportPayroll untuk mengunci.
+func (u *payrollUseCase) ExportPayroll(ctx context.Context, adminID uuid.UUID, req dto.PayrollExportRequest) (*dto.PayrollExportFile, error) {
+	start, end, err := parsePayPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	formatter, err := payrollFormatterFor(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := u.render(formatter, start, end, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := u.repo.FindPayPeriodByRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if period != nil {
+		if err := u.markExported(period); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+// LockAndExportPayroll mengunci pay period lebih dulu, baru merekapnya, sehingga tidak ada perubahan
+// attendance yang ikut terekspor lalu terbekukan di antara rekap dan lock. Jika rekap gagal, lock
+// dikembalikan ke status sebelumnya.
+func (u *payrollUseCase) LockAndExportPayroll(ctx context.Context, adminID uuid.UUID, req dto.LockPayrollExportRequest) (*dto.PayrollExportFile, error) {
+	if req.DepartmentID != nil {
+		return nil, fmt.Errorf("pay period lock applies to all departments and cannot be filtered by department")
+	}
+	start, end, err := parsePayPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	formatter, err := payrollFormatterFor(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := u.repo.FindPayPeriodByRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	var previous *domain.PayPeriod
+	if period != nil {
+		snapshot := *period
+		previous = &snapshot
+	}
+	if period, err = u.lock(adminID, period, start, end, req.Note); err != nil {
+		return nil, err
+	}
+
+	file, err := u.render(formatter, start, end, nil)
+	if err != nil {
+		u.revertLock(period, previous)
+		return nil, err
+	}
+	if err := u.markExported(period); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// revertLock mengembalikan pay period yang baru dikunci ke status sebelum LockAndExportPayroll
+func (u *payrollUseCase) revertLock(period, previous *domain.PayPeriod) {
+	if previous != nil {
+		*period = *previous
+	} else {
+		period.Status = domain.PayPeriodOpen
+		period.LockedBy = nil
+		period.LockedAt = nil
+	}
+	if err := u.repo.UpdatePayPeriod(period); err != nil {
+		u.log.WithError(err).WithField("pay_period_id", period.ID).Error("Failed to revert pay period lock after failed export")
+	}
+}
+
+func (u *payrollUseCase) markExported(period *domain.PayPeriod) error {
+	now := time.Now()
+	period.LastExportedAt = &now
+	return u.repo.UpdatePayPeriod(period)
+}
+
+func payrollFormatterFor(name string) (PayrollFormatter, error) {
+	if name == "" {
+		name = "csv"
+	}
+	formatter, ok := payrollFormatters[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported payroll format")
+	}
+	return formatter, nil
+}
+
+// render merekap pay period dan memformatnya menjadi file export
+func (u *payrollUseCase) render(formatter PayrollFormatter, start, end time.Time, departmentID *uuid.UUID) (*dto.PayrollExportFile, error) {
+	summary, err := u.summarize(start, end, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, summary); err != nil {
+		return nil, err
+	}
+	return &dto.PayrollExportFile{
+		Filename:    fmt.Sprintf("payroll-%s-%s.%s", start.Format(dateLayout), end.Format(dateLayout), formatter.Extension()),
+		ContentType: formatter.ContentType(),
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+// summarize memakai query dan rule punctuality yang sama dengan logs. Lembur yang dibayar adalah
+// menit yang disetujui, dibatasi lembur aktual di hari tersebut (sama seperti overtime summary).
+func (u *payrollUseCase) summarize(start, end time.Time, departmentID *uuid.UUID) (*dto.PayrollSummary, error) {
+	query := u.attRepo.GetAttendanceQuery().
+		Where("COALESCE(a.work_date, DATE(a.clock_in)) BETWEEN ? AND ?", start.Format(dateLayout), end.Format(dateLayout))
+	if departmentID != nil {
+		query = query.Where("up.department_id = ?", *departmentID)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := make(map[string]*dto.PayrollEmployeeSummary)
+	actualOvertime := make(map[string]map[string]int)
+	employee := func(code, name, department string) *dto.PayrollEmployeeSummary {
+		e, ok := employees[code]
+		if !ok {
+			e = &dto.PayrollEmployeeSummary{EmployeeCode: code, FullName: name, DepartmentName: department}
+			employees[code] = e
+			actualOvertime[code] = make(map[string]int)
+		}
+		return e
+	}
+
+	for rows.Next() {
+		var raw dto.RawAttendanceLog
+		if err := query.ScanRows(rows, &raw); err != nil {
+			return nil, err
+		}
+		e := employee(raw.EmployeeCode, raw.FullName, raw.DepartmentName)
+		switch domain.AttendanceStatus(raw.Status) {
+		case domain.AttendanceStatusAbsent:
+			e.AbsentDays++
+			continue
+		case domain.AttendanceStatusOnLeave:
+			continue // dihitung dari CountLeaveDays supaya bisa dipisah berbayar / tidak
+		}
+
+		schedule, err := scheduleFromRawLog(raw)
+		if err != nil {
+			return nil, err
+		}
+		result := evaluateRawLog(raw, schedule)
+		if raw.ClockIn != nil {
+			e.WorkedDays++
+		}
+		if result.WorkedMinutes != nil {
+			e.WorkedMinutes += *result.WorkedMinutes
+		}
+		if result.LateMinutes > 0 {
+			e.LateCount++
+			e.LateMinutes += result.LateMinutes
+		}
+		if minutes := overtimeFromRawLog(raw, schedule); minutes > 0 {
+			e.ActualOvertimeMinutes += minutes
+			actualOvertime[raw.EmployeeCode][raw.WorkDate.Format(dateLayout)] = minutes
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	leaves, err := u.repo.CountLeaveDays(start, end, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range leaves {
+		e, ok := employees[l.EmployeeCode]
+		if !ok {
+			continue
+		}
+		e.PaidLeaveDays = l.PaidLeaveDays
+		e.UnpaidLeaveDays = l.UnpaidLeaveDays
+	}
+
+	requests, err := u.overtimeRepo.FindOvertimeRequestsBetween("", start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range requests {
+		e, ok := employees[r.EmployeeCode]
+		if !ok || r.Status != domain.ApprovalApproved {
+			continue
+		}
+		e.OvertimeMinutes += min(r.ApprovedMinutes, actualOvertime[r.EmployeeCode][r.WorkDate.Format(dateLayout)])
+	}
+
+	summary := &dto.PayrollSummary{
+		StartDate:   start.Format(dateLayout),
+		EndDate:     end.Format(dateLayout),
+		GeneratedAt: time.Now(),
+		Employees:   make([]dto.PayrollEmployeeSummary, 0, len(employees)),
+	}
+	for _, e := range employees {
+		summary.Employees = append(summary.Employees, *e)
+	}
+	sort.Slice(summary.Employees, func(i, j int) bool {
+		return summary.Employees[i].EmployeeCode < summary.Employees[j].EmployeeCode
+	})
+	return summary, nil
+}
+
+func (u *payrollUseCase) LockPayPeriod(ctx context.Context, adminID uuid.UUID, req dto.LockPayPeriodRequest) (*dto.PayPeriodResponse, error) {
+	start, end, err := parsePayPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	period, err := u.repo.FindPayPeriodByRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	period, err = u.lock(adminID, period, start, end, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return mapToPayPeriodResponse(period), nil
+}
+
+func (u *payrollUseCase) RelockPayPeriod(ctx context.Context, adminID uuid.UUID, id uuid.UUID) (*dto.PayPeriodResponse, error) {
+	period, err := u.findPayPeriod(id)
+	if err != nil {
+		return nil, err
+	}
+	period, err = u.lock(adminID, period, period.StartDate, period.EndDate, "")
+	if err != nil {
+		return nil, err
+	}
+	return mapToPayPeriodResponse(period), nil
+}
+
+func (u *payrollUseCase) UnlockPayPeriod(ctx context.Context, adminID uuid.UUID, id uuid.UUID) (*dto.PayPeriodResponse, error) {
+	period, err := u.findPayPeriod(id)
+	if err != nil {
+		return nil, err
+	}
+	if period.Status != domain.PayPeriodLocked {
+		return nil, fmt.Errorf("pay period is not locked")
+	}
+	now := time.Now()
+	period.Status = domain.PayPeriodOpen
+	period.UnlockedBy = &adminID
+	period.UnlockedAt = &now
+	if err := u.repo.UpdatePayPeriod(period); err != nil {
+		return nil, err
+	}
+	return mapToPayPeriodResponse(period), nil
+}
+
+func (u *payrollUseCase) ListPayPeriods(ctx context.Context, req dto.ListPayPeriodsRequest) ([]*dto.PayPeriodResponse, int64, error) {
+	periods, total, err := u.repo.FindPayPeriods(req.Status, (req.Page-1)*req.Limit, req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.PayPeriodResponse, len(periods))
+	for i, p := range periods {
+		res[i] = mapToPayPeriodResponse(p)
+	}
+	return res, total, nil
+}
+
+// lock mengunci period (atau membuat baru jika nil). Pay period terkunci tidak boleh beririsan.
+func (u *payrollUseCase) lock(adminID uuid.UUID, period *domain.PayPeriod, start, end time.Time, note string) (*domain.PayPeriod, error) {
+	if period != nil && period.Status == domain.PayPeriodLocked {
+		return nil, fmt.Errorf("pay period already locked")
+	}
+	var excludeID *uuid.UUID
+	if period != nil {
+		excludeID = &period.ID
+	}
+	overlapping, err := u.repo.FindLockedPayPeriod(start, end, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	if overlapping != nil {
+		return nil, fmt.Errorf("overlaps with a locked pay period")
+	}
+
+	now := time.Now()
+	if period == nil {
+		period = &domain.PayPeriod{StartDate: start, EndDate: end}
+	}
+	period.Status = domain.PayPeriodLocked
+	period.LockedBy = &adminID
+	period.LockedAt = &now
+	if note != "" {
+		period.Note = note
+	}
+	if period.ID == uuid.Nil {
+		err = u.repo.CreatePayPeriod(period)
+	} else {
+		err = u.repo.UpdatePayPeriod(period)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+func (u *payrollUseCase) findPayPeriod(id uuid.UUID) (*domain.PayPeriod, error) {
+	period, err := u.repo.FindPayPeriodByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("pay period not found")
+		}
+		return nil, err
+	}
+	return period, nil
+}
+
+func parsePayPeriod(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.Parse(dateLayout, startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date: %w", err)
+	}
+	end, err := time.Parse(dateLayout, endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date: %w", err)
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must not be before start_date")
+	}
+	if end.Sub(start) >= maxPayPeriodDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("pay period cannot be longer than %d days", maxPayPeriodDays)
+	}
+	return start, end, nil
+}
+
+func mapToPayPeriodResponse(p *domain.PayPeriod) *dto.PayPeriodResponse {
+	return &dto.PayPeriodResponse{
+		ID:             p.ID,
+		StartDate:      p.StartDate.Format(dateLayout),
+		EndDate:        p.EndDate.Format(dateLayout),
+		Status:         string(p.Status),
+		Note:           p.Note,
+		LockedBy:       p.LockedBy,
+		LockedAt:       p.LockedAt,
+		UnlockedBy:     p.UnlockedBy,
+		UnlockedAt:     p.UnlockedAt,
+		LastExportedAt: p.LastExportedAt,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
+
+// payPeriodGuard menolak perubahan attendance pada tanggal yang pay period-nya sudah dikunci.
+// Dipakai oleh semua usecase yang menulis attendance.
+type payPeriodGuard struct {
+	repo repository.PayrollRepository
+}
+
+// check memastikan hari kerja day tidak berada di pay period terkunci
+func (g payPeriodGuard) check(day time.Time) error {
+	return g.checkRange(day, day)
+}
+
+// checkRange memastikan tidak ada tanggal di [start, end] yang berada di pay period terkunci
+func (g payPeriodGuard) checkRange(start, end time.Time) error {
+	period, err := g.repo.FindLockedPayPeriod(start, end, nil)
+	if err != nil {
+		return err
+	}
+	if period != nil {
+		return fmt.Errorf("pay period is locked")
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"math"
+	"time"
+)
+
+// Label punctuality yang dikirim ke client
+const (
+	punctualityNA         = "N/A"
+	punctualityOnTime     = "On Time"
+	punctualityLate       = "Late"
+	punctualityEarlyLeave = "Early Leave"
+	punctualityAutoClosed = "Auto Closed"
+	punctualityOnLeave    = "On Leave"
+	punctualityAbsent     = "Absent"
+)
+
+// attendanceDay adalah data satu hari kerja yang dinilai oleh punctualityRule
+type attendanceDay struct {
+	WorkDate     time.Time // tanggal hari kerja (tanggal mulai shift)
+	ClockIn      *time.Time
+	ClockOut     *time.Time
+	AutoClosed   bool
+	BreakSeconds int
+	Schedule     *workSchedule
+}
+
+// workedMinutes adalah durasi kerja bersih (tanpa istirahat), 0 jika belum clock out
+func (d attendanceDay) workedMinutes() int {
+	if d.ClockIn == nil || d.ClockOut == nil {
+		return 0
+	}
+	return max((int(d.ClockOut.Sub(*d.ClockIn).Seconds())-d.BreakSeconds)/60, 0)
+}
+
+// punctualityRule menilai ketepatan waktu satu hari kerja. Setiap rule mengembalikan
+// menit terlambat dan menit pulang cepat; 0 berarti tepat waktu. Ini satu-satunya tempat
+// perhitungan menit; kolom late_minutes/early_leave_minutes/worked_minutes di attendances
+// hanya salinan yang ditulis job punctuality untuk filter, sort dan agregasi di SQL.
+type punctualityRule interface {
+	LateMinutes(day attendanceDay) int
+	EarlyLeaveMinutes(day attendanceDay) int
+}
+
+// punctualitySettings adalah konfigurasi rule departemen
+type punctualitySettings struct {
+	Rule                domain.PunctualityRule
+	GraceMinutes        int // grace departemen, dipakai flexitime
+	CoreStartTime       *time.Time
+	CoreEndTime         *time.Time
+	MinWorkMinutes      int
+	WeekdayGraceMinutes map[int]int
+}
+
+func punctualityFromDepartment(dept *domain.Department) punctualitySettings {
+	if dept == nil {
+		return punctualitySettings{Rule: domain.PunctualityFixedWindow}
+	}
+	return punctualitySettings{
+		Rule:                dept.PunctualityRule,
+		GraceMinutes:        dept.GraceMinutes,
+		CoreStartTime:       dept.CoreStartTime,
+		CoreEndTime:         dept.CoreEndTime,
+		MinWorkMinutes:      dept.MinWorkMinutes,
+		WeekdayGraceMinutes: dept.WeekdayGraceMinutes,
+	}
+}
+
+func punctualityFromRawLog(raw dto.RawAttendanceLog) punctualitySettings {
+	return punctualitySettings{
+		Rule:                domain.PunctualityRule(raw.PunctualityRule),
+		GraceMinutes:        raw.DepartmentGraceMinutes,
+		CoreStartTime:       raw.CoreStartTime,
+		CoreEndTime:         raw.CoreEndTime,
+		MinWorkMinutes:      raw.MinWorkMinutes,
+		WeekdayGraceMinutes: raw.WeekdayGraceMinutes,
+	}
+}
+
+// graceFor mengembalikan toleransi terlambat untuk hari tersebut: override per weekday
+// departemen jika ada, selain itu fallback
+func (s punctualitySettings) graceFor(day time.Time, fallback int) int {
+	if grace, ok := s.WeekdayGraceMinutes[int(day.Weekday())]; ok {
+		return grace
+	}
+	return fallback
+}
+
+// newPunctualityRule memilih rule sesuai konfigurasi departemen, default fixed window
+func newPunctualityRule(s punctualitySettings) punctualityRule {
+	switch s.Rule {
+	case domain.PunctualityFlexitime:
+		if s.CoreStartTime != nil && s.CoreEndTime != nil {
+			return &flexitimeRule{settings: s}
+		}
+	case domain.PunctualityMinimumDuration:
+		return &minimumDurationRule{minWorkMinutes: s.MinWorkMinutes}
+	}
+	return &fixedWindowRule{settings: s}
+}
+
+// fixedWindowRule: terlambat jika masuk setelah jam masuk + grace, pulang cepat jika
+// keluar sebelum jam pulang jadwal (shift atau departemen)
+type fixedWindowRule struct {
+	settings punctualitySettings
+}
+
+func (r *fixedWindowRule) LateMinutes(day attendanceDay) int {
+	workDate := dateIn(day.WorkDate, day.ClockIn.Location())
+	grace := r.settings.graceFor(workDate, day.Schedule.GraceMinutes)
+	return lateMinutes(*day.ClockIn, on(workDate, day.Schedule.Start), grace)
+}
+
+func (r *fixedWindowRule) EarlyLeaveMinutes(day attendanceDay) int {
+	// Target clock out dihitung dari hari kerja, bukan tanggal clock out,
+	// supaya shift yang melewati tengah malam tetap benar
+	return earlyLeaveMinutes(*day.ClockOut, day.Schedule.TargetOut(dateIn(day.WorkDate, day.ClockOut.Location())))
+}
+
+// flexitimeRule: jam masuk bebas asalkan hadir selama core hours departemen
+type flexitimeRule struct {
+	settings punctualitySettings
+}
+
+func (r *flexitimeRule) LateMinutes(day attendanceDay) int {
+	workDate := dateIn(day.WorkDate, day.ClockIn.Location())
+	grace := r.settings.graceFor(workDate, r.settings.GraceMinutes)
+	return lateMinutes(*day.ClockIn, on(workDate, *r.settings.CoreStartTime), grace)
+}
+
+func (r *flexitimeRule) EarlyLeaveMinutes(day attendanceDay) int {
+	core := workSchedule{Start: *r.settings.CoreStartTime, End: *r.settings.CoreEndTime}
+	return earlyLeaveMinutes(*day.ClockOut, core.TargetOut(dateIn(day.WorkDate, day.ClockOut.Location())))
+}
+
+// minimumDurationRule: tidak ada keterlambatan, yang dinilai hanya durasi kerja bersih
+type minimumDurationRule struct {
+	minWorkMinutes int
+}
+
+func (r *minimumDurationRule) LateMinutes(day attendanceDay) int {
+	return 0
+}
+
+func (r *minimumDurationRule) EarlyLeaveMinutes(day attendanceDay) int {
+	return max(r.minWorkMinutes-day.workedMinutes(), 0)
+}
+
+// lateMinutes menghitung keterlambatan dari jam masuk, hanya jika melewati grace period
+func lateMinutes(clockIn, start time.Time, grace int) int {
+	if !clockIn.After(start.Add(time.Duration(grace) * time.Minute)) {
+		return 0
+	}
+	return int(math.Ceil(clockIn.Sub(start).Minutes()))
+}
+
+func earlyLeaveMinutes(clockOut, target time.Time) int {
+	if !clockOut.Before(target) {
+		return 0
+	}
+	return int(math.Ceil(target.Sub(clockOut).Minutes()))
+}
+
+// punctualityResult adalah hasil penilaian yang dikirim ke client
+type punctualityResult struct {
+	In                string
+	Out               string
+	LateMinutes       int
+	EarlyLeaveMinutes int
+	WorkedMinutes     *int // nil jika belum clock out
+}
+
+// evaluatePunctuality menilai satu hari kerja dengan rule departemen. Hari di luar jadwal
+// tidak dinilai, dan clock out otomatis oleh sistem tidak dianggap clock out asli.
+func evaluatePunctuality(rule punctualityRule, status domain.AttendanceStatus, day attendanceDay) punctualityResult {
+	result := punctualityResult{In: punctualityNA, Out: punctualityNA}
+	if day.ClockIn != nil && day.ClockOut != nil {
+		worked := day.workedMinutes()
+		result.WorkedMinutes = &worked
+	}
+	switch status {
+	case domain.AttendanceStatusOnLeave:
+		result.In, result.Out = punctualityOnLeave, punctualityOnLeave
+	case domain.AttendanceStatusAbsent:
+		result.In, result.Out = punctualityAbsent, punctualityAbsent
+	}
+	if day.Schedule == nil || !day.Schedule.Scheduled {
+		if day.AutoClosed {
+			result.Out = punctualityAutoClosed
+		}
+		return result
+	}
+
+	if day.ClockIn != nil {
+		result.LateMinutes = rule.LateMinutes(day)
+		result.In = punctualityOnTime
+		if result.LateMinutes > 0 {
+			result.In = punctualityLate
+		}
+	}
+	if day.ClockOut != nil {
+		if day.AutoClosed {
+			result.Out = punctualityAutoClosed
+			return result
+		}
+		result.EarlyLeaveMinutes = rule.EarlyLeaveMinutes(day)
+		result.Out = punctualityOnTime
+		if result.EarlyLeaveMinutes > 0 {
+			result.Out = punctualityEarlyLeave
+		}
+	}
+	return result
+}
+
+// evaluateRawLog menilai satu baris GetAttendanceQuery dengan rule departemennya
+func evaluateRawLog(raw dto.RawAttendanceLog, schedule *workSchedule) punctualityResult {
+	return evaluatePunctuality(newPunctualityRule(punctualityFromRawLog(raw)), domain.AttendanceStatus(raw.Status), attendanceDay{
+		WorkDate:     raw.WorkDate,
+		ClockIn:      raw.ClockIn,
+		ClockOut:     raw.ClockOut,
+		AutoClosed:   raw.AutoClosed,
+		BreakSeconds: raw.BreakSeconds,
+		Schedule:     schedule,
+	})
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"testing"
+	"time"
+)
+
+// clock membuat jam tanpa tanggal, sama seperti kolom time di database
+func clock(hour, minute, second int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, second, 0, time.UTC)
+}
+
+// at membuat timestamp jam dinding pada tanggal September 2025
+func at(day, hour, minute, second int) *time.Time {
+	t := time.Date(2025, time.September, day, hour, minute, second, 0, time.UTC)
+	return &t
+}
+
+type punctualityCase struct {
+	name       string
+	settings   punctualitySettings
+	schedule   *workSchedule
+	status     domain.AttendanceStatus
+	workDay    int // 1 September 2025 adalah hari Senin
+	clockIn    *time.Time
+	clockOut   *time.Time
+	autoClosed bool
+	breakSecs  int
+	want       punctualityResult
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func runPunctualityCases(t *testing.T, cases []punctualityCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := tc.status
+			if status == "" {
+				status = domain.AttendanceStatusPresent
+			}
+			got := evaluatePunctuality(newPunctualityRule(tc.settings), status, attendanceDay{
+				WorkDate:     time.Date(2025, time.September, tc.workDay, 0, 0, 0, 0, time.UTC),
+				ClockIn:      tc.clockIn,
+				ClockOut:     tc.clockOut,
+				AutoClosed:   tc.autoClosed,
+				BreakSeconds: tc.breakSecs,
+				Schedule:     tc.schedule,
+			})
+			if got.In != tc.want.In || got.Out != tc.want.Out ||
+				got.LateMinutes != tc.want.LateMinutes || got.EarlyLeaveMinutes != tc.want.EarlyLeaveMinutes {
+				t.Errorf("got in=%q out=%q late=%d early=%d, want in=%q out=%q late=%d early=%d",
+					got.In, got.Out, got.LateMinutes, got.EarlyLeaveMinutes,
+					tc.want.In, tc.want.Out, tc.want.LateMinutes, tc.want.EarlyLeaveMinutes)
+			}
+			if (got.WorkedMinutes == nil) != (tc.want.WorkedMinutes == nil) ||
+				(got.WorkedMinutes != nil && *got.WorkedMinutes != *tc.want.WorkedMinutes) {
+				t.Errorf("worked minutes: got %v, want %v", got.WorkedMinutes, tc.want.WorkedMinutes)
+			}
+		})
+	}
+}
+
+func TestFixedWindowRule(t *testing.T) {
+	fixed := punctualitySettings{Rule: domain.PunctualityFixedWindow}
+	day := &workSchedule{Start: clock(8, 0, 0), End: clock(17, 0, 0), GraceMinutes: 10, Scheduled: true}
+	night := &workSchedule{Start: clock(22, 0, 0), End: clock(6, 0, 0), GraceMinutes: 5, Scheduled: true}
+
+	runPunctualityCases(t, []punctualityCase{
+		{
+			name: "on time", settings: fixed, schedule: day, workDay: 1,
+			clockIn: at(1, 7, 55, 0), clockOut: at(1, 17, 0, 0),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityOnTime, WorkedMinutes: intPtr(545)},
+		},
+		{
+			name: "exactly at grace boundary", settings: fixed, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 10, 0),
+			want:    punctualityResult{In: punctualityOnTime, Out: punctualityNA},
+		},
+		{
+			name: "one second past grace counts from shift start", settings: fixed, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 10, 1),
+			want:    punctualityResult{In: punctualityLate, Out: punctualityNA, LateMinutes: 11},
+		},
+		{
+			name: "early leave rounds up", settings: fixed, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 0, 0), clockOut: at(1, 16, 44, 30),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityEarlyLeave, EarlyLeaveMinutes: 16, WorkedMinutes: intPtr(524)},
+		},
+		{
+			name: "weekday grace override", workDay: 1, schedule: day,
+			settings: punctualitySettings{Rule: domain.PunctualityFixedWindow, WeekdayGraceMinutes: map[int]int{int(time.Monday): 30}},
+			clockIn:  at(1, 8, 30, 0),
+			want:     punctualityResult{In: punctualityOnTime, Out: punctualityNA},
+		},
+		{
+			name: "weekday grace override only on that weekday", workDay: 2, schedule: day,
+			settings: punctualitySettings{Rule: domain.PunctualityFixedWindow, WeekdayGraceMinutes: map[int]int{int(time.Monday): 30}},
+			clockIn:  at(2, 8, 30, 0),
+			want:     punctualityResult{In: punctualityLate, Out: punctualityNA, LateMinutes: 30},
+		},
+		{
+			name: "overnight shift clock out next day on time", settings: fixed, schedule: night, workDay: 1,
+			clockIn: at(1, 22, 5, 0), clockOut: at(2, 6, 0, 0),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityOnTime, WorkedMinutes: intPtr(475)},
+		},
+		{
+			name: "overnight shift early leave after midnight", settings: fixed, schedule: night, workDay: 1,
+			clockIn: at(1, 22, 5, 1), clockOut: at(2, 5, 30, 0),
+			want: punctualityResult{In: punctualityLate, Out: punctualityEarlyLeave, LateMinutes: 6, EarlyLeaveMinutes: 30, WorkedMinutes: intPtr(444)},
+		},
+		{
+			name: "overnight shift clock in after midnight is late", settings: fixed, schedule: night, workDay: 1,
+			clockIn: at(2, 0, 30, 0),
+			want:    punctualityResult{In: punctualityLate, Out: punctualityNA, LateMinutes: 150},
+		},
+		{
+			name: "auto closed is not early leave", settings: fixed, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 0, 0), clockOut: at(1, 12, 0, 0), autoClosed: true,
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityAutoClosed, WorkedMinutes: intPtr(240)},
+		},
+		{
+			name: "unscheduled day is not evaluated", settings: fixed, workDay: 6,
+			schedule: &workSchedule{Start: clock(8, 0, 0), End: clock(17, 0, 0), Scheduled: false},
+			clockIn:  at(6, 9, 0, 0), clockOut: at(6, 12, 0, 0),
+			want: punctualityResult{In: punctualityNA, Out: punctualityNA, WorkedMinutes: intPtr(180)},
+		},
+		{
+			name: "absent", settings: fixed, schedule: day, workDay: 1, status: domain.AttendanceStatusAbsent,
+			want: punctualityResult{In: punctualityAbsent, Out: punctualityAbsent},
+		},
+	})
+}
+
+func TestFlexitimeRule(t *testing.T) {
+	flex := punctualitySettings{
+		Rule:          domain.PunctualityFlexitime,
+		GraceMinutes:  5,
+		CoreStartTime: ptrTime(clock(10, 0, 0)),
+		CoreEndTime:   ptrTime(clock(15, 0, 0)),
+	}
+	overnightCore := punctualitySettings{
+		Rule:          domain.PunctualityFlexitime,
+		CoreStartTime: ptrTime(clock(23, 0, 0)),
+		CoreEndTime:   ptrTime(clock(3, 0, 0)),
+	}
+	// Jadwal shift tidak dipakai flexitime selain untuk menentukan hari kerja
+	day := &workSchedule{Start: clock(8, 0, 0), End: clock(17, 0, 0), GraceMinutes: 60, Scheduled: true}
+
+	runPunctualityCases(t, []punctualityCase{
+		{
+			name: "inside core hours", settings: flex, schedule: day, workDay: 1,
+			clockIn: at(1, 9, 30, 0), clockOut: at(1, 15, 0, 0),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityOnTime, WorkedMinutes: intPtr(330)},
+		},
+		{
+			name: "exactly at department grace", settings: flex, schedule: day, workDay: 1,
+			clockIn: at(1, 10, 5, 0),
+			want:    punctualityResult{In: punctualityOnTime, Out: punctualityNA},
+		},
+		{
+			name: "past department grace ignores shift grace", settings: flex, schedule: day, workDay: 1,
+			clockIn: at(1, 10, 5, 30),
+			want:    punctualityResult{In: punctualityLate, Out: punctualityNA, LateMinutes: 6},
+		},
+		{
+			name: "leaves before core end", settings: flex, schedule: day, workDay: 1,
+			clockIn: at(1, 7, 0, 0), clockOut: at(1, 14, 30, 0),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityEarlyLeave, EarlyLeaveMinutes: 30, WorkedMinutes: intPtr(450)},
+		},
+		{
+			name: "weekday grace override", workDay: 5, schedule: day,
+			settings: punctualitySettings{
+				Rule:                domain.PunctualityFlexitime,
+				GraceMinutes:        5,
+				CoreStartTime:       ptrTime(clock(10, 0, 0)),
+				CoreEndTime:         ptrTime(clock(15, 0, 0)),
+				WeekdayGraceMinutes: map[int]int{int(time.Friday): 0},
+			},
+			clockIn: at(5, 10, 1, 0),
+			want:    punctualityResult{In: punctualityLate, Out: punctualityNA, LateMinutes: 1},
+		},
+		{
+			name: "overnight core hours", settings: overnightCore, schedule: day, workDay: 1,
+			clockIn: at(1, 22, 0, 0), clockOut: at(2, 2, 0, 0),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityEarlyLeave, EarlyLeaveMinutes: 60, WorkedMinutes: intPtr(240)},
+		},
+		{
+			name: "missing core hours falls back to fixed window", workDay: 1, schedule: day,
+			settings: punctualitySettings{Rule: domain.PunctualityFlexitime, GraceMinutes: 5},
+			clockIn:  at(1, 9, 0, 1),
+			want:     punctualityResult{In: punctualityLate, Out: punctualityNA, LateMinutes: 61},
+		},
+	})
+}
+
+func TestMinimumDurationRule(t *testing.T) {
+	minimum := punctualitySettings{Rule: domain.PunctualityMinimumDuration, MinWorkMinutes: 480}
+	day := &workSchedule{Start: clock(8, 0, 0), End: clock(17, 0, 0), Scheduled: true}
+	night := &workSchedule{Start: clock(22, 0, 0), End: clock(6, 0, 0), Scheduled: true}
+
+	runPunctualityCases(t, []punctualityCase{
+		{
+			name: "late arrival is never late", settings: minimum, schedule: day, workDay: 1,
+			clockIn: at(1, 11, 0, 0), clockOut: at(1, 19, 0, 0),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityOnTime, WorkedMinutes: intPtr(480)},
+		},
+		{
+			name: "breaks are not worked time", settings: minimum, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 0, 0), clockOut: at(1, 16, 0, 0), breakSecs: 1800,
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityEarlyLeave, EarlyLeaveMinutes: 30, WorkedMinutes: intPtr(450)},
+		},
+		{
+			name: "partial minute is not worked", settings: minimum, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 0, 0), clockOut: at(1, 15, 59, 59),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityEarlyLeave, EarlyLeaveMinutes: 1, WorkedMinutes: intPtr(479)},
+		},
+		{
+			name: "overnight duration", settings: minimum, schedule: night, workDay: 1,
+			clockIn: at(1, 22, 30, 0), clockOut: at(2, 6, 30, 0),
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityOnTime, WorkedMinutes: intPtr(480)},
+		},
+		{
+			name: "auto closed", settings: minimum, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 0, 0), clockOut: at(1, 10, 0, 0), autoClosed: true,
+			want: punctualityResult{In: punctualityOnTime, Out: punctualityAutoClosed, WorkedMinutes: intPtr(120)},
+		},
+		{
+			name: "still clocked in", settings: minimum, schedule: day, workDay: 1,
+			clockIn: at(1, 8, 0, 0),
+			want:    punctualityResult{In: punctualityOnTime, Out: punctualityNA},
+		},
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
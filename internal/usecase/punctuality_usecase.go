@@ -0,0 +1,64 @@
+// punctuality_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// punctualityBatchSize membatasi jumlah attendance yang dihitung ulang per run job
+const punctualityBatchSize = 500
+
+// PunctualityUseCase menyimpan hasil punctualityRule ke attendances supaya filter, sort dan
+// dashboard di SQL memakai angka yang sama dengan response API
+type PunctualityUseCase interface {
+	RecomputeStale(ctx context.Context, now time.Time) (int, error)
+}
+
+type punctualityUseCase struct {
+	repo repository.AttendanceRepository
+	log  *logrus.Logger
+}
+
+func NewPunctualityUseCase(repo repository.AttendanceRepository, log *logrus.Logger) PunctualityUseCase {
+	return &punctualityUseCase{repo: repo, log: log}
+}
+
+// RecomputeStale menghitung ulang attendance yang belum dinilai atau yang ditandai ulang
+// karena attendance, shift atau departemennya berubah
+func (u *punctualityUseCase) RecomputeStale(ctx context.Context, now time.Time) (int, error) {
+	var rows []dto.RawAttendanceLog
+	if err := u.repo.GetAttendanceQuery().
+		Where("a.clock_in IS NOT NULL AND a.punctuality_evaluated_at IS NULL").
+		Order("a.clock_in").
+		Limit(punctualityBatchSize).
+		Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+
+	saved := 0
+	for _, raw := range rows {
+		if err := ctx.Err(); err != nil {
+			return saved, err
+		}
+		schedule, err := scheduleFromRawLog(raw)
+		if err != nil {
+			// Departemen tanpa jadwal dinilai tanpa jadwal (0 menit) supaya tidak diambil terus di setiap run
+			u.log.WithField("attendance_id", raw.AttendanceID).WithError(err).Warn("Evaluating punctuality without schedule")
+			schedule = nil
+		}
+		result := evaluateRawLog(raw, schedule)
+		ok, err := u.repo.SavePunctuality(raw.AttendanceID, result.LateMinutes, result.EarlyLeaveMinutes, result.WorkedMinutes, now)
+		if err != nil {
+			return saved, err
+		}
+		if ok {
+			saved++
+		}
+	}
+	return saved, nil
+}
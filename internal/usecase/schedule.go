@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"fmt"
+	"time"
+)
+
+// maxShiftDuration adalah batas waktu sebuah attendance yang masih open boleh
+// ditutup oleh ClockOut (mis. shift malam 22:00 - 06:00 di hari berikutnya).
+const maxShiftDuration = 24 * time.Hour
+
+// workSchedule adalah jam kerja efektif untuk satu hari kerja, diambil dari
+// shift karyawan atau fallback ke MaxClockInTime/MaxClockOutTime departemen.
+type workSchedule struct {
+	ShiftName    string
+	Start        time.Time // hanya jam
+	End          time.Time // hanya jam
+	GraceMinutes int
+	Scheduled    bool // false jika shift tidak berlaku di hari tersebut
+}
+
+func scheduleFromRawLog(raw dto.RawAttendanceLog) (*workSchedule, error) {
+	if raw.ShiftStartTime != nil && raw.ShiftEndTime != nil {
+		s := &workSchedule{
+			Start:     *raw.ShiftStartTime,
+			End:       *raw.ShiftEndTime,
+			Scheduled: raw.ShiftApplies != nil && *raw.ShiftApplies,
+		}
+		if raw.ShiftName != nil {
+			s.ShiftName = *raw.ShiftName
+		}
+		if raw.ShiftGraceMinutes != nil {
+			s.GraceMinutes = *raw.ShiftGraceMinutes
+		}
+		return s, nil
+	}
+
+	if raw.MaxClockInTime == nil {
+		return nil, fmt.Errorf("konfigurasi 'MaxClockInTime' untuk departemen '%s' tidak ditemukan", raw.DepartmentName)
+	}
+	if raw.MaxClockOutTime == nil {
+		return nil, fmt.Errorf("konfigurasi 'MaxClockOutTime' untuk departemen '%s' tidak ditemukan", raw.DepartmentName)
+	}
+	return &workSchedule{
+		Start:        *raw.MaxClockInTime,
+		End:          *raw.MaxClockOutTime,
+		GraceMinutes: raw.DepartmentGraceMinutes,
+		Scheduled:    true,
+	}, nil
+}
+
+func scheduleFromShift(shift *domain.Shift, day time.Time) *workSchedule {
+	return &workSchedule{
+		ShiftName:    shift.Name,
+		Start:        shift.StartTime,
+		End:          shift.EndTime,
+		GraceMinutes: shift.GraceMinutes,
+		Scheduled:    shift.AppliesOn(day.Weekday()),
+	}
+}
+
+func scheduleFromDepartment(dept *domain.Department) *workSchedule {
+	return &workSchedule{
+		Start:        dept.MaxClockInTime,
+		End:          dept.MaxClockOutTime,
+		GraceMinutes: dept.GraceMinutes,
+		Scheduled:    true,
+	}
+}
+
+// on menggabungkan tanggal dari day dengan jam t
+func on(day time.Time, t time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), 0, day.Location())
+}
+
+// dateOf membuang komponen jam dari t
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// dateIn memindahkan tanggal d (tanpa jam) ke zona waktu loc tanpa menggeser harinya
+func dateIn(d time.Time, loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// wallClock mengembalikan jam dinding t dalam UTC, sama seperti kolom timestamp
+// (tanpa zona) yang dibaca dari database, supaya keduanya bisa dibandingkan
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func secondsOfDay(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+}
+
+// Overnight bernilai true jika jam pulang jatuh di hari berikutnya (mis. 22:00 - 06:00)
+func (s *workSchedule) Overnight() bool {
+	return secondsOfDay(s.End) <= secondsOfDay(s.Start)
+}
+
+// TargetIn adalah batas clock in (termasuk grace period) pada hari kerja workDate
+func (s *workSchedule) TargetIn(workDate time.Time) time.Time {
+	return on(workDate, s.Start).Add(time.Duration(s.GraceMinutes) * time.Minute)
+}
+
+// TargetOut adalah jam pulang untuk hari kerja workDate, digeser ke hari
+// berikutnya untuk shift yang melewati tengah malam
+func (s *workSchedule) TargetOut(workDate time.Time) time.Time {
+	out := on(workDate, s.End)
+	if s.Overnight() {
+		out = out.AddDate(0, 0, 1)
+	}
+	return out
+}
+
+func attendanceIDFor(employeeCode string, workDate time.Time) string {
+	return fmt.Sprintf("%s-%s", employeeCode, workDate.Format("2006-01-02"))
+}
+
+// workDateOf mengembalikan hari kerja attendance; record lama tanpa work_date memakai tanggal clock in
+func workDateOf(attendance *domain.Attendance) time.Time {
+	if attendance.WorkDate != nil {
+		return *attendance.WorkDate
+	}
+	if attendance.ClockIn != nil {
+		return dateOf(*attendance.ClockIn)
+	}
+	return dateOf(attendance.CreatedAt)
+}
@@ -0,0 +1,273 @@
+// shift_usecase.go
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ShiftUseCase interface {
+	CreateShift(ctx context.Context, req dto.CreateShiftRequest) (*dto.ShiftResponse, error)
+	GetShift(ctx context.Context, id uuid.UUID) (*dto.ShiftResponse, error)
+	UpdateShift(ctx context.Context, id uuid.UUID, req dto.UpdateShiftRequest) (*dto.ShiftResponse, error)
+	DeleteShift(ctx context.Context, id uuid.UUID) error
+	GetShifts(ctx context.Context, page, limit int) ([]*dto.ShiftResponse, int64, error)
+
+	AssignShift(ctx context.Context, req dto.AssignShiftRequest) (*dto.ShiftAssignmentResponse, error)
+	GetShiftAssignments(ctx context.Context, req dto.GetShiftAssignmentsRequest) ([]*dto.ShiftAssignmentResponse, int64, error)
+	DeleteShiftAssignment(ctx context.Context, id uuid.UUID) error
+}
+
+type shiftUseCase struct {
+	repo     repository.ShiftRepository
+	userRepo repository.UserRepository
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewShiftUseCase(repo repository.ShiftRepository, userRepo repository.UserRepository, log *logrus.Logger, validate *validator.Validate) ShiftUseCase {
+	return &shiftUseCase{repo: repo, userRepo: userRepo, log: log, validate: validate}
+}
+
+const (
+	timeOfDayLayout = "15:04:05"
+	dateLayout      = "2006-01-02"
+)
+
+func (u *shiftUseCase) CreateShift(ctx context.Context, req dto.CreateShiftRequest) (*dto.ShiftResponse, error) {
+	start, err := time.Parse(timeOfDayLayout, req.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_time: %w", err)
+	}
+	end, err := time.Parse(timeOfDayLayout, req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_time: %w", err)
+	}
+	if start.Equal(end) {
+		return nil, fmt.Errorf("start_time and end_time cannot be equal")
+	}
+
+	shift := &domain.Shift{
+		Name:         req.Name,
+		StartTime:    start,
+		EndTime:      end,
+		GraceMinutes: req.GraceMinutes,
+		Weekdays:     normalizeWeekdays(req.Weekdays),
+	}
+	if err := u.repo.CreateShift(shift); err != nil {
+		return nil, err
+	}
+	return mapToShiftResponse(shift), nil
+}
+
+func (u *shiftUseCase) GetShift(ctx context.Context, id uuid.UUID) (*dto.ShiftResponse, error) {
+	shift, err := u.repo.FindShiftByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToShiftResponse(shift), nil
+}
+
+func (u *shiftUseCase) UpdateShift(ctx context.Context, id uuid.UUID, req dto.UpdateShiftRequest) (*dto.ShiftResponse, error) {
+	shift, err := u.repo.FindShiftByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		shift.Name = req.Name
+	}
+	if req.StartTime != "" {
+		start, err := time.Parse(timeOfDayLayout, req.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_time: %w", err)
+		}
+		shift.StartTime = start
+	}
+	if req.EndTime != "" {
+		end, err := time.Parse(timeOfDayLayout, req.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_time: %w", err)
+		}
+		shift.EndTime = end
+	}
+	if req.GraceMinutes != nil {
+		shift.GraceMinutes = *req.GraceMinutes
+	}
+	if len(req.Weekdays) > 0 {
+		shift.Weekdays = normalizeWeekdays(req.Weekdays)
+	}
+	if shift.StartTime.Format(timeOfDayLayout) == shift.EndTime.Format(timeOfDayLayout) {
+		return nil, fmt.Errorf("start_time and end_time cannot be equal")
+	}
+
+	if err := u.repo.UpdateShift(shift); err != nil {
+		return nil, err
+	}
+	return mapToShiftResponse(shift), nil
+}
+
+func (u *shiftUseCase) DeleteShift(ctx context.Context, id uuid.UUID) error {
+	return u.repo.DeleteShift(id)
+}
+
+func (u *shiftUseCase) GetShifts(ctx context.Context, page, limit int) ([]*dto.ShiftResponse, int64, error) {
+	offset := (page - 1) * limit
+	shifts, total, err := u.repo.FindAllShifts(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.ShiftResponse, len(shifts))
+	for i, s := range shifts {
+		res[i] = mapToShiftResponse(s)
+	}
+	return res, total, nil
+}
+
+func (u *shiftUseCase) AssignShift(ctx context.Context, req dto.AssignShiftRequest) (*dto.ShiftAssignmentResponse, error) {
+	profile, err := u.userRepo.FindUserProfileByUserID(req.UserID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	shift, err := u.repo.FindShiftByID(req.ShiftID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("shift not found")
+		}
+		return nil, err
+	}
+
+	from, err := time.Parse(dateLayout, req.EffectiveFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective_from: %w", err)
+	}
+	var to *time.Time
+	if req.EffectiveTo != "" {
+		t, err := time.Parse(dateLayout, req.EffectiveTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid effective_to: %w", err)
+		}
+		if t.Before(from) {
+			return nil, fmt.Errorf("effective_to cannot be before effective_from")
+		}
+		to = &t
+	}
+
+	overlaps, err := u.repo.FindOverlappingAssignments(profile.EmployeeCode, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assignment open-ended yang mulai sebelum `from` otomatis ditutup sehari sebelumnya,
+	// selain itu overlap dianggap konflik.
+	var closePrevious *domain.ShiftAssignment
+	for _, a := range overlaps {
+		if a.EffectiveTo == nil && a.EffectiveFrom.Before(from) && closePrevious == nil {
+			closePrevious = a
+			continue
+		}
+		return nil, fmt.Errorf("shift assignment overlaps an existing assignment")
+	}
+	if closePrevious != nil {
+		closedAt := from.AddDate(0, 0, -1)
+		closePrevious.EffectiveTo = &closedAt
+	}
+
+	assignment := &domain.ShiftAssignment{
+		EmployeeCode:  profile.EmployeeCode,
+		ShiftID:       shift.ID,
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		Shift:         shift,
+	}
+	if err := u.repo.CreateAssignment(assignment, closePrevious); err != nil {
+		return nil, err
+	}
+	return mapToShiftAssignmentResponse(assignment), nil
+}
+
+func (u *shiftUseCase) GetShiftAssignments(ctx context.Context, req dto.GetShiftAssignmentsRequest) ([]*dto.ShiftAssignmentResponse, int64, error) {
+	profile, err := u.userRepo.FindUserProfileByUserID(req.UserID)
+	if err != nil || profile == nil {
+		return nil, 0, fmt.Errorf("user not found")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	assignments, total, err := u.repo.FindAssignmentsByEmployeeCode(profile.EmployeeCode, offset, req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]*dto.ShiftAssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		res[i] = mapToShiftAssignmentResponse(a)
+	}
+	return res, total, nil
+}
+
+func (u *shiftUseCase) DeleteShiftAssignment(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.repo.FindAssignmentByID(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("shift assignment not found")
+		}
+		return err
+	}
+	return u.repo.DeleteAssignment(id)
+}
+
+func normalizeWeekdays(days []int) []int {
+	seen := make(map[int]bool)
+	res := make([]int, 0, len(days))
+	for _, d := range days {
+		if !seen[d] {
+			seen[d] = true
+			res = append(res, d)
+		}
+	}
+	sort.Ints(res)
+	return res
+}
+
+func mapToShiftResponse(s *domain.Shift) *dto.ShiftResponse {
+	if s == nil {
+		return nil
+	}
+	return &dto.ShiftResponse{
+		ID:           s.ID,
+		Name:         s.Name,
+		StartTime:    s.StartTime.Format(timeOfDayLayout),
+		EndTime:      s.EndTime.Format(timeOfDayLayout),
+		GraceMinutes: s.GraceMinutes,
+		Weekdays:     s.Weekdays,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+func mapToShiftAssignmentResponse(a *domain.ShiftAssignment) *dto.ShiftAssignmentResponse {
+	var to *string
+	if a.EffectiveTo != nil {
+		s := a.EffectiveTo.Format(dateLayout)
+		to = &s
+	}
+	return &dto.ShiftAssignmentResponse{
+		ID:            a.ID,
+		EmployeeCode:  a.EmployeeCode,
+		ShiftID:       a.ShiftID,
+		EffectiveFrom: a.EffectiveFrom.Format(dateLayout),
+		EffectiveTo:   to,
+		Shift:         mapToShiftResponse(a.Shift),
+		CreatedAt:     a.CreatedAt,
+	}
+}
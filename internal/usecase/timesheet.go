@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const monthLayout = "2006-01"
+
+// Jenis hari pada timesheet
+const (
+	dayTypeWorked    = "worked"
+	dayTypeAbsent    = "absent"
+	dayTypeLeave     = "leave"
+	dayTypeHoliday   = "holiday"
+	dayTypeWeekend   = "weekend"
+	dayTypeUpcoming  = "upcoming"
+	dayTypeNotJoined = "not_joined" // sebelum karyawan terdaftar
+)
+
+// GetTimesheet menyusun satu baris per tanggal dalam bulan untuk seorang karyawan. Hari kerja
+// yang sudah lewat tanpa record attendance dihitung absent meskipun job absensi belum berjalan.
+func (u *attendanceUseCase) GetTimesheet(ctx context.Context, userID uuid.UUID, req dto.GetTimesheetRequest) (*dto.TimesheetResponse, error) {
+	profile, err := u.profileRepo.FindUserProfileByUserID(userID)
+	if err != nil || profile == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	monthStart, err := time.Parse(monthLayout, req.Month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month")
+	}
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	var rawLogs []dto.RawAttendanceLog
+	if err := u.repo.GetAttendanceQuery().
+		Where("a.employee_code = ?", profile.EmployeeCode).
+		Where("COALESCE(a.work_date, DATE(a.clock_in)) BETWEEN ? AND ?", monthStart.Format(dateLayout), monthEnd.Format(dateLayout)).
+		Scan(&rawLogs).Error; err != nil {
+		return nil, err
+	}
+	logs := make(map[string]dto.AttendanceLogResponse, len(rawLogs))
+	for _, raw := range rawLogs {
+		log, err := u.buildAttendanceLog(raw)
+		if err != nil {
+			return nil, err
+		}
+		logs[log.WorkDate] = log
+	}
+
+	res := &dto.TimesheetResponse{
+		UserID:       userID,
+		EmployeeCode: profile.EmployeeCode,
+		FullName:     profile.FullName,
+		Month:        req.Month,
+		Days:         make([]dto.TimesheetDayResponse, 0, monthEnd.Day()),
+	}
+	if profile.Department != nil {
+		res.Department = profile.Department.Name
+	}
+
+	// Libur dan shift sebulan dimuat sekali, bukan query per tanggal
+	calendar, err := u.calendar.Load([]*domain.UserProfile{profile}, monthStart, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+	today := dateOf(wallClock(time.Now())) // bandingkan sebagai tanggal UTC seperti monthStart
+	joined := joinedDate(profile, time.UTC)
+	for day := monthStart; !day.After(monthEnd); day = day.AddDate(0, 0, 1) {
+		row := timesheetDay(calendar, profile, day, today, joined, logs)
+		res.Days = append(res.Days, row)
+		addTimesheetTotals(&res.Totals, row)
+	}
+	return res, nil
+}
+
+func timesheetDay(calendar *loadedCalendar, profile *domain.UserProfile, day, today, joined time.Time, logs map[string]dto.AttendanceLogResponse) dto.TimesheetDayResponse {
+	holiday := calendar.HolidayOn(profile, day)
+	// Hari sebelum karyawan terdaftar bukan hari kerja, supaya tidak dihitung absent
+	working := !day.Before(joined) && calendar.IsWorkingDay(profile, day)
+
+	row := dto.TimesheetDayResponse{
+		Date:           day.Format(dateLayout),
+		Weekday:        day.Weekday().String(),
+		WorkingDay:     working,
+		InPunctuality:  punctualityNA,
+		OutPunctuality: punctualityNA,
+	}
+	if holiday != nil {
+		row.HolidayName = &holiday.Name
+	}
+
+	log, ok := logs[row.Date]
+	if !ok {
+		switch {
+		case day.Before(joined):
+			row.DayType = dayTypeNotJoined
+		case holiday != nil:
+			row.DayType = dayTypeHoliday
+		case !working:
+			row.DayType = dayTypeWeekend
+		case day.Before(today):
+			row.DayType = dayTypeAbsent
+			row.InPunctuality, row.OutPunctuality = punctualityAbsent, punctualityAbsent
+		default:
+			row.DayType = dayTypeUpcoming
+		}
+		return row
+	}
+
+	switch domain.AttendanceStatus(log.Status) {
+	case domain.AttendanceStatusOnLeave:
+		row.DayType = dayTypeLeave
+	case domain.AttendanceStatusAbsent:
+		row.DayType = dayTypeAbsent
+	default:
+		row.DayType = dayTypeWorked
+	}
+	row.AttendanceID = &log.AttendanceID
+	row.ShiftName = log.ShiftName
+	row.Mode = log.Mode
+	row.ClockIn = log.ClockIn
+	row.ClockOut = log.ClockOut
+	if log.WorkedMinutes != nil {
+		row.WorkedMinutes = *log.WorkedMinutes
+	}
+	row.LateMinutes = log.LateMinutes
+	row.EarlyLeaveMinutes = log.EarlyLeaveMinutes
+	row.OvertimeMinutes = log.OvertimeMinutes
+	row.InPunctuality = log.InPunctuality
+	row.OutPunctuality = log.OutPunctuality
+	return row
+}
+
+func addTimesheetTotals(totals *dto.TimesheetTotalsResponse, row dto.TimesheetDayResponse) {
+	if row.WorkingDay {
+		totals.WorkingDays++
+	}
+	switch row.DayType {
+	case dayTypeWorked:
+		totals.WorkedDays++
+	case dayTypeAbsent:
+		totals.AbsentDays++
+	case dayTypeLeave:
+		totals.LeaveDays++
+	case dayTypeHoliday:
+		totals.HolidayDays++
+	case dayTypeWeekend:
+		totals.WeekendDays++
+	}
+	if row.LateMinutes > 0 {
+		totals.LateDays++
+	}
+	totals.WorkedMinutes += row.WorkedMinutes
+	totals.LateMinutes += row.LateMinutes
+	totals.EarlyLeaveMinutes += row.EarlyLeaveMinutes
+	totals.OvertimeMinutes += row.OvertimeMinutes
+}
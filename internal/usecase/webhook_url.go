@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// errWebhookURLPrefix mengawali semua error URL webhook yang ditolak (dipetakan controller ke 400)
+const errWebhookURLPrefix = "invalid webhook url"
+
+// sharedAddressSpace (100.64.0.0/10, CGNAT) tidak termasuk net.IP.IsPrivate tapi tetap bukan alamat publik
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP menolak alamat yang mengarah ke server ini atau jaringan internal: loopback,
+// private, link-local (termasuk metadata cloud 169.254.169.254), multicast dan unspecified
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// validateWebhookURL memastikan URL webhook memakai http(s) dan semua alamat host-nya publik.
+// Dicek ulang saat dial karena DNS bisa berubah setelah webhook dibuat.
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("%s: must be an absolute http or https url", errWebhookURLPrefix)
+	}
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", parsed.Hostname())
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("%s: host %s cannot be resolved", errWebhookURLPrefix, parsed.Hostname())
+	}
+	for _, ip := range ips {
+		if !isPublicIP(ip) {
+			return fmt.Errorf("%s: host %s resolves to a non-public address", errWebhookURLPrefix, parsed.Hostname())
+		}
+	}
+	return nil
+}
+
+// newWebhookClient membuat HTTP client yang hanya bisa terhubung ke alamat publik dan tidak
+// mengikuti redirect, supaya webhook tidak bisa dipakai membaca layanan internal (SSRF)
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		// Control dijalankan setelah DNS resolve, tepat sebelum connect, untuk setiap alamat yang dicoba
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("webhook destination %s is not a public address", host)
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		Proxy:                 nil, // Proxy akan membuat pengecekan alamat di atas tidak berlaku
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   webhookTimeout,
+		ResponseHeaderTimeout: webhookTimeout,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConnsPerHost:   webhookConcurrency,
+	}
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,408 @@
+// webhook_usecase.go
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"employee-attendance-system/internal/entity/domain"
+	"employee-attendance-system/internal/entity/dto"
+	"employee-attendance-system/internal/repository"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// WebhookPublisher mengirim event ke webhook subscription. Publish tidak pernah blocking.
+type WebhookPublisher interface {
+	Publish(eventType string, data any)
+}
+
+type WebhookUseCase interface {
+	WebhookPublisher
+	CreateWebhook(ctx context.Context, adminID uuid.UUID, req dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	GetWebhooks(ctx context.Context, page, limit int) ([]*dto.WebhookResponse, int64, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, req dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, req dto.ListWebhookDeliveriesRequest) ([]*dto.WebhookDeliveryResponse, int64, error)
+	ReplayDelivery(ctx context.Context, id uuid.UUID) (*dto.WebhookDeliveryResponse, error)
+	ReplayFailedDeliveries(ctx context.Context, webhookID uuid.UUID) (int, error)
+	DeliverDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// Kebijakan pengiriman: percobaan ke-n ditunda webhookBaseDelay * 2^(n-1), maksimal webhookMaxDelay
+const (
+	webhookMaxAttempts   = 8
+	webhookBaseDelay     = 30 * time.Second
+	webhookMaxDelay      = 6 * time.Hour
+	webhookTimeout       = 10 * time.Second
+	webhookClaimLease    = 2 * time.Minute // Harus lebih lama dari webhookTimeout
+	webhookBatchSize     = 50
+	webhookConcurrency   = 5
+	webhookMaxBodyLogged = 1024
+)
+
+type webhookUseCase struct {
+	repo     repository.WebhookRepository
+	client   *http.Client
+	log      *logrus.Logger
+	validate *validator.Validate
+}
+
+func NewWebhookUseCase(repo repository.WebhookRepository, log *logrus.Logger, validate *validator.Validate) WebhookUseCase {
+	return &webhookUseCase{repo: repo, client: newWebhookClient(), log: log, validate: validate}
+}
+
+func (u *webhookUseCase) CreateWebhook(ctx context.Context, adminID uuid.UUID, req dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := validateWebhookURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+	subscription := &domain.WebhookSubscription{
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: req.EventTypes,
+		IsActive:   true,
+		CreatedBy:  &adminID,
+	}
+	if err := u.repo.CreateSubscription(subscription); err != nil {
+		return nil, err
+	}
+	res := mapToWebhookResponse(subscription)
+	res.Secret = secret
+	return res, nil
+}
+
+func (u *webhookUseCase) GetWebhooks(ctx context.Context, page, limit int) ([]*dto.WebhookResponse, int64, error) {
+	subscriptions, total, err := u.repo.FindSubscriptions((page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.WebhookResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		res[i] = mapToWebhookResponse(s)
+	}
+	return res, total, nil
+}
+
+func (u *webhookUseCase) UpdateWebhook(ctx context.Context, id uuid.UUID, req dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	subscription, err := u.findSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		subscription.Name = req.Name
+	}
+	if req.URL != "" {
+		if err := validateWebhookURL(ctx, req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = req.URL
+	}
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = *req.EventTypes
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+	if err := u.repo.UpdateSubscription(subscription); err != nil {
+		return nil, err
+	}
+	res := mapToWebhookResponse(subscription)
+	res.Secret = req.Secret
+	return res, nil
+}
+
+func (u *webhookUseCase) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.findSubscription(id); err != nil {
+		return err
+	}
+	return u.repo.DeleteSubscription(id)
+}
+
+func (u *webhookUseCase) GetDeliveries(ctx context.Context, webhookID uuid.UUID, req dto.ListWebhookDeliveriesRequest) ([]*dto.WebhookDeliveryResponse, int64, error) {
+	if _, err := u.findSubscription(webhookID); err != nil {
+		return nil, 0, err
+	}
+	deliveries, total, err := u.repo.FindDeliveries(webhookID, req.Status, (req.Page-1)*req.Limit, req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		res[i] = mapToWebhookDeliveryResponse(d)
+	}
+	return res, total, nil
+}
+
+// ReplayDelivery membuat delivery baru dengan payload yang sama; delivery lama tetap di log
+func (u *webhookUseCase) ReplayDelivery(ctx context.Context, id uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := u.repo.FindDeliveryByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook delivery not found")
+		}
+		return nil, err
+	}
+	if delivery.Status != domain.WebhookDeliveryFailed {
+		return nil, fmt.Errorf("only failed deliveries can be replayed")
+	}
+	if _, err := u.findSubscription(delivery.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	replay := newReplayDelivery(delivery)
+	if err := u.repo.CreateDeliveries([]*domain.WebhookDelivery{replay}); err != nil {
+		return nil, err
+	}
+	return mapToWebhookDeliveryResponse(replay), nil
+}
+
+// ReplayFailedDeliveries me-replay semua delivery gagal milik satu subscription
+func (u *webhookUseCase) ReplayFailedDeliveries(ctx context.Context, webhookID uuid.UUID) (int, error) {
+	if _, err := u.findSubscription(webhookID); err != nil {
+		return 0, err
+	}
+	failed, err := u.repo.FindFailedDeliveries(webhookID)
+	if err != nil {
+		return 0, err
+	}
+	replays := make([]*domain.WebhookDelivery, len(failed))
+	for i, d := range failed {
+		replays[i] = newReplayDelivery(d)
+	}
+	if err := u.repo.CreateDeliveries(replays); err != nil {
+		return 0, err
+	}
+	return len(replays), nil
+}
+
+// Publish menyimpan delivery untuk setiap subscription aktif di goroutine terpisah,
+// sehingga request yang memicu event (clock in, signup, ...) tidak menunggu database atau HTTP
+func (u *webhookUseCase) Publish(eventType string, data any) {
+	payload := dto.WebhookPayload{ID: uuid.New(), Type: eventType, CreatedAt: time.Now(), Data: data}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		u.log.WithError(err).WithField("event_type", eventType).Error("Failed to encode webhook payload")
+		return
+	}
+	go func() {
+		if err := u.enqueue(payload.ID, eventType, string(body)); err != nil {
+			u.log.WithError(err).WithField("event_type", eventType).Error("Failed to enqueue webhook deliveries")
+		}
+	}()
+}
+
+func (u *webhookUseCase) enqueue(eventID uuid.UUID, eventType, body string) error {
+	subscriptions, err := u.repo.FindActiveSubscriptionsByEvent(eventType)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	deliveries := make([]*domain.WebhookDelivery, len(subscriptions))
+	for i, s := range subscriptions {
+		deliveries[i] = &domain.WebhookDelivery{
+			SubscriptionID: s.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        body,
+			Status:         domain.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+		}
+	}
+	return u.repo.CreateDeliveries(deliveries)
+}
+
+// DeliverDue mengirim delivery yang jatuh tempo (baru atau retry). Dijalankan berkala oleh scheduler.
+func (u *webhookUseCase) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	deliveries, err := u.repo.ClaimDueDeliveries(now, webhookClaimLease, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, webhookConcurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d *domain.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			u.attempt(ctx, d)
+			if err := u.repo.UpdateDelivery(d); err != nil {
+				u.log.WithError(err).WithField("delivery_id", d.ID).Error("Failed to save webhook delivery")
+			}
+		}(delivery)
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+// attempt mengirim satu delivery dan mencatat hasilnya; respons 2xx dianggap berhasil
+func (u *webhookUseCase) attempt(ctx context.Context, d *domain.WebhookDelivery) {
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.ResponseStatus = nil
+	d.ResponseBody = ""
+
+	status, body, err := u.send(ctx, d)
+	if status != 0 {
+		d.ResponseStatus = &status
+		d.ResponseBody = body
+	}
+	if err == nil && (status < 200 || status >= 300) {
+		err = fmt.Errorf("unexpected response status %d", status)
+	}
+	if err == nil {
+		d.Status = domain.WebhookDeliverySucceeded
+		d.DeliveredAt = &now
+		d.NextAttemptAt = nil
+		d.LastError = ""
+		return
+	}
+
+	d.LastError = err.Error()
+	// Subscription yang dihapus / dinonaktifkan tidak perlu dicoba ulang
+	if d.Attempts >= webhookMaxAttempts || d.Subscription == nil || !d.Subscription.IsActive {
+		d.Status = domain.WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		u.log.WithFields(logrus.Fields{"delivery_id": d.ID, "subscription_id": d.SubscriptionID}).WithError(err).Warn("Webhook delivery failed permanently")
+		return
+	}
+	next := now.Add(webhookBackoff(d.Attempts))
+	d.NextAttemptAt = &next
+}
+
+func (u *webhookUseCase) send(ctx context.Context, d *domain.WebhookDelivery) (int, string, error) {
+	s := d.Subscription
+	if s == nil {
+		return 0, "", fmt.Errorf("webhook subscription deleted")
+	}
+	if !s.IsActive {
+		return 0, "", fmt.Errorf("webhook subscription inactive")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewBufferString(d.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "employee-attendance-webhook/1.0")
+	req.Header.Set("X-Webhook-Id", d.EventID.String())
+	req.Header.Set("X-Webhook-Delivery", d.ID.String())
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhook(s.Secret, timestamp, d.Payload))
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxBodyLogged))
+	return resp.StatusCode, string(body), nil
+}
+
+// signWebhook menghitung HMAC-SHA256 dari "<timestamp>.<body>". Timestamp ikut ditandatangani
+// supaya subscriber bisa menolak request lama yang dikirim ulang pihak lain.
+func signWebhook(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	delay := webhookBaseDelay
+	for i := 1; i < attempts && delay < webhookMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookMaxDelay)
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func newReplayDelivery(d *domain.WebhookDelivery) *domain.WebhookDelivery {
+	now := time.Now()
+	return &domain.WebhookDelivery{
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         domain.WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+		ReplayOf:       &d.ID,
+	}
+}
+
+func (u *webhookUseCase) findSubscription(id uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, err := u.repo.FindSubscriptionByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook not found")
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func mapToWebhookResponse(s *domain.WebhookSubscription) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:         s.ID,
+		Name:       s.Name,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		IsActive:   s.IsActive,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func mapToWebhookDeliveryResponse(d *domain.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	return &dto.WebhookDeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		ReplayOf:       d.ReplayOf,
+		CreatedAt:      d.CreatedAt,
+	}
+}
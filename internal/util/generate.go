@@ -4,9 +4,19 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"math/rand"
+	"strings"
 	"time"
 )
 
+// placeholderSecretPrefix dipakai nilai contoh di config.json yang wajib diganti sebelum dijalankan
+const placeholderSecretPrefix = "change-me"
+
+// IsUsableSecret menolak secret kosong atau yang masih berisi placeholder dari config.json
+func IsUsableSecret(secret string) bool {
+	secret = strings.TrimSpace(secret)
+	return secret != "" && !strings.HasPrefix(secret, placeholderSecretPrefix)
+}
+
 func HashToken(token string) string {
 	h := sha256.New()
 	h.Write([]byte(token))
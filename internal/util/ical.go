@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ICalEvent adalah VEVENT hasil parsing file iCalendar (.ics)
+type ICalEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time // tanggal pertama (inklusif)
+	End     time.Time // tanggal terakhir (inklusif)
+}
+
+// ParseICalendar membaca VEVENT dari file .ics. Hanya DTSTART, DTEND, SUMMARY
+// dan UID yang dipakai; DTEND pada event all-day bersifat eksklusif (RFC 5545).
+func ParseICalendar(r io.Reader) ([]ICalEvent, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []ICalEvent
+		current *ICalEvent
+		endSet  bool
+		allDay  bool
+	)
+	for i, line := range lines {
+		name, params, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &ICalEvent{}
+			endSet = false
+			allDay = false
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", i+1)
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: VEVENT without DTSTART", i+1)
+			}
+			if !endSet {
+				current.End = current.Start
+			} else if allDay && current.End.After(current.Start) {
+				current.End = current.End.AddDate(0, 0, -1)
+			}
+			if current.End.Before(current.Start) {
+				current.End = current.Start
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeICalText(value)
+		case name == "DTSTART":
+			t, isDate, err := parseICalDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid DTSTART: %w", i+1, err)
+			}
+			current.Start = t
+			allDay = isDate
+		case name == "DTEND":
+			t, _, err := parseICalDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid DTEND: %w", i+1, err)
+			}
+			current.End = t
+			endSet = true
+		}
+	}
+	return events, nil
+}
+
+// unfoldICalLines menggabungkan baris lanjutan (diawali spasi/tab) ke baris sebelumnya
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+func splitICalLine(line string) (name string, params map[string]string, value string) {
+	params = make(map[string]string)
+	idx := strings.Index(line, ":")
+	if idx == -1 {
+		return strings.ToUpper(line), params, ""
+	}
+	head, value := line[:idx], line[idx+1:]
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	for _, p := range parts[1:] {
+		if kv := strings.SplitN(p, "=", 2); len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = kv[1]
+		}
+	}
+	return name, params, strings.TrimSpace(value)
+}
+
+// parseICalDate mengembalikan tanggal (tanpa jam) dari DTSTART/DTEND
+func parseICalDate(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+
+	loc := time.UTC
+	if tzid, ok := params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	layout := "20060102T150405"
+	if strings.HasSuffix(value, "Z") {
+		layout = "20060102T150405Z"
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), false, nil
+}
+
+func unescapeICalText(s string) string {
+	r := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return r.Replace(s)
+}